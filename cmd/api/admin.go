@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (app *application) adminRoutes() http.Handler {
+	router := httprouter.New()
+
+	router.NotFound = http.HandlerFunc(app.notFoundResponse)
+	router.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
+
+	router.Handler(http.MethodGet, "/metrics", app.metrics.registry.Handler())
+
+	router.HandlerFunc(http.MethodGet, "/log-level", app.showLogLevelHandler)
+	router.HandlerFunc(http.MethodPut, "/log-level", app.requireAPIKey(app.updateLogLevelHandler))
+
+	return app.recoverPanic(app.authenticate(router))
+}
+
+func (app *application) showLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	err := app.writeJSON(w, r, http.StatusOK, envelope{"level": app.logger.Level()}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) updateLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Level string `json:"level"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	level, err := jsonlog.ParseLevel(input.Level)
+
+	v := validator.New()
+	v.Check(input.Level != "", "level", "must be provided")
+	v.Check(input.Level == "" || err == nil, "level", "must be one of debug, info, warn, error, fatal, off")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	previous := app.logger.Level()
+
+	// Log the change while the more verbose of the two levels is set, so it
+	// is recorded whether logging is being turned up or down.
+	properties := jsonlog.Properties{"from": previous, "to": level}
+
+	if level < previous {
+		app.logger.SetLevel(level)
+		app.logger.PrintInfo("log level changed", properties)
+	} else {
+		app.logger.PrintInfo("log level changed", properties)
+		app.logger.SetLevel(level)
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"level": level}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+)
+
+func TestLogLevel(t *testing.T) {
+	app := newTestApplication(t)
+	admin := newTestServer(t, app.adminRoutes())
+
+	var body struct {
+		Level string `json:"level"`
+	}
+
+	res := admin.do(t, http.MethodGet, "/log-level", "", nil)
+	assertStatus(t, res, http.StatusOK)
+	res.decode(t, &body)
+
+	if body.Level != "off" {
+		t.Errorf("got level %q; want off", body.Level)
+	}
+
+	assertStatus(t, admin.do(t, http.MethodPut, "/log-level", `{"level": "debug"}`, nil), http.StatusUnauthorized)
+
+	if app.logger.Level() != jsonlog.LevelOff {
+		t.Errorf("got logger at %s after an unauthenticated update; want OFF", app.logger.Level())
+	}
+
+	auth := map[string]string{"Authorization": "Bearer test-key"}
+
+	res = admin.do(t, http.MethodPut, "/log-level", `{"level": "DEBUG"}`, auth)
+	assertStatus(t, res, http.StatusOK)
+	res.decode(t, &body)
+
+	if body.Level != "debug" || app.logger.Level() != jsonlog.LevelDebug {
+		t.Errorf("got level %q, logger at %s; want debug", body.Level, app.logger.Level())
+	}
+
+	assertStatus(t, admin.do(t, http.MethodPut, "/log-level", `{"level": "loud"}`, auth), http.StatusUnprocessableEntity)
+	assertStatus(t, admin.do(t, http.MethodPut, "/log-level", `{}`, auth), http.StatusUnprocessableEntity)
+	assertStatus(t, admin.do(t, http.MethodPut, "/log-level", `{"level": 1}`, auth), http.StatusBadRequest)
+
+	if app.logger.Level() != jsonlog.LevelDebug {
+		t.Errorf("got logger at %s after rejected updates; want DEBUG", app.logger.Level())
+	}
+}
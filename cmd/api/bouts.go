@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/events"
 	"github.com/corsairconstantine/sumodb/internal/validator"
 )
 
@@ -13,6 +15,7 @@ func (app *application) createBoutHandler(w http.ResponseWriter, r *http.Request
 	var input struct {
 		Tournament string `json:"tournament"`
 		Day        string `json:"day"`
+		Division   string `json:"division"`
 		Winner     string `json:"winner"`
 		Loser      string `json:"loser"`
 		Kimarite   string `json:"kimarite"`
@@ -27,33 +30,125 @@ func (app *application) createBoutHandler(w http.ResponseWriter, r *http.Request
 	bout := &data.Bout{
 		Tournament: input.Tournament,
 		Day:        input.Day,
+		Division:   input.Division,
 		Winner:     input.Winner,
 		Loser:      input.Loser,
 		Kimarite:   input.Kimarite,
 	}
 
 	v := validator.New()
-	if data.ValidateBout(v, bout, app.models.Rikishis); !v.Valid() {
+	err = data.ValidateBout(r.Context(), v, bout, app.models.Rikishis)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	err = app.models.Bouts.Insert(bout)
+	err = app.models.Bouts.Insert(r.Context(), bout)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
+	app.events.Publish(events.BoutCreated, bout)
+
 	headers := make(http.Header)
 	headers.Set("Location", fmt.Sprintf("/v1/bouts/%d", bout.ID))
+	headers.Set("ETag", app.etag(bout.Version))
 
-	err = app.writeJSON(w, http.StatusCreated, envelope{"bout": bout}, headers)
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"bout": bout}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 
 }
 
+func (app *application) createBoutsBatchHandler(w http.ResponseWriter, r *http.Request) {
+	var input []struct {
+		Tournament string `json:"tournament"`
+		Day        string `json:"day"`
+		Division   string `json:"division"`
+		Winner     string `json:"winner"`
+		Loser      string `json:"loser"`
+		Kimarite   string `json:"kimarite"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(input) > 0, "bouts", "must contain at least 1 bout")
+	v.Check(len(input) <= app.config.batch.maxSize, "bouts", fmt.Sprintf("must not contain more than %d bouts", app.config.batch.maxSize))
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	bouts := make([]*data.Bout, len(input))
+	shikonas := make([]string, 0, 2*len(input))
+
+	for i, item := range input {
+		bouts[i] = &data.Bout{
+			Tournament: item.Tournament,
+			Day:        item.Day,
+			Division:   item.Division,
+			Winner:     item.Winner,
+			Loser:      item.Loser,
+			Kimarite:   item.Kimarite,
+		}
+		shikonas = append(shikonas, item.Winner, item.Loser)
+	}
+
+	existing, err := app.models.Rikishis.ExistingShikonas(r.Context(), shikonas)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	batchErrors := make(map[int]map[string]string)
+
+	for i, bout := range bouts {
+		v := validator.New()
+		err = data.ValidateBout(r.Context(), v, bout, existing)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		if !v.Valid() {
+			batchErrors[i] = v.Errors
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		app.failedBatchValidationResponse(w, r, batchErrors)
+		return
+	}
+
+	err = app.models.Bouts.InsertBatch(r.Context(), bouts)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	for _, bout := range bouts {
+		app.events.Publish(events.BoutCreated, bout)
+	}
+
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"bouts": bouts}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
 func (app *application) showBoutHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
@@ -61,7 +156,18 @@ func (app *application) showBoutHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	bout, err := app.models.Bouts.Get(id)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	fields := app.readList(qs, "fields", boutFields, v)
+	include := app.readList(qs, "include", boutInclude, v)
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	bout, err := app.models.Bouts.Get(r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -72,7 +178,23 @@ func (app *application) showBoutHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"bout": bout}, nil)
+	headers := make(http.Header)
+
+	if len(include) == 0 {
+		if app.checkIfNoneMatch(w, r, bout.Version) {
+			return
+		}
+
+		headers.Set("ETag", app.etag(bout.Version))
+	}
+
+	resources, err := app.boutResources(r.Context(), []*data.Bout{bout}, fields, include)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"bout": resources[0]}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -85,7 +207,7 @@ func (app *application) updateBoutHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	bout, err := app.models.Bouts.Get(id)
+	bout, err := app.models.Bouts.Get(r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -96,9 +218,14 @@ func (app *application) updateBoutHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if !app.checkIfMatch(w, r, bout.Version) {
+		return
+	}
+
 	var input struct {
 		Tournament *string `json:"tournament"`
 		Day        *string `json:"day"`
+		Division   *string `json:"division"`
 		Winner     *string `json:"winner"`
 		Loser      *string `json:"loser"`
 		Kimarite   *string `json:"kimarite"`
@@ -118,6 +245,10 @@ func (app *application) updateBoutHandler(w http.ResponseWriter, r *http.Request
 		bout.Day = *input.Day
 	}
 
+	if input.Division != nil {
+		bout.Division = *input.Division
+	}
+
 	if input.Winner != nil {
 		bout.Winner = *input.Winner
 	}
@@ -131,12 +262,18 @@ func (app *application) updateBoutHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	v := validator.New()
-	if data.ValidateBout(v, bout, app.models.Rikishis); !v.Valid() {
+	err = data.ValidateBout(r.Context(), v, bout, app.models.Rikishis)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	err = app.models.Bouts.Update(bout)
+	err = app.models.Bouts.Update(r.Context(), bout)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrEditConflict):
@@ -147,7 +284,12 @@ func (app *application) updateBoutHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"bout": bout}, nil)
+	app.events.Publish(events.BoutUpdated, bout)
+
+	headers := make(http.Header)
+	headers.Set("ETag", app.etag(bout.Version))
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"bout": bout}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -160,7 +302,7 @@ func (app *application) deleteBoutHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = app.models.Bouts.Delete(id)
+	bout, err := app.models.Bouts.Get(r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -171,7 +313,26 @@ func (app *application) deleteBoutHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"message": "bout successfully deleted"}, nil)
+	if !app.checkIfMatch(w, r, bout.Version) {
+		return
+	}
+
+	err = app.models.Bouts.Delete(r.Context(), id, bout.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrEditConflict):
+			app.preconditionFailedResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(events.BoutDeleted, bout)
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"message": "bout successfully deleted"}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -181,9 +342,14 @@ func (app *application) listBoutsHandler(w http.ResponseWriter, r *http.Request)
 	var input struct {
 		Tournament string
 		Day        string
+		Division   string
 		Rikishi1   string
 		Rikishi2   string
+		Winner     string
+		Loser      string
 		Kimarite   string
+		From       string
+		To         string
 		data.Filters
 	}
 
@@ -192,40 +358,83 @@ func (app *application) listBoutsHandler(w http.ResponseWriter, r *http.Request)
 
 	input.Tournament = app.readString(qs, "tournament", "")
 	input.Day = app.readString(qs, "day", "")
+	input.Division = app.readString(qs, "division", "")
 	input.Kimarite = app.readString(qs, "kimarite", "")
 	input.Rikishi1 = app.readString(qs, "rikishi1", "")
-	input.Rikishi1 = app.readString(qs, "rikishi2", "")
+	input.Rikishi2 = app.readString(qs, "rikishi2", "")
+	input.Winner = app.readString(qs, "winner", "")
+	input.Loser = app.readString(qs, "loser", "")
+	input.From = app.readString(qs, "from", "")
+	input.To = app.readString(qs, "to", "")
+
+	fields := app.readList(qs, "fields", boutFields, v)
+	include := app.readList(qs, "include", boutInclude, v)
+
+	format := app.readFormat(r, v)
+	unlimited := app.readExportOptions(r, format, include, v)
 
 	input.Filters.Page = app.readInt(qs, "page", 1, v)
 	input.Filters.PageSize = app.readInt(qs, "page_size", 20, v)
 	input.Filters.Sort = app.readString(qs, "sort", "id")
+	input.Filters.Cursor = app.readString(qs, "cursor", "")
+	input.Filters.IncludeTotal = app.readBool(qs, "total", input.Filters.Cursor == "", v)
+	input.Filters.Unlimited = unlimited
 
-	input.Filters.SortSafelist = []string{"id", "tournament", "-tournament"}
+	input.Filters.SortSafelist = []string{"id", "tournament", "day", "winner", "loser", "kimarite", "-id", "-tournament", "-day", "-winner", "-loser", "-kimarite"}
+
+	v.Check(input.Tournament == "" || validator.ValidTournament(input.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+	v.Check(input.Day == "" || validator.ValidDay(input.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+	v.Check(input.Division == "" || validator.In(input.Division, data.Divisions...), "division", "must be one of "+strings.Join(data.Divisions, ", "))
+	v.Check(input.From == "" || validator.ValidTournament(input.From), "from", "must be a tournament. Example: 2022 Nov")
+	v.Check(input.To == "" || validator.ValidTournament(input.To), "to", "must be a tournament. Example: 2022 Nov")
 
 	if data.ValidateFilters(v, input.Filters); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	r1, err := app.models.Rikishis.GetShikonaHistory(input.Rikishi1)
-	if err != nil {
-		app.serverErrorResponse(w, r, err)
+	if input.Filters.Unlimited && app.contextGetAPIKey(r) == "" {
+		app.apiKeyRequiredResponse(w, r)
+		return
+	}
+
+	var shikonas [4][]string
+
+	for i, rikishi := range []string{input.Rikishi1, input.Rikishi2, input.Winner, input.Loser} {
+		history, err := app.models.Rikishis.GetShikonaHistory(r.Context(), rikishi)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		shikonas[i] = history
+	}
+
+	if format != formatJSON {
+		input.Filters.IncludeTotal = false
+
+		exp := app.newExporter(w, format, "bouts", fields, boutFields)
+
+		err := app.models.Bouts.Stream(r.Context(), input.Tournament, input.Day, input.Division, input.Kimarite, shikonas[0], shikonas[1], shikonas[2], shikonas[3], input.From, input.To, input.Filters, func(bout *data.Bout) error {
+			return exp.write(bout)
+		})
+
+		app.finishExport(w, r, exp, err)
 		return
 	}
 
-	r2, err := app.models.Rikishis.GetShikonaHistory(input.Rikishi2)
+	bouts, metadata, err := app.models.Bouts.GetAll(r.Context(), input.Tournament, input.Day, input.Division, input.Kimarite, shikonas[0], shikonas[1], shikonas[2], shikonas[3], input.From, input.To, input.Filters)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	bouts, err := app.models.Bouts.GetAll(input.Tournament, input.Day, input.Kimarite, r1, r2)
+	resources, err := app.boutResources(r.Context(), bouts, fields, include)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"bouts": bouts}, nil)
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"bouts": resources, "metadata": metadata}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
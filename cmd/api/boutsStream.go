@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/events"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+func (app *application) streamBoutsHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Tournament  string
+		Day         string
+		Rikishi     string
+		Epoch       string
+		LastEventID int64
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	input.Tournament = app.readString(qs, "tournament", "")
+	input.Day = app.readString(qs, "day", "")
+	input.Rikishi = app.readString(qs, "rikishi", "")
+
+	input.LastEventID = -1
+
+	// Event IDs are "<epoch>-<id>". A bare number comes from before epochs
+	// were sent and is resumed like an ID from another epoch.
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		epoch, number, found := strings.Cut(header, "-")
+		if !found {
+			epoch, number = "", header
+		}
+
+		id, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || id < 0 {
+			v.AddError("last_event_id", "must be a non-negative integer")
+		}
+		input.Epoch, input.LastEventID = epoch, id
+	}
+
+	v.Check(input.Tournament == "" || validator.ValidTournament(input.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+	v.Check(input.Day == "" || validator.ValidDay(input.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	shikonas, err := app.models.Rikishis.GetShikonaHistory(r.Context(), input.Rikishi)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if input.Rikishi != "" && len(shikonas) == 0 {
+		shikonas = []string{input.Rikishi}
+	}
+
+	filter := events.BoutFilter{Tournament: input.Tournament, Day: input.Day, Shikonas: shikonas}
+
+	var sub *events.Subscription
+	var missed []events.Event
+
+	complete := true
+
+	if input.LastEventID >= 0 {
+		sub, missed, complete = app.events.Resume(input.Epoch, input.LastEventID, 64)
+	} else {
+		sub = app.events.Subscribe(64)
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Clients whose Last-Event-ID is no longer in the log, or was issued by
+	// another process, are told to refetch with /v1/bouts, as some changes
+	// cannot be replayed.
+	if !complete {
+		fmt.Fprint(w, "event: stream.reset\ndata: {}\n\n")
+	}
+
+	for _, event := range missed {
+		if filter.Matches(event.Bout) {
+			err := app.writeEvent(w, event)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	err = rc.Flush()
+	if err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(app.config.stream.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if !filter.Matches(event.Bout) {
+				continue
+			}
+			err = app.writeEvent(w, event)
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (app *application) writeEvent(w http.ResponseWriter, event events.Event) error {
+	js, err := json.Marshal(event.Bout)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s-%d\nevent: %s\ndata: %s\n\n", app.events.Epoch(), event.ID, event.Type, js)
+	return err
+}
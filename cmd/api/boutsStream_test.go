@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/events"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+type sseStream struct {
+	res     *http.Response
+	scanner *bufio.Scanner
+}
+
+func (ts *testServer) stream(t *testing.T, path string, headers map[string]string) *sseStream {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d; want %d", res.StatusCode, http.StatusOK)
+	}
+
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("got Content-Type %q; want text/event-stream", ct)
+	}
+
+	return &sseStream{res: res, scanner: bufio.NewScanner(res.Body)}
+}
+
+// next returns the next event, or a comment as an event named ":".
+func (s *sseStream) next(t *testing.T) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, ":"):
+			event.event = ":"
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	t.Fatalf("stream ended: %v", s.scanner.Err())
+	return event
+}
+
+func TestStreamBouts(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	stream := ts.stream(t, "/v1/bouts/stream?rikishi=Terunofuji", nil)
+
+	res := ts.do(t, http.MethodPost, "/v1/bouts", `{"tournament": "2022 Nov", "day": "1", "division": "Makuuchi", "winner": "Takakeisho", "loser": "Takayasu", "kimarite": "oshidashi"}`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	res = ts.do(t, http.MethodPost, "/v1/bouts", `{"tournament": "2022 Nov", "day": "2", "division": "Makuuchi", "winner": "Terunofuji", "loser": "Takayasu", "kimarite": "yorikiri"}`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	res = ts.do(t, http.MethodPatch, "/v1/bouts/2", `{"kimarite": "yoritaoshi"}`, nil)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodDelete, "/v1/bouts/2", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	epoch := app.events.Epoch()
+
+	for _, want := range []sseEvent{
+		{id: epoch + "-2", event: events.BoutCreated, data: `"kimarite":"yorikiri"`},
+		{id: epoch + "-3", event: events.BoutUpdated, data: `"kimarite":"yoritaoshi"`},
+		{id: epoch + "-4", event: events.BoutDeleted, data: `"kimarite_kanji":"寄り倒し"`},
+	} {
+		got := stream.next(t)
+
+		if got.id != want.id || got.event != want.event || !strings.Contains(got.data, want.data) {
+			t.Errorf("got event %+v; want %+v", got, want)
+		}
+	}
+}
+
+func TestStreamBoutsResume(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	for id := int64(1); id <= 12; id++ {
+		app.events.Publish(events.BoutCreated, &data.Bout{ID: id, Tournament: "2022 Nov", Day: "1"})
+	}
+
+	epoch := app.events.Epoch()
+
+	t.Run("In log", func(t *testing.T) {
+		stream := ts.stream(t, "/v1/bouts/stream", map[string]string{"Last-Event-ID": epoch + "-10"})
+
+		for _, want := range []string{epoch + "-11", epoch + "-12"} {
+			if got := stream.next(t); got.id != want {
+				t.Errorf("got event id %q; want %q", got.id, want)
+			}
+		}
+	})
+
+	// IDs from a restarted process or another replica must not be resumed
+	// as if this process had issued them.
+	for name, lastEventID := range map[string]string{
+		"Before log":    epoch + "-1",
+		"Other process": events.NewHub(10).Epoch() + "-10",
+		"No epoch":      "10",
+	} {
+		t.Run(name, func(t *testing.T) {
+			stream := ts.stream(t, "/v1/bouts/stream", map[string]string{"Last-Event-ID": lastEventID})
+
+			if got := stream.next(t); got.event != "stream.reset" {
+				t.Errorf("got event %+v; want stream.reset", got)
+			}
+
+			if got := stream.next(t); got.id != epoch+"-3" {
+				t.Errorf("got event id %q; want the oldest logged event 3", got.id)
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/bouts/stream", "", map[string]string{"Last-Event-ID": "abc"})
+		assertStatus(t, res, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestStreamBoutsHeartbeat(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.stream.heartbeat = 10 * time.Millisecond
+
+	ts := newTestServer(t, app.routes())
+
+	stream := ts.stream(t, "/v1/bouts/stream?tournament=2022+Nov", nil)
+
+	if got := stream.next(t); got.event != ":" {
+		t.Errorf("got event %+v; want a heartbeat comment", got)
+	}
+}
+
+func TestStreamBoutsRoutes(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+	seedBouts(t, app, &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"})
+
+	res := ts.do(t, http.MethodGet, "/v1/bouts/1", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodPost, "/v1/bouts/stream", "", nil)
+	assertStatus(t, res, http.StatusMethodNotAllowed)
+
+	res = ts.do(t, http.MethodGet, "/v1/bouts/stream?day=16", "", nil)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+}
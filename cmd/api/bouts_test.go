@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func seedBoutsFixture(t *testing.T, app *application) {
+	t.Helper()
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama", ShikonaHistory: []string{"Wakamisho", "Terunofuji"}},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama"},
+	)
+
+	seedBouts(t, app,
+		&data.Bout{Tournament: "2022 Nov", Day: "15", Division: "Makuuchi", Winner: "Abi", Loser: "Takakeisho", Kimarite: "hikiotoshi"},
+		&data.Bout{Tournament: "2022 Nov", Day: "Playoff", Division: "Makuuchi", Winner: "Abi", Loser: "Takakeisho", Kimarite: "oshidashi"},
+		&data.Bout{Tournament: "2022 Nov", Day: "3", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Abi", Kimarite: "oshidashi"},
+		&data.Bout{Tournament: "2022 Jan", Day: "1", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Takakeisho", Kimarite: "yorikiri"},
+	)
+}
+
+func TestCreateBoutHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantErr  string
+	}{
+		{"Valid", `{"tournament": "2023 Jan", "day": "2", "division": "Makuuchi", "winner": "Abi", "loser": "Terunofuji", "kimarite": "tsukiotoshi"}`, http.StatusCreated, ""},
+		{"Unknown rikishi", `{"tournament": "2023 Jan", "day": "2", "winner": "Hakuho", "loser": "Abi"}`, http.StatusUnprocessableEntity, "winner"},
+		{"Invalid day", `{"tournament": "2023 Jan", "day": "16", "winner": "Abi", "loser": "Terunofuji"}`, http.StatusUnprocessableEntity, "day"},
+		{"Invalid division", `{"tournament": "2023 Jan", "day": "2", "division": "Maezumo", "winner": "Abi", "loser": "Terunofuji"}`, http.StatusUnprocessableEntity, "division"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodPost, "/v1/bouts", tt.body, nil)
+			assertStatus(t, res, tt.wantCode)
+
+			if tt.wantErr == "" {
+				if location := res.header.Get("Location"); location != "/v1/bouts/5" {
+					t.Errorf("got Location %q; want %q", location, "/v1/bouts/5")
+				}
+				return
+			}
+
+			var body struct {
+				Error map[string]string `json:"error"`
+			}
+			res.decode(t, &body)
+
+			if _, ok := body.Error[tt.wantErr]; !ok {
+				t.Errorf("got errors %v; want an error for %q", body.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+type unavailableRikishiStore struct {
+	data.RikishiStore
+}
+
+func (s unavailableRikishiStore) Exists(ctx context.Context, shikona string) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestCreateBoutHandlerLookupError(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	app.models.Rikishis = unavailableRikishiStore{app.models.Rikishis}
+
+	res := ts.do(t, http.MethodPost, "/v1/bouts", `{"tournament": "2023 Jan", "day": "2", "winner": "Abi", "loser": "Terunofuji"}`, nil)
+	assertStatus(t, res, http.StatusInternalServerError)
+}
+
+func TestCreateBoutsBatchHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	res := ts.do(t, http.MethodPost, "/v1/bouts/batch", `[
+		{"tournament": "2023 Jan", "day": "1", "winner": "Abi", "loser": "Takakeisho"},
+		{"tournament": "2023 Jan", "day": "2", "winner": "Abi", "loser": "Hakuho"}
+	]`, nil)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+
+	var failed struct {
+		Error map[string]map[string]string `json:"error"`
+	}
+	res.decode(t, &failed)
+
+	if _, ok := failed.Error["1"]["loser"]; !ok || len(failed.Error) != 1 {
+		t.Errorf("got errors %v; want only a loser error for item 1", failed.Error)
+	}
+
+	bouts, _, err := app.models.Bouts.GetAll(t.Context(), "2023 Jan", "", "", "", nil, nil, nil, nil, "", "", data.Filters{Page: 1, PageSize: 20, Sort: "id", SortSafelist: []string{"id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bouts) != 0 {
+		t.Errorf("got %d bouts after a rejected batch; want 0", len(bouts))
+	}
+
+	res = ts.do(t, http.MethodPost, "/v1/bouts/batch", `[
+		{"tournament": "2023 Jan", "day": "1", "winner": "Abi", "loser": "Takakeisho"},
+		{"tournament": "2023 Jan", "day": "2", "winner": "Terunofuji", "loser": "Abi"}
+	]`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	res = ts.do(t, http.MethodPost, "/v1/bouts/batch", `[{}, {}, {}, {}]`, nil)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+}
+
+func TestListBoutsHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int64
+	}{
+		{"Default sort", "", []int64{1, 2, 3, 4}},
+		{"Rikishi by former shikona", "?rikishi1=Wakamisho", []int64{4}},
+		{"Head to head", "?rikishi1=Abi&rikishi2=Takakeisho", []int64{1, 2, 3}},
+		{"Winner", "?winner=Abi", []int64{1, 2}},
+		{"Kimarite", "?kimarite=oshidashi", []int64{2, 3}},
+		{"Day sorts playoff last", "?tournament=2022+Nov&sort=-day", []int64{2, 1, 3}},
+		{"Tournament sorts by date", "?sort=tournament&page_size=2", []int64{4, 1}},
+		{"Date range", "?from=2022+Feb&to=2022+Dec", []int64{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodGet, "/v1/bouts"+tt.query, "", nil)
+			assertStatus(t, res, http.StatusOK)
+
+			var body struct {
+				Bouts []data.Bout `json:"bouts"`
+			}
+			res.decode(t, &body)
+
+			ids := []int64{}
+			for _, bout := range body.Bouts {
+				ids = append(ids, bout.ID)
+			}
+
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("got %v; want %v", ids, tt.want)
+			}
+		})
+	}
+
+	t.Run("Invalid tournament range", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/bouts?from=yesterday", "", nil)
+		assertStatus(t, res, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("NDJSON export", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/bouts?winner=Abi&fields=id,kimarite", "", map[string]string{"Accept": "application/x-ndjson"})
+		assertStatus(t, res, http.StatusOK)
+
+		want := `{"id":1,"kimarite":"hikiotoshi"}` + "\n" + `{"id":2,"kimarite":"oshidashi"}` + "\n"
+		if string(res.body) != want {
+			t.Errorf("got %q; want %q", res.body, want)
+		}
+	})
+}
+
+func TestUpdateBoutHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	res := ts.do(t, http.MethodPatch, "/v1/bouts/3", `{"kimarite": "tsukidashi"}`, nil)
+	assertStatus(t, res, http.StatusOK)
+
+	if etag := res.header.Get("ETag"); etag != `"2"` {
+		t.Errorf("got ETag %q; want %q", etag, `"2"`)
+	}
+
+	bout, err := app.models.Bouts.Get(t.Context(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bout.Kimarite != "tsukidashi" {
+		t.Errorf("got kimarite %q; want %q", bout.Kimarite, "tsukidashi")
+	}
+
+	res = ts.do(t, http.MethodPatch, "/v1/bouts/3", `{"kimarite": "oshidashi"}`, map[string]string{"If-Match": `"1"`})
+	assertStatus(t, res, http.StatusPreconditionFailed)
+
+	res = ts.do(t, http.MethodPatch, "/v1/bouts/3", `{"winner": "Hakuho"}`, nil)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+
+	res = ts.do(t, http.MethodPatch, "/v1/bouts/99", `{"kimarite": "oshidashi"}`, nil)
+	assertStatus(t, res, http.StatusNotFound)
+}
+
+func TestShowBoutHandlerInclude(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedBoutsFixture(t, app)
+
+	res := ts.do(t, http.MethodGet, "/v1/bouts/4?include=winner", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	if !strings.Contains(string(res.body), `"heya":"Isegahama"`) {
+		t.Errorf("got %s; want the winner included", res.body)
+	}
+
+	res = ts.do(t, http.MethodDelete, "/v1/bouts/4", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodGet, "/v1/bouts/4", "", nil)
+	assertStatus(t, res, http.StatusNotFound)
+}
+
+// racingBouts runs race after each Get, standing in for a request that changes
+// the bout before the handler deletes it.
+type racingBouts struct {
+	data.BoutStore
+	race func(ctx context.Context, bout data.Bout) error
+}
+
+func (b racingBouts) Get(ctx context.Context, id int64) (*data.Bout, error) {
+	bout, err := b.BoutStore.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return bout, b.race(ctx, *bout)
+}
+
+func TestDeleteBoutHandlerRace(t *testing.T) {
+	tests := []struct {
+		name string
+		race func(store data.BoutStore) func(ctx context.Context, bout data.Bout) error
+		want int
+	}{
+		{"Deleted", func(store data.BoutStore) func(ctx context.Context, bout data.Bout) error {
+			return func(ctx context.Context, bout data.Bout) error {
+				return store.Delete(ctx, bout.ID, bout.Version)
+			}
+		}, http.StatusNotFound},
+		{"Updated", func(store data.BoutStore) func(ctx context.Context, bout data.Bout) error {
+			return func(ctx context.Context, bout data.Bout) error {
+				bout.Kimarite = "tsukidashi"
+				return store.Update(ctx, &bout)
+			}
+		}, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication(t)
+			seedBoutsFixture(t, app)
+
+			app.models.Bouts = racingBouts{app.models.Bouts, tt.race(app.models.Bouts)}
+			ts := newTestServer(t, app.routes())
+
+			res := ts.do(t, http.MethodDelete, "/v1/bouts/4", "", nil)
+			assertStatus(t, res, tt.want)
+		})
+	}
+}
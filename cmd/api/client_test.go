@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/pkg/client"
+)
+
+func TestClient(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	ctx := context.Background()
+	c := client.New(ts.URL, client.WithAPIKey("test-key"))
+
+	for _, shikona := range []string{"Abi", "Takakeisho", "Terunofuji"} {
+		_, err := c.CreateRikishi(ctx, &client.Rikishi{Shikona: shikona, HighestRank: "Ozeki", Heya: "Isegahama", ShikonaHistory: []string{shikona}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := c.CreateRikishi(ctx, &client.Rikishi{Shikona: "Kirishima", ShikonaHistory: []string{"Kirishima"}})
+	var verr *client.ValidationError
+	if !errors.As(err, &verr) || verr.Fields["heya"] == "" {
+		t.Errorf("got %v; want a ValidationError for heya", err)
+	}
+
+	bouts, err := c.CreateBouts(ctx, []*client.Bout{
+		{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Abi", Loser: "Takakeisho", Kimarite: "oshidashi"},
+		{Tournament: "2022 Nov", Day: "2", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Abi", Kimarite: "yorikiri"},
+		{Tournament: "2022 Nov", Day: "3", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Terunofuji", Kimarite: "tsukiotoshi"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bouts) != 3 || bouts[1].KimariteKanji != "寄り切り" {
+		t.Fatalf("got %+v", bouts)
+	}
+
+	_, err = c.CreateBouts(ctx, []*client.Bout{{Tournament: "2022 Nov", Day: "4"}})
+	if !errors.As(err, new(*client.BatchValidationError)) {
+		t.Errorf("got %v; want a BatchValidationError", err)
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		filter := client.BoutFilter{ListOptions: client.ListOptions{PageSize: 2, Sort: "day"}}
+
+		days := []string{}
+		for bout, err := range c.AllBouts(ctx, filter) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			days = append(days, bout.Day)
+		}
+
+		if len(days) != 3 || days[0] != "1" || days[2] != "3" {
+			t.Errorf("got days %v; want [1 2 3]", days)
+		}
+
+		page, metadata, err := c.ListBouts(ctx, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(page) != 2 || metadata.TotalRecords != 3 || metadata.NextCursor == "" {
+			t.Errorf("got %d bouts and %+v", len(page), metadata)
+		}
+	})
+
+	t.Run("Optimistic locking", func(t *testing.T) {
+		bout := bouts[0]
+		kimarite := "hatakikomi"
+
+		updated, err := c.UpdateBout(ctx, bout.ID, bout.Version, client.BoutUpdate{Kimarite: &kimarite})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if updated.Version != bout.Version+1 || updated.Kimarite != kimarite {
+			t.Errorf("got %+v", updated)
+		}
+
+		_, err = c.UpdateBout(ctx, bout.ID, bout.Version, client.BoutUpdate{Kimarite: &kimarite})
+		if !errors.Is(err, client.ErrEditConflict) {
+			t.Errorf("got %v; want ErrEditConflict", err)
+		}
+
+		err = c.DeleteBout(ctx, bout.ID, bout.Version)
+		if !errors.Is(err, client.ErrEditConflict) {
+			t.Errorf("got %v; want ErrEditConflict", err)
+		}
+
+		err = c.DeleteBout(ctx, bout.ID, updated.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.GetBout(ctx, bout.ID)
+		if !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("got %v; want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Tournament results", func(t *testing.T) {
+		trs, err := c.CreateTournamentsResults(ctx, []*client.TournamentResult{
+			{Tournament: "2022 Nov", Rikishi: "Abi", Rank: "Komusubi", Wins: 12, Losses: 3},
+			{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 12, Losses: 3},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := c.GetTournamentResult(ctx, trs[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Rikishi != "Abi" || got.Wins != 12 {
+			t.Errorf("got %+v", got)
+		}
+
+		count := 0
+		for _, err := range c.AllTournamentsResults(ctx, client.TournamentResultFilter{Tournament: "2022 Nov", ListOptions: client.ListOptions{PageSize: 1}}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			count++
+		}
+
+		if count != 2 {
+			t.Errorf("got %d results; want 2", count)
+		}
+	})
+
+	t.Run("Rikishis", func(t *testing.T) {
+		rikishi, err := c.GetRikishi(ctx, "Abi")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		heya := "Shikoroyama"
+		updated, err := c.UpdateRikishi(ctx, "Abi", rikishi.Version, client.RikishiUpdate{Heya: &heya})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if updated.Heya != heya {
+			t.Errorf("got heya %q; want %q", updated.Heya, heya)
+		}
+
+		hits, err := c.Search(ctx, "shikoroyama", []string{"heya"}, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(hits) != 1 || hits[0].Value != heya {
+			t.Errorf("got %+v", hits)
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		user, err := c.RegisterUser(ctx, "Alice", "alice@example.com", "pa55word1234")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if user.ID == 0 || user.Email != "alice@example.com" {
+			t.Errorf("got %+v", user)
+		}
+
+		_, err = c.RegisterUser(ctx, "Alice", "alice@example.com", "pa55word1234")
+		if !errors.As(err, new(*client.ValidationError)) {
+			t.Errorf("got %v; want a ValidationError", err)
+		}
+	})
+
+	health, err := c.Healthcheck(ctx)
+	if err != nil || health.Status != "available" {
+		t.Errorf("got %+v, %v", health, err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey string
+
+const (
+	apiKeyContextKey      = contextKey("apiKey")
+	requestInfoContextKey = contextKey("requestInfo")
+)
+
+// requestInfo is created by the outermost middleware and shared through the
+// request context, so details learned further in, such as the matched route
+// or the API key, are visible once the response has been written.
+type requestInfo struct {
+	id     string
+	route  string
+	apiKey string
+}
+
+func (app *application) contextSetRequestInfo(r *http.Request, info *requestInfo) *http.Request {
+	ctx := context.WithValue(r.Context(), requestInfoContextKey, info)
+	return r.WithContext(ctx)
+}
+
+func (app *application) contextGetRequestInfo(r *http.Request) *requestInfo {
+	info, ok := r.Context().Value(requestInfoContextKey).(*requestInfo)
+	if !ok {
+		return &requestInfo{}
+	}
+
+	return info
+}
+
+func (app *application) contextGetRequestID(r *http.Request) string {
+	return app.contextGetRequestInfo(r).id
+}
+
+func (app *application) contextSetAPIKey(r *http.Request, key string) *http.Request {
+	app.contextGetRequestInfo(r).apiKey = key
+
+	ctx := context.WithValue(r.Context(), apiKeyContextKey, key)
+	return r.WithContext(ctx)
+}
+
+func (app *application) contextGetAPIKey(r *http.Request) string {
+	key, ok := r.Context().Value(apiKeyContextKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return key
+}
+
+func (app *application) contextSetRoute(r *http.Request, pattern string) {
+	app.contextGetRequestInfo(r).route = pattern
+}
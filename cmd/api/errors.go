@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/i18n"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
 )
 
 func (app *application) badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
@@ -15,9 +21,31 @@ func (app *application) editConflictResponse(w http.ResponseWriter, r *http.Requ
 }
 
 func (app *application) errorResponse(w http.ResponseWriter, r *http.Request, status int, message interface{}) {
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+
+	switch m := message.(type) {
+	case string:
+		message = i18n.Message(lang, m)
+	case map[string]string:
+		message = i18n.Errors(lang, m)
+	case map[int]map[string]string:
+		localized := make(map[int]map[string]string, len(m))
+		for i, errors := range m {
+			localized[i] = i18n.Errors(lang, errors)
+		}
+		message = localized
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", lang)
+
 	env := envelope{"error": message}
 
-	err := app.writeJSON(w, status, env, nil)
+	if id := app.contextGetRequestID(r); id != "" {
+		env["request_id"] = id
+	}
+
+	err := app.writeJSON(w, r, status, env, nil)
 	if err != nil {
 		app.logError(r, err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -28,11 +56,32 @@ func (app *application) failedValidationResponse(w http.ResponseWriter, r *http.
 	app.errorResponse(w, r, http.StatusUnprocessableEntity, errors)
 }
 
+func (app *application) failedBatchValidationResponse(w http.ResponseWriter, r *http.Request, errors map[int]map[string]string) {
+	app.errorResponse(w, r, http.StatusUnprocessableEntity, errors)
+}
+
+func (app *application) invalidAPIKeyResponse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+
+	message := "invalid or missing API key"
+	app.errorResponse(w, r, http.StatusUnauthorized, message)
+}
+
 func (app *application) logError(r *http.Request, err error) {
-	app.logger.PrintError(err, map[string]string{
+	app.logger.PrintError(err, app.errorLogProperties(r))
+}
+
+func (app *application) errorLogProperties(r *http.Request) jsonlog.Properties {
+	properties := jsonlog.Properties{
 		"request_method": r.Method,
 		"request_url":    r.URL.String(),
-	})
+	}
+
+	if id := app.contextGetRequestID(r); id != "" {
+		properties["request_id"] = id
+	}
+
+	return properties
 }
 
 func (app *application) methodNotAllowedResponse(w http.ResponseWriter, r *http.Request) {
@@ -40,19 +89,66 @@ func (app *application) methodNotAllowedResponse(w http.ResponseWriter, r *http.
 	app.errorResponse(w, r, http.StatusMethodNotAllowed, message)
 }
 
+func (app *application) apiKeyRequiredResponse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+
+	message := "you must provide an API key to access this resource"
+	app.errorResponse(w, r, http.StatusUnauthorized, message)
+}
+
 func (app *application) notFoundResponse(w http.ResponseWriter, r *http.Request) {
 	message := "the requested resourse cannot be found"
 	app.errorResponse(w, r, http.StatusNotFound, message)
 }
 
+func (app *application) preconditionFailedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the record has been modified since it was retrieved, fetch the latest version and try again"
+	app.errorResponse(w, r, http.StatusPreconditionFailed, message)
+}
+
+func (app *application) preconditionRequiredResponse(w http.ResponseWriter, r *http.Request) {
+	message := "this request must include an If-Match header"
+	app.errorResponse(w, r, http.StatusPreconditionRequired, message)
+}
+
 func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request) {
 	message := "rate limit exceeded"
 	app.errorResponse(w, r, http.StatusTooManyRequests, message)
 }
 
+// requestCanceledResponse is logged as a warning, as the client has usually
+// disconnected and the server is not at fault.
+func (app *application) requestCanceledResponse(w http.ResponseWriter, r *http.Request, err error) {
+	properties := app.errorLogProperties(r)
+	properties["error"] = err
+
+	app.logger.PrintWarn("request canceled", properties)
+
+	message := "the request was canceled before it could complete"
+	app.errorResponse(w, r, http.StatusServiceUnavailable, message)
+}
+
+// serverErrorResponse reports queries stopped by a canceled request as 503 and
+// queries which ran out of time as 504, rather than as a generic 500.
 func (app *application) serverErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if data.IsCanceled(err) {
+		if errors.Is(r.Context().Err(), context.Canceled) {
+			app.requestCanceledResponse(w, r, err)
+		} else {
+			app.timeoutResponse(w, r, err)
+		}
+		return
+	}
+
 	app.logError(r, err)
 
 	message := "The server encountered a problem and could not process your request"
 	app.errorResponse(w, r, http.StatusInternalServerError, message)
 }
+
+func (app *application) timeoutResponse(w http.ResponseWriter, r *http.Request, err error) {
+	app.logError(r, err)
+
+	message := "the server timed out waiting for the database, please try again later"
+	app.errorResponse(w, r, http.StatusGatewayTimeout, message)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestDatabaseTimeout(t *testing.T) {
+	app := newTestApplication(t)
+
+	app.config.db.driver = "sqlite"
+	app.config.db.dsn = filepath.Join(t.TempDir(), "sumodb.db")
+	app.config.db.maxOpenConns = 1
+	app.config.db.maxIdleTime = "15m"
+	app.config.db.timeouts = data.DefaultTimeouts
+	app.config.db.timeouts.Read = time.Nanosecond
+
+	db, err := openDB(app.config, noop.NewTracerProvider())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	app.config.limiter.store = "database"
+
+	app.models, err = newModels(app.config, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := newTestServer(t, app.routes())
+
+	res := ts.do(t, http.MethodGet, "/v1/bouts/1", "", nil)
+	assertStatus(t, res, http.StatusGatewayTimeout)
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	res.decode(t, &body)
+
+	if body.Error != "the server timed out waiting for the database, please try again later" {
+		t.Errorf("got error %q", body.Error)
+	}
+}
+
+func TestServerErrorResponse(t *testing.T) {
+	app := newTestApplication(t)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want int
+	}{
+		{"Server error", context.Background(), errors.New("connection refused"), http.StatusInternalServerError},
+		{"Query timeout", context.Background(), context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"Request canceled", canceled, context.Canceled, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/bouts/1", nil).WithContext(tt.ctx)
+
+			app.serverErrorResponse(rr, r, tt.err)
+
+			if rr.Code != tt.want {
+				t.Errorf("got status %d; want %d", rr.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+const exportFlushInterval = 100
+
+type exporter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	format  string
+	name    string
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+	written int
+}
+
+func (app *application) readFormat(r *http.Request, v *validator.Validator) string {
+	format := r.URL.Query().Get("format")
+	if format != "" {
+		v.Check(validator.In(format, formatJSON, formatCSV, formatNDJSON), "format", "must be one of json, csv or ndjson")
+		return format
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case "application/json":
+			return formatJSON
+		case "text/csv":
+			return formatCSV
+		case "application/x-ndjson":
+			return formatNDJSON
+		}
+	}
+
+	return formatJSON
+}
+
+func (app *application) readExportOptions(r *http.Request, format string, include []string, v *validator.Validator) bool {
+	unlimited := app.readBool(r.URL.Query(), "all", false, v)
+
+	v.Check(!unlimited || format != formatJSON, "all", "is only supported for csv and ndjson exports")
+	v.Check(len(include) == 0 || format == formatJSON, "include", "is not supported for csv and ndjson exports")
+
+	return unlimited
+}
+
+func (app *application) newExporter(w http.ResponseWriter, format, name string, fields, safelist []string) *exporter {
+	columns := fields
+	if len(columns) == 0 {
+		columns = safelist
+	}
+
+	return &exporter{
+		w:       w,
+		rc:      http.NewResponseController(w),
+		format:  format,
+		name:    name,
+		columns: columns,
+	}
+}
+
+func (e *exporter) start() error {
+	e.started = true
+
+	e.rc.SetWriteDeadline(time.Time{})
+
+	switch e.format {
+	case formatCSV:
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.name+".csv"))
+		e.w.WriteHeader(http.StatusOK)
+
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.columns)
+	default:
+		e.w.Header().Set("Content-Type", "application/x-ndjson")
+		e.w.WriteHeader(http.StatusOK)
+
+		e.json = json.NewEncoder(e.w)
+		return nil
+	}
+}
+
+func (e *exporter) write(v interface{}) error {
+	if !e.started {
+		err := e.start()
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := newResource(v)
+	if err != nil {
+		return err
+	}
+
+	switch e.format {
+	case formatCSV:
+		record := make([]string, len(e.columns))
+		for i, column := range e.columns {
+			record[i] = csvValue(res[column])
+		}
+
+		err = e.csv.Write(record)
+	default:
+		err = e.json.Encode(res.project(e.columns, nil))
+	}
+	if err != nil {
+		return err
+	}
+
+	e.written++
+	if e.written%exportFlushInterval == 0 {
+		return e.flush()
+	}
+
+	return nil
+}
+
+func (e *exporter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	err := e.rc.Flush()
+	if err == http.ErrNotSupported {
+		return nil
+	}
+
+	return err
+}
+
+func (e *exporter) finish() error {
+	if !e.started {
+		err := e.start()
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
+
+func (app *application) finishExport(w http.ResponseWriter, r *http.Request, e *exporter, err error) {
+	if err == nil {
+		err = e.finish()
+	}
+
+	if err == nil {
+		return
+	}
+
+	if !e.started {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	app.logError(r, err)
+}
+
+func csvValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = csvValue(item)
+		}
+		return strings.Join(items, ";")
+	default:
+		return fmt.Sprint(value)
+	}
+}
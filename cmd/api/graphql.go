@@ -0,0 +1,816 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/graphql"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+const graphqlMaxFirst = 100
+
+type graphqlHeya struct {
+	Name  string
+	Kanji string
+	Kana  string
+}
+
+type graphqlTournament struct {
+	Name string
+}
+
+func (app *application) graphqlHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+		Extensions    map[string]interface{} `json:"extensions"`
+	}
+
+	v := validator.New()
+
+	if r.Method == http.MethodGet {
+		qs := r.URL.Query()
+
+		input.Query = app.readString(qs, "query", "")
+		input.OperationName = app.readString(qs, "operationName", "")
+
+		if variables := qs.Get("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &input.Variables)
+			v.Check(err == nil, "variables", "must be a JSON object")
+		}
+	} else {
+		err := app.readJSON(w, r, &input)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	v.Check(strings.TrimSpace(input.Query) != "", "query", "must be provided")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	result := graphql.Execute(r.Context(), graphql.Params{
+		Schema:        app.graphql,
+		Query:         input.Query,
+		OperationName: input.OperationName,
+		Variables:     input.Variables,
+		MaxDepth:      app.config.graphql.maxDepth,
+		MaxComplexity: app.config.graphql.maxComplexity,
+		ReportError: func(err error) {
+			app.logError(r, err)
+		},
+	})
+
+	status := http.StatusOK
+	env := envelope{}
+
+	if result.Data != nil {
+		env["data"] = result.Data
+	} else {
+		status = http.StatusBadRequest
+	}
+
+	if len(result.Errors) > 0 {
+		env["errors"] = result.Errors
+	}
+
+	err := app.writeJSON(w, r, status, env, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// graphqlSchema builds the schema served by graphqlHandler. It is built once
+// at startup, as the resolvers read everything else through app.
+func (app *application) graphqlSchema() *graphql.Schema {
+	query := &graphql.Object{Name: "Query"}
+	rikishi := &graphql.Object{Name: "Rikishi"}
+	heya := &graphql.Object{Name: "Heya"}
+	tournament := &graphql.Object{Name: "Tournament"}
+	tournamentResult := &graphql.Object{Name: "TournamentResult"}
+	bout := &graphql.Object{Name: "Bout"}
+	searchHit := &graphql.Object{Name: "SearchHit"}
+
+	rikishi.Fields = map[string]*graphql.Field{
+		"shikona":        graphqlProperty(graphql.String, func(r *data.Rikishi) interface{} { return r.Shikona }),
+		"highestRank":    graphqlProperty(graphql.String, func(r *data.Rikishi) interface{} { return r.HighestRank }),
+		"shikonaHistory": graphqlListProperty(graphql.String, func(r *data.Rikishi) interface{} { return r.ShikonaHistory }),
+		"shikonaKanji":   graphqlProperty(graphql.String, func(r *data.Rikishi) interface{} { return r.ShikonaKanji }),
+		"shikonaKana":    graphqlProperty(graphql.String, func(r *data.Rikishi) interface{} { return r.ShikonaKana }),
+		"version":        graphqlProperty(graphql.Int, func(r *data.Rikishi) interface{} { return r.Version }),
+		"heya": {
+			Object: heya,
+			Resolve: graphql.Property(func(src interface{}) interface{} {
+				r := src.(*data.Rikishi)
+				return &graphqlHeya{Name: r.Heya, Kanji: r.HeyaKanji, Kana: r.HeyaKana}
+			}),
+		},
+		"tournaments": {
+			Object:     tournamentResult,
+			List:       true,
+			Args:       map[string]*graphql.Argument{"last": {Type: graphql.Int, Default: 5}},
+			Multiplier: graphqlMultiplier("last"),
+			Resolve:    app.graphqlRikishiTournaments,
+		},
+		"bouts": {
+			Object: bout,
+			List:   true,
+			Args: map[string]*graphql.Argument{
+				"first":      {Type: graphql.Int, Default: 20},
+				"tournament": {Type: graphql.String},
+			},
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    app.graphqlRikishiBouts,
+		},
+	}
+
+	heya.Fields = map[string]*graphql.Field{
+		"name":  graphqlProperty(graphql.String, func(h *graphqlHeya) interface{} { return h.Name }),
+		"kanji": graphqlProperty(graphql.String, func(h *graphqlHeya) interface{} { return h.Kanji }),
+		"kana":  graphqlProperty(graphql.String, func(h *graphqlHeya) interface{} { return h.Kana }),
+		"rikishis": {
+			Object:     rikishi,
+			List:       true,
+			Args:       map[string]*graphql.Argument{"first": {Type: graphql.Int, Default: 20}},
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    app.graphqlHeyaRikishis,
+		},
+	}
+
+	tournament.Fields = map[string]*graphql.Field{
+		"name": graphqlProperty(graphql.String, func(t *graphqlTournament) interface{} { return t.Name }),
+		"results": {
+			Object: tournamentResult,
+			List:   true,
+			Args: map[string]*graphql.Argument{
+				"first": {Type: graphql.Int, Default: 20},
+				"rank":  {Type: graphql.String},
+			},
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    app.graphqlTournamentResults,
+		},
+		"bouts": {
+			Object: bout,
+			List:   true,
+			Args: map[string]*graphql.Argument{
+				"first":    {Type: graphql.Int, Default: 20},
+				"day":      {Type: graphql.String},
+				"division": {Type: graphql.String},
+			},
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    app.graphqlTournamentBouts,
+		},
+	}
+
+	tournamentResult.Fields = map[string]*graphql.Field{
+		"id":      graphqlProperty(graphql.ID, func(tr *data.TournamentResult) interface{} { return tr.ID }),
+		"rank":    graphqlProperty(graphql.String, func(tr *data.TournamentResult) interface{} { return tr.Rank }),
+		"wins":    graphqlProperty(graphql.Int, func(tr *data.TournamentResult) interface{} { return tr.Wins }),
+		"losses":  graphqlProperty(graphql.Int, func(tr *data.TournamentResult) interface{} { return tr.Losses }),
+		"absent":  graphqlProperty(graphql.Int, func(tr *data.TournamentResult) interface{} { return tr.Absent }),
+		"version": graphqlProperty(graphql.Int, func(tr *data.TournamentResult) interface{} { return tr.Version }),
+		"tournament": {
+			Object: tournament,
+			Resolve: graphql.Property(func(src interface{}) interface{} {
+				return &graphqlTournament{Name: src.(*data.TournamentResult).Tournament}
+			}),
+		},
+		"rikishi": {
+			Object: rikishi,
+			Resolve: app.graphqlRikishisByShikona(func(src interface{}) string {
+				return src.(*data.TournamentResult).Rikishi
+			}),
+		},
+		"bouts": {
+			Object:     bout,
+			List:       true,
+			Args:       map[string]*graphql.Argument{"first": {Type: graphql.Int, Default: 16}},
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    app.graphqlTournamentResultBouts,
+		},
+	}
+
+	bout.Fields = map[string]*graphql.Field{
+		"id":            graphqlProperty(graphql.ID, func(b *data.Bout) interface{} { return b.ID }),
+		"day":           graphqlProperty(graphql.String, func(b *data.Bout) interface{} { return b.Day }),
+		"division":      graphqlProperty(graphql.String, func(b *data.Bout) interface{} { return b.Division }),
+		"kimarite":      graphqlProperty(graphql.String, func(b *data.Bout) interface{} { return b.Kimarite }),
+		"kimariteKanji": graphqlProperty(graphql.String, func(b *data.Bout) interface{} { return data.KimariteReadings[strings.ToLower(b.Kimarite)].Kanji }),
+		"kimariteKana":  graphqlProperty(graphql.String, func(b *data.Bout) interface{} { return data.KimariteReadings[strings.ToLower(b.Kimarite)].Kana }),
+		"version":       graphqlProperty(graphql.Int, func(b *data.Bout) interface{} { return b.Version }),
+		"tournament": {
+			Object: tournament,
+			Resolve: graphql.Property(func(src interface{}) interface{} {
+				return &graphqlTournament{Name: src.(*data.Bout).Tournament}
+			}),
+		},
+		"winner": {
+			Object: rikishi,
+			Resolve: app.graphqlRikishisByShikona(func(src interface{}) string {
+				return src.(*data.Bout).Winner
+			}),
+		},
+		"loser": {
+			Object: rikishi,
+			Resolve: app.graphqlRikishisByShikona(func(src interface{}) string {
+				return src.(*data.Bout).Loser
+			}),
+		},
+	}
+
+	searchHit.Fields = map[string]*graphql.Field{
+		"kind":      graphqlProperty(graphql.String, func(h *data.SearchHit) interface{} { return h.Kind }),
+		"value":     graphqlProperty(graphql.String, func(h *data.SearchHit) interface{} { return h.Value }),
+		"rikishi":   graphqlProperty(graphql.String, func(h *data.SearchHit) interface{} { return h.Rikishi }),
+		"score":     graphqlProperty(graphql.Float, func(h *data.SearchHit) interface{} { return h.Score }),
+		"highlight": graphqlProperty(graphql.String, func(h *data.SearchHit) interface{} { return h.Highlight }),
+	}
+
+	query.Fields = map[string]*graphql.Field{
+		"rikishi": {
+			Object:  rikishi,
+			Args:    map[string]*graphql.Argument{"shikona": {Type: graphql.String, NonNull: true}},
+			Resolve: graphql.Each(app.graphqlRikishi),
+		},
+		"rikishis": {
+			Object: rikishi,
+			List:   true,
+			Args: graphqlPageArgs("shikona", map[string]*graphql.Argument{
+				"shikona":     {Type: graphql.String, Default: ""},
+				"highestRank": {Type: graphql.String, Default: ""},
+				"heya":        {Type: graphql.String, Default: ""},
+			}),
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    graphql.Each(app.graphqlRikishis),
+		},
+		"heya": {
+			Object:  heya,
+			Args:    map[string]*graphql.Argument{"name": {Type: graphql.String, NonNull: true}},
+			Resolve: graphql.Each(app.graphqlHeya),
+		},
+		"tournament": {
+			Object:  tournament,
+			Args:    map[string]*graphql.Argument{"name": {Type: graphql.String, NonNull: true}},
+			Resolve: graphql.Each(app.graphqlTournament),
+		},
+		"tournamentResult": {
+			Object:  tournamentResult,
+			Args:    map[string]*graphql.Argument{"id": {Type: graphql.ID, NonNull: true}},
+			Resolve: graphql.Each(app.graphqlTournamentResult),
+		},
+		"tournamentResults": {
+			Object: tournamentResult,
+			List:   true,
+			Args: graphqlPageArgs("id", map[string]*graphql.Argument{
+				"tournament": {Type: graphql.String, Default: ""},
+				"rikishi":    {Type: graphql.String, Default: ""},
+				"rank":       {Type: graphql.String, Default: ""},
+				"wins":       {Type: graphql.Int, Default: 0},
+			}),
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    graphql.Each(app.graphqlTournamentsResults),
+		},
+		"bout": {
+			Object:  bout,
+			Args:    map[string]*graphql.Argument{"id": {Type: graphql.ID, NonNull: true}},
+			Resolve: graphql.Each(app.graphqlBout),
+		},
+		"bouts": {
+			Object: bout,
+			List:   true,
+			Args: graphqlPageArgs("id", map[string]*graphql.Argument{
+				"tournament": {Type: graphql.String, Default: ""},
+				"day":        {Type: graphql.String, Default: ""},
+				"division":   {Type: graphql.String, Default: ""},
+				"kimarite":   {Type: graphql.String, Default: ""},
+				"rikishi1":   {Type: graphql.String, Default: ""},
+				"rikishi2":   {Type: graphql.String, Default: ""},
+				"winner":     {Type: graphql.String, Default: ""},
+				"loser":      {Type: graphql.String, Default: ""},
+				"from":       {Type: graphql.String, Default: ""},
+				"to":         {Type: graphql.String, Default: ""},
+			}),
+			Multiplier: graphqlMultiplier("first"),
+			Resolve:    graphql.Each(app.graphqlBouts),
+		},
+		"search": {
+			Object: searchHit,
+			List:   true,
+			Args: map[string]*graphql.Argument{
+				"q":     {Type: graphql.String, NonNull: true},
+				"types": {Type: graphql.String, List: true},
+				"limit": {Type: graphql.Int, Default: 20},
+			},
+			Multiplier: graphqlMultiplier("limit"),
+			Resolve:    graphql.Each(app.graphqlSearch),
+		},
+	}
+
+	return &graphql.Schema{Query: query}
+}
+
+func graphqlProperty[T any](typ string, fn func(T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: graphql.Property(func(src interface{}) interface{} {
+			return fn(src.(T))
+		}),
+	}
+}
+
+func graphqlListProperty[T any](typ string, fn func(T) interface{}) *graphql.Field {
+	field := graphqlProperty(typ, fn)
+	field.List = true
+
+	return field
+}
+
+func graphqlPageArgs(sort string, args map[string]*graphql.Argument) map[string]*graphql.Argument {
+	args["first"] = &graphql.Argument{Type: graphql.Int, Default: 20}
+	args["page"] = &graphql.Argument{Type: graphql.Int, Default: 1}
+	args["sort"] = &graphql.Argument{Type: graphql.String, Default: sort}
+
+	return args
+}
+
+func graphqlMultiplier(arg string) func(args map[string]interface{}) int {
+	return func(args map[string]interface{}) int {
+		n, _ := args[arg].(int)
+
+		switch {
+		case n < 1:
+			return 1
+		case n > graphqlMaxFirst:
+			return graphqlMaxFirst
+		}
+
+		return n
+	}
+}
+
+func graphqlInt(args map[string]interface{}, name string) int {
+	n, _ := args[name].(int)
+	return n
+}
+
+func graphqlString(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func graphqlFilters(args map[string]interface{}, safelist []string, v *validator.Validator) data.Filters {
+	filters := data.Filters{
+		Page:         graphqlInt(args, "page"),
+		PageSize:     graphqlInt(args, "first"),
+		Sort:         graphqlString(args, "sort"),
+		SortSafelist: safelist,
+	}
+
+	v.Check(filters.Page > 0, "page", "must be greater than zero")
+	v.Check(filters.Page <= 10_000_000, "page", "must be a maximum of 10 million")
+	v.Check(validator.In(filters.Sort, safelist...), "sort", "invalid sort value")
+	graphqlCheckFirst(v, "first", filters.PageSize)
+
+	return filters
+}
+
+func graphqlCheckFirst(v *validator.Validator, name string, n int) {
+	v.Check(n > 0, name, "must be greater than zero")
+	v.Check(n <= graphqlMaxFirst, name, "must be a maximum of "+strconv.Itoa(graphqlMaxFirst))
+}
+
+func graphqlValidationError(errs map[string]string) error {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, key+": "+errs[key])
+	}
+
+	return graphql.Errorf("%s", strings.Join(messages, "; "))
+}
+
+func graphqlTournamentTime(name string) time.Time {
+	fields := strings.Fields(name)
+	if len(fields) < 2 {
+		return time.Time{}
+	}
+
+	date, _ := time.Parse("2006 Jan", fields[0]+" "+fields[len(fields)-1])
+
+	return date
+}
+
+func (app *application) graphqlRikishi(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	rikishi, err := app.models.Rikishis.Get(ctx, graphqlString(args, "shikona"))
+	if errors.Is(err, data.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return rikishi, err
+}
+
+func (app *application) graphqlRikishis(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	v := validator.New()
+
+	filters := graphqlFilters(args, []string{"shikona", "highest_rank", "heya", "-shikona", "-highest_rank", "-heya"}, v)
+	if !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	rikishis, _, err := app.models.Rikishis.GetAll(ctx, graphqlString(args, "shikona"), graphqlString(args, "highestRank"), graphqlString(args, "heya"), filters)
+
+	return rikishis, err
+}
+
+func (app *application) graphqlHeya(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	name := graphqlString(args, "name")
+
+	rikishis, _, err := app.models.Rikishis.GetAll(ctx, "", "", name, data.Filters{Page: 1, PageSize: 1, Sort: "shikona", SortSafelist: []string{"shikona"}})
+	if err != nil || len(rikishis) == 0 {
+		return nil, err
+	}
+
+	return &graphqlHeya{Name: rikishis[0].Heya, Kanji: rikishis[0].HeyaKanji, Kana: rikishis[0].HeyaKana}, nil
+}
+
+func (app *application) graphqlTournament(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	name := graphqlString(args, "name")
+
+	if !validator.ValidTournament(name) {
+		return nil, graphqlValidationError(map[string]string{"name": "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov"})
+	}
+
+	return &graphqlTournament{Name: name}, nil
+}
+
+func (app *application) graphqlTournamentResult(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	id, err := strconv.ParseInt(graphqlString(args, "id"), 10, 64)
+	if err != nil || id < 1 {
+		return nil, graphqlValidationError(map[string]string{"id": "must be greater than zero"})
+	}
+
+	tr, err := app.models.TournamentsResults.Get(ctx, id)
+	if errors.Is(err, data.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return tr, err
+}
+
+func (app *application) graphqlTournamentsResults(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	v := validator.New()
+
+	filters := graphqlFilters(args, []string{"id", "tournament", "rikishi", "rank", "wins", "-id", "-tournament", "-rikishi", "-rank", "-wins"}, v)
+	if !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	shikonas, err := app.models.Rikishis.GetShikonaHistory(ctx, graphqlString(args, "rikishi"))
+	if err != nil {
+		return nil, err
+	}
+
+	trs, _, err := app.models.TournamentsResults.GetAll(ctx, graphqlString(args, "tournament"), graphqlString(args, "rank"), graphqlInt(args, "wins"), shikonas, filters)
+
+	return trs, err
+}
+
+func (app *application) graphqlBout(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	id, err := strconv.ParseInt(graphqlString(args, "id"), 10, 64)
+	if err != nil || id < 1 {
+		return nil, graphqlValidationError(map[string]string{"id": "must be greater than zero"})
+	}
+
+	bout, err := app.models.Bouts.Get(ctx, id)
+	if errors.Is(err, data.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return bout, err
+}
+
+func (app *application) graphqlBouts(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	v := validator.New()
+
+	filters := graphqlFilters(args, []string{"id", "tournament", "day", "winner", "loser", "kimarite", "-id", "-tournament", "-day", "-winner", "-loser", "-kimarite"}, v)
+
+	tournament, day, division := graphqlString(args, "tournament"), graphqlString(args, "day"), graphqlString(args, "division")
+	from, to := graphqlString(args, "from"), graphqlString(args, "to")
+
+	v.Check(tournament == "" || validator.ValidTournament(tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+	v.Check(day == "" || validator.ValidDay(day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+	v.Check(division == "" || validator.In(division, data.Divisions...), "division", "must be one of "+strings.Join(data.Divisions, ", "))
+	v.Check(from == "" || validator.ValidTournament(from), "from", "must be a tournament. Example: 2022 Nov")
+	v.Check(to == "" || validator.ValidTournament(to), "to", "must be a tournament. Example: 2022 Nov")
+
+	if !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	var shikonas [4][]string
+
+	for i, name := range []string{"rikishi1", "rikishi2", "winner", "loser"} {
+		history, err := app.models.Rikishis.GetShikonaHistory(ctx, graphqlString(args, name))
+		if err != nil {
+			return nil, err
+		}
+		shikonas[i] = history
+	}
+
+	bouts, _, err := app.models.Bouts.GetAll(ctx, tournament, day, division, graphqlString(args, "kimarite"), shikonas[0], shikonas[1], shikonas[2], shikonas[3], from, to, filters)
+
+	return bouts, err
+}
+
+func (app *application) graphqlSearch(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+	v := validator.New()
+
+	q, limit := graphqlString(args, "q"), graphqlInt(args, "limit")
+
+	types := []string{}
+	if list, ok := args["types"].([]interface{}); ok {
+		for _, kind := range list {
+			types = append(types, kind.(string))
+		}
+	}
+
+	for _, kind := range types {
+		v.Check(validator.In(kind, data.SearchKinds...), "types", "must only contain values from: "+strings.Join(data.SearchKinds, ", "))
+	}
+
+	if data.ValidateSearch(v, q, limit); !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	if len(types) == 0 {
+		types = data.SearchKinds
+	}
+
+	return app.models.Search.Search(ctx, q, types, limit)
+}
+
+func (app *application) graphqlRikishisByShikona(shikona func(src interface{}) string) graphql.ResolveFunc {
+	return func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		shikonas := make([]string, 0, len(sources))
+		for _, src := range sources {
+			shikonas = append(shikonas, shikona(src))
+		}
+
+		rikishis, err := app.models.Rikishis.GetByShikonas(ctx, shikonas)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, len(sources))
+		for i, name := range shikonas {
+			if rikishi, ok := rikishis[name]; ok {
+				values[i] = rikishi
+			}
+		}
+
+		return values, nil
+	}
+}
+
+func graphqlRikishiOwners(sources []interface{}) (map[string]string, []string) {
+	owners := make(map[string]string)
+	shikonas := []string{}
+
+	for _, src := range sources {
+		rikishi := src.(*data.Rikishi)
+
+		for _, shikona := range append([]string{rikishi.Shikona}, rikishi.ShikonaHistory...) {
+			if _, ok := owners[shikona]; !ok {
+				owners[shikona] = rikishi.Shikona
+				shikonas = append(shikonas, shikona)
+			}
+		}
+	}
+
+	return owners, shikonas
+}
+
+func (app *application) graphqlRikishiTournaments(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	last := graphqlInt(args, "last")
+	if graphqlCheckFirst(v, "last", last); !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	owners, shikonas := graphqlRikishiOwners(sources)
+
+	trs, err := app.models.TournamentsResults.GetForRikishis(ctx, nil, shikonas)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]*data.TournamentResult)
+	for _, tr := range trs {
+		owner := owners[tr.Rikishi]
+		results[owner] = append(results[owner], tr)
+	}
+
+	values := make([]interface{}, len(sources))
+
+	for i, src := range sources {
+		trs := results[src.(*data.Rikishi).Shikona]
+
+		sort.SliceStable(trs, func(i, j int) bool {
+			return graphqlTournamentTime(trs[i].Tournament).After(graphqlTournamentTime(trs[j].Tournament))
+		})
+
+		if len(trs) > last {
+			trs = trs[:last]
+		}
+
+		values[i] = append([]*data.TournamentResult{}, trs...)
+	}
+
+	return values, nil
+}
+
+func (app *application) graphqlRikishiBouts(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	first, tournament := graphqlInt(args, "first"), graphqlString(args, "tournament")
+
+	graphqlCheckFirst(v, "first", first)
+	v.Check(tournament == "" || validator.ValidTournament(tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+
+	if !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	groups := make([]data.BoutGroup, len(sources))
+	for i, src := range sources {
+		rikishi := src.(*data.Rikishi)
+		groups[i] = data.BoutGroup{Shikonas: append([]string{rikishi.Shikona}, rikishi.ShikonaHistory...), Tournament: tournament}
+	}
+
+	bouts, err := app.models.Bouts.GetForRikishis(ctx, groups, first)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(sources))
+	for i := range sources {
+		values[i] = append([]*data.Bout{}, bouts[i]...)
+	}
+
+	return values, nil
+}
+
+func (app *application) graphqlHeyaRikishis(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	first := graphqlInt(args, "first")
+	if graphqlCheckFirst(v, "first", first); !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	rikishis, err := app.models.Rikishis.GetForHeyas(ctx, graphqlNames(sources, func(src interface{}) string {
+		return src.(*graphqlHeya).Name
+	}), first)
+	if err != nil {
+		return nil, err
+	}
+
+	heyas := make(map[string][]*data.Rikishi)
+	for _, rikishi := range rikishis {
+		name := strings.ToLower(rikishi.Heya)
+		heyas[name] = append(heyas[name], rikishi)
+	}
+
+	values := make([]interface{}, len(sources))
+	for i, src := range sources {
+		values[i] = append([]*data.Rikishi{}, heyas[strings.ToLower(src.(*graphqlHeya).Name)]...)
+	}
+
+	return values, nil
+}
+
+// graphqlNames returns the distinct names of a batch of sources, compared
+// case-insensitively as the stores compare them.
+func graphqlNames(sources []interface{}, name func(src interface{}) string) []string {
+	seen := make(map[string]bool)
+	names := []string{}
+
+	for _, src := range sources {
+		name := name(src)
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func graphqlTournamentName(src interface{}) string {
+	return src.(*graphqlTournament).Name
+}
+
+func (app *application) graphqlTournamentResults(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	first := graphqlInt(args, "first")
+	if graphqlCheckFirst(v, "first", first); !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	trs, err := app.models.TournamentsResults.GetForTournaments(ctx, graphqlNames(sources, graphqlTournamentName), graphqlString(args, "rank"), first)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]*data.TournamentResult)
+	for _, tr := range trs {
+		name := strings.ToLower(tr.Tournament)
+		results[name] = append(results[name], tr)
+	}
+
+	values := make([]interface{}, len(sources))
+	for i, src := range sources {
+		values[i] = append([]*data.TournamentResult{}, results[strings.ToLower(graphqlTournamentName(src))]...)
+	}
+
+	return values, nil
+}
+
+func (app *application) graphqlTournamentBouts(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	first, day, division := graphqlInt(args, "first"), graphqlString(args, "day"), graphqlString(args, "division")
+
+	graphqlCheckFirst(v, "first", first)
+	v.Check(day == "" || validator.ValidDay(day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+	v.Check(division == "" || validator.In(division, data.Divisions...), "division", "must be one of "+strings.Join(data.Divisions, ", "))
+
+	if !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	bouts, err := app.models.Bouts.GetForTournaments(ctx, graphqlNames(sources, graphqlTournamentName), day, division, first)
+	if err != nil {
+		return nil, err
+	}
+
+	tournaments := make(map[string][]*data.Bout)
+	for _, bout := range bouts {
+		name := strings.ToLower(bout.Tournament)
+		tournaments[name] = append(tournaments[name], bout)
+	}
+
+	values := make([]interface{}, len(sources))
+	for i, src := range sources {
+		values[i] = append([]*data.Bout{}, tournaments[strings.ToLower(graphqlTournamentName(src))]...)
+	}
+
+	return values, nil
+}
+
+func (app *application) graphqlTournamentResultBouts(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+	v := validator.New()
+
+	first := graphqlInt(args, "first")
+	if graphqlCheckFirst(v, "first", first); !v.Valid() {
+		return nil, graphqlValidationError(v.Errors)
+	}
+
+	groups := make([]data.BoutGroup, len(sources))
+	for i, src := range sources {
+		tr := src.(*data.TournamentResult)
+		groups[i] = data.BoutGroup{Shikonas: []string{tr.Rikishi}, Tournament: tr.Tournament}
+	}
+
+	bouts, err := app.models.Bouts.GetForRikishis(ctx, groups, first)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(sources))
+	for i := range sources {
+		values[i] = append([]*data.Bout{}, bouts[i]...)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type countingBoutStore struct {
+	data.BoutStore
+	queries int
+}
+
+func (s *countingBoutStore) Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters data.Filters, fn func(*data.Bout) error) error {
+	s.queries++
+	return s.BoutStore.Stream(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, fn)
+}
+
+func (s *countingBoutStore) GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) ([]*data.Bout, error) {
+	s.queries++
+	return s.BoutStore.GetForTournaments(ctx, tournaments, day, division, limit)
+}
+
+func (s *countingBoutStore) GetForRikishis(ctx context.Context, groups []data.BoutGroup, limit int) ([][]*data.Bout, error) {
+	s.queries++
+	return s.BoutStore.GetForRikishis(ctx, groups, limit)
+}
+
+type graphqlTestResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+func graphqlRequest(t *testing.T, query string, variables map[string]interface{}) string {
+	t.Helper()
+
+	js, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(js)
+}
+
+func seedGraphQL(t *testing.T, app *application) {
+	t.Helper()
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama", HeyaKanji: "伊勢ヶ濱"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	seedTournamentsResults(t, app,
+		&data.TournamentResult{Tournament: "2022 Sep", Rikishi: "Terunofuji", Rank: "Y1e", Wins: 8, Losses: 5, Absent: 2},
+		&data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Terunofuji", Rank: "Y1e", Wins: 0, Losses: 0, Absent: 15},
+		&data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "O1e", Wins: 12, Losses: 3},
+		&data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Takayasu", Rank: "M1e", Wins: 12, Losses: 3},
+	)
+
+	seedBouts(t, app,
+		&data.Bout{Tournament: "2022 Sep", Day: "1", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Takayasu", Kimarite: "yorikiri"},
+		&data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"},
+		&data.Bout{Tournament: "2022 Nov", Day: "Playoff", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "tsukiotoshi"},
+	)
+}
+
+func TestGraphQLNestedQuery(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedGraphQL(t, app)
+
+	bouts := &countingBoutStore{BoutStore: app.models.Bouts}
+	app.models.Bouts = bouts
+
+	query := `query Rikishis($heya: String) {
+		rikishis(heya: $heya, sort: "shikona", first: 3) {
+			shikona
+			heya { name kanji }
+			tournaments(last: 5) {
+				tournament { name }
+				wins
+				bouts(first: 5) { day winner { shikona } kimariteKanji }
+			}
+		}
+	}`
+
+	res := ts.do(t, http.MethodPost, "/v1/graphql", graphqlRequest(t, query, nil), nil)
+	assertStatus(t, res, http.StatusOK)
+
+	var body graphqlTestResponse
+	res.decode(t, &body)
+
+	if len(body.Errors) > 0 {
+		t.Fatalf("got errors %+v", body.Errors)
+	}
+
+	want := `{"rikishis":[` +
+		`{"shikona":"Takakeisho","heya":{"name":"Tokiwayama","kanji":""},"tournaments":[{"tournament":{"name":"2022 Nov"},"wins":12,"bouts":[{"day":"1","winner":{"shikona":"Takakeisho"},"kimariteKanji":"押し出し"},{"day":"Playoff","winner":{"shikona":"Takakeisho"},"kimariteKanji":"突き落とし"}]}]},` +
+		`{"shikona":"Takayasu","heya":{"name":"Tagonoura","kanji":""},"tournaments":[{"tournament":{"name":"2022 Nov"},"wins":12,"bouts":[{"day":"1","winner":{"shikona":"Takakeisho"},"kimariteKanji":"押し出し"},{"day":"Playoff","winner":{"shikona":"Takakeisho"},"kimariteKanji":"突き落とし"}]}]},` +
+		`{"shikona":"Terunofuji","heya":{"name":"Isegahama","kanji":"伊勢ヶ濱"},"tournaments":[{"tournament":{"name":"2022 Nov"},"wins":0,"bouts":[]},{"tournament":{"name":"2022 Sep"},"wins":8,"bouts":[{"day":"1","winner":{"shikona":"Terunofuji"},"kimariteKanji":"寄り切り"}]}]}` +
+		`]}`
+
+	if string(body.Data) != want {
+		t.Errorf("got data %s; want %s", body.Data, want)
+	}
+
+	if bouts.queries != 1 {
+		t.Errorf("got %d bout queries; want 1", bouts.queries)
+	}
+}
+
+func TestGraphQLQueryCount(t *testing.T) {
+	app := newTestApplication(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+
+	app.config.db.driver = "sqlite"
+	app.config.db.dsn = filepath.Join(t.TempDir(), "sumodb.db")
+	app.config.db.maxOpenConns = 1
+	app.config.db.maxIdleTime = "15m"
+	app.config.db.timeouts = data.DefaultTimeouts
+	app.config.limiter.store = "memory"
+
+	db, err := openDB(app.config, tp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	err = migrateDB(db, app.config.db.driver, app.logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.models, err = newModels(app.config, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := newTestServer(t, app.routes())
+
+	seedGraphQL(t, app)
+
+	query := `query Rikishis($first: Int) {
+		rikishis(sort: "shikona", first: $first) {
+			heya { rikishis(first: 5) { shikona } }
+			bouts(first: 5) { id }
+			tournaments(last: 5) {
+				bouts(first: 5) { id }
+				tournament {
+					results(first: 5) { rank }
+					bouts(first: 5) { id }
+				}
+			}
+		}
+	}`
+
+	// Each nested field is loaded with one query for all of its parents, so
+	// asking for more rikishis must not add queries.
+	queries := func(first int) int {
+		recorder.Reset()
+
+		res := ts.do(t, http.MethodPost, "/v1/graphql", graphqlRequest(t, query, map[string]interface{}{"first": first}), nil)
+		assertStatus(t, res, http.StatusOK)
+
+		var body graphqlTestResponse
+		res.decode(t, &body)
+
+		if len(body.Errors) > 0 {
+			t.Fatalf("got errors %+v", body.Errors)
+		}
+
+		n := 0
+		for _, span := range recorder.Ended() {
+			if span.Name() == "sql.conn.query" {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	if one, all := queries(1), queries(3); one != all {
+		t.Errorf("got %d queries for one rikishi and %d for three; want the same", one, all)
+	}
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedGraphQL(t, app)
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		headers   map[string]string
+		wantCode  int
+		wantError string
+	}{
+		{
+			name:     "Query string",
+			method:   http.MethodGet,
+			path:     "/v1/graphql?query=" + url.QueryEscape(`query($id: ID!) { bout(id: $id) { winner { shikona } loser { shikona } } }`) + "&variables=" + url.QueryEscape(`{"id": 1}`),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Fragments and directives",
+			method:   http.MethodPost,
+			path:     "/v1/graphql",
+			body:     graphqlRequest(t, `{ tournament(name: "2022 Nov") { results(first: 2) { ...result } bouts @skip(if: true) { id } } } fragment result on TournamentResult { rank rikishi { shikona } }`, nil),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Missing record",
+			method:   http.MethodPost,
+			path:     "/v1/graphql",
+			body:     graphqlRequest(t, `{ rikishi(shikona: "Hakuho") { shikona } }`, nil),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "Invalid argument",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ rikishis(first: 1000) { shikona } }`, nil),
+			wantCode:  http.StatusOK,
+			wantError: "first: must be a maximum of 100",
+		},
+		{
+			name:      "Syntax error",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ rikishis { shikona }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: `syntax error: unexpected "end of document"`,
+		},
+		{
+			name:      "Unknown field",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ rikishis { weight } }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: `cannot query field "weight" on type "Rikishi"`,
+		},
+		{
+			name:     "Introspection",
+			method:   http.MethodPost,
+			path:     "/v1/graphql",
+			body:     graphqlRequest(t, `{ __schema { queryType { name } types { name fields { name type { kind ofType { kind ofType { name } } } } } } __type(name: "Rikishi") { fields { name } } }`, nil),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "Too deep",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ bout(id: 1) { winner { tournaments { bouts { winner { heya { name } } } } } } }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: "query depth exceeds the maximum of 6",
+		},
+		{
+			name:      "Too complex",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ rikishis(first: 100) { tournaments(last: 100) { rank } } }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: "query complexity",
+		},
+		{
+			name:      "Fragment cycle",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `{ rikishis { ...a } } fragment a on Rikishi { heya { rikishis { ...a } } }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: `fragment "a" cannot spread itself`,
+		},
+		{
+			name:      "Mutation",
+			method:    http.MethodPost,
+			path:      "/v1/graphql",
+			body:      graphqlRequest(t, `mutation { deleteRikishi }`, nil),
+			wantCode:  http.StatusBadRequest,
+			wantError: "mutation operations are not supported",
+		},
+		{
+			name:     "Missing query",
+			method:   http.MethodPost,
+			path:     "/v1/graphql",
+			body:     `{"query": ""}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Invalid API key",
+			method:   http.MethodPost,
+			path:     "/v1/graphql",
+			body:     graphqlRequest(t, `{ rikishis { shikona } }`, nil),
+			headers:  map[string]string{"Authorization": "Bearer wrong-key"},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, tt.method, tt.path, tt.body, tt.headers)
+			assertStatus(t, res, tt.wantCode)
+
+			if tt.wantCode == http.StatusUnprocessableEntity || tt.wantCode == http.StatusUnauthorized {
+				return
+			}
+
+			var body graphqlTestResponse
+			res.decode(t, &body)
+
+			if tt.wantError == "" {
+				if len(body.Errors) > 0 {
+					t.Fatalf("got errors %+v", body.Errors)
+				}
+				return
+			}
+
+			if len(body.Errors) == 0 || !strings.Contains(body.Errors[0].Message, tt.wantError) {
+				t.Fatalf("got errors %+v; want %q", body.Errors, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	sumodbpb.UnimplementedSumodbServer
+	app *application
+}
+
+func (app *application) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(app.grpcRequestIDUnary, app.grpcTraceUnary, app.grpcRecoverUnary, app.grpcAuthenticateUnary),
+		grpc.ChainStreamInterceptor(app.grpcRequestIDStream, app.grpcTraceStream, app.grpcRecoverStream, app.grpcAuthenticateStream),
+	)
+
+	sumodbpb.RegisterSumodbServer(srv, &grpcServer{app: app})
+
+	return srv
+}
+
+func (app *application) grpcRequestIDUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, id, err := app.grpcRequestID(ctx)
+	if err != nil {
+		return nil, app.grpcServerError(ctx, info.FullMethod, err)
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+
+	return handler(ctx, req)
+}
+
+func (app *application) grpcRequestIDStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, id, err := app.grpcRequestID(ss.Context())
+	if err != nil {
+		return app.grpcServerError(ctx, info.FullMethod, err)
+	}
+
+	ss.SetHeader(metadata.Pairs("x-request-id", id))
+
+	return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// grpcRequestID mirrors the requestID middleware, reading and returning the
+// ID through x-request-id metadata.
+func (app *application) grpcRequestID(ctx context.Context) (context.Context, string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var id string
+	if values := md.Get("x-request-id"); len(values) > 0 && validRequestID(values[0]) {
+		id = values[0]
+	} else {
+		var err error
+
+		id, err = generateRequestID()
+		if err != nil {
+			return ctx, "", err
+		}
+	}
+
+	return context.WithValue(ctx, requestInfoContextKey, &requestInfo{id: id}), id, nil
+}
+
+func (app *application) grpcRecoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = app.grpcServerError(ctx, info.FullMethod, fmt.Errorf("%s", p))
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func (app *application) grpcRecoverStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = app.grpcServerError(ss.Context(), info.FullMethod, fmt.Errorf("%s", p))
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func (app *application) grpcAuthenticateUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := app.grpcAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (app *application) grpcAuthenticateStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := app.grpcAuthenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+}
+
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcAuthenticate mirrors the authenticate middleware, reading the API key
+// from the authorization metadata instead of the Authorization header.
+func (app *application) grpcAuthenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	headerParts := strings.Split(values[0], " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing API key")
+	}
+
+	key := headerParts[1]
+
+	for _, apiKey := range app.config.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return context.WithValue(ctx, apiKeyContextKey, key), nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid or missing API key")
+}
+
+func (app *application) grpcAPIKey(ctx context.Context) string {
+	key, ok := ctx.Value(apiKeyContextKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return key
+}
+
+func (app *application) grpcServerError(ctx context.Context, method string, err error) error {
+	properties := jsonlog.Properties{
+		"grpc_method": method,
+	}
+
+	if info, ok := ctx.Value(requestInfoContextKey).(*requestInfo); ok {
+		properties["request_id"] = info.id
+	}
+
+	app.logger.PrintError(err, properties)
+
+	return status.Error(codes.Internal, "The server encountered a problem and could not process your request")
+}
+
+func (app *application) grpcError(ctx context.Context, err error) error {
+	method, _ := grpc.Method(ctx)
+
+	switch {
+	case errors.Is(err, data.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "the requested resourse cannot be found")
+	case errors.Is(err, data.ErrEditConflict):
+		return status.Error(codes.Aborted, "unable to update the record due to an edit conflict, please try again later")
+	case data.IsCanceled(err) && errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, "the request was canceled before it could complete")
+	case data.IsCanceled(err):
+		app.grpcServerError(ctx, method, err)
+		return status.Error(codes.DeadlineExceeded, "the server timed out waiting for the database, please try again later")
+	default:
+		return app.grpcServerError(ctx, method, err)
+	}
+}
+
+func (app *application) grpcFailedValidation(errs map[string]string) error {
+	return app.grpcFailedBatchValidation("", map[int]map[string]string{-1: errs})
+}
+
+// grpcFailedBatchValidation reports batch errors with the item index in the
+// field name, for example bouts[3].winner.
+func (app *application) grpcFailedBatchValidation(name string, errs map[int]map[string]string) error {
+	br := &errdetails.BadRequest{}
+
+	for i, fields := range errs {
+		for field, description := range fields {
+			if i >= 0 {
+				field = fmt.Sprintf("%s[%d].%s", name, i, field)
+			}
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: description,
+			})
+		}
+	}
+
+	sort.Slice(br.FieldViolations, func(i, j int) bool {
+		return br.FieldViolations[i].Field < br.FieldViolations[j].Field
+	})
+
+	st, err := status.New(codes.InvalidArgument, "failed validation").WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "failed validation")
+	}
+
+	return st.Err()
+}
+
+// grpcCheckVersion is the If-Match check for gRPC, where a zero version
+// means the caller did not send one.
+func (app *application) grpcCheckVersion(want, version int32) error {
+	if want == 0 {
+		if app.config.requireIfMatch {
+			return status.Error(codes.FailedPrecondition, "this request must include a version")
+		}
+		return nil
+	}
+
+	if want != version {
+		return app.grpcPreconditionFailed()
+	}
+
+	return nil
+}
+
+func (app *application) grpcPreconditionFailed() error {
+	return status.Error(codes.FailedPrecondition, "the record has been modified since it was retrieved, fetch the latest version and try again")
+}
+
+func (app *application) grpcAPIKeyRequired() error {
+	return status.Error(codes.Unauthenticated, "you must provide an API key to access this resource")
+}
+
+func (app *application) grpcFilters(page *sumodbpb.PageRequest, sort string, safelist []string, v *validator.Validator) data.Filters {
+	filters := data.Filters{
+		Page:         1,
+		PageSize:     20,
+		Sort:         sort,
+		SortSafelist: safelist,
+	}
+
+	if page != nil {
+		if page.Page != 0 {
+			filters.Page = int(page.Page)
+		}
+		if page.PageSize != 0 {
+			filters.PageSize = int(page.PageSize)
+		}
+		if page.Sort != "" {
+			filters.Sort = page.Sort
+		}
+		filters.Cursor = page.Cursor
+		filters.Unlimited = page.All
+	}
+
+	filters.IncludeTotal = filters.Cursor == "" && !page.GetSkipTotal()
+
+	data.ValidateFilters(v, filters)
+
+	return filters
+}
+
+func grpcMetadata(m data.Metadata) *sumodbpb.Metadata {
+	return &sumodbpb.Metadata{
+		CurrentPage:  int32(m.CurrentPage),
+		PageSize:     int32(m.PageSize),
+		FirstPage:    int32(m.FirstPage),
+		LastPage:     int32(m.LastPage),
+		TotalRecords: int32(m.TotalRecords),
+		NextCursor:   m.NextCursor,
+		PrevCursor:   m.PrevCursor,
+	}
+}
+
+func (s *grpcServer) Healthcheck(ctx context.Context, req *sumodbpb.HealthcheckRequest) (*sumodbpb.HealthcheckResponse, error) {
+	return &sumodbpb.HealthcheckResponse{
+		Status:      "available",
+		Environment: s.app.config.env,
+		Version:     version,
+	}, nil
+}
+
+func (s *grpcServer) Search(ctx context.Context, req *sumodbpb.SearchRequest) (*sumodbpb.SearchResponse, error) {
+	v := validator.New()
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 20
+	}
+
+	for _, kind := range req.Types {
+		if !validator.In(kind, data.SearchKinds...) {
+			v.AddError("types", "must only contain values from: "+strings.Join(data.SearchKinds, ", "))
+			break
+		}
+	}
+
+	if data.ValidateSearch(v, req.Q, limit); !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	types := req.Types
+	if len(types) == 0 {
+		types = data.SearchKinds
+	}
+
+	hits, err := s.app.models.Search.Search(ctx, req.Q, types, limit)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.SearchResponse{Results: make([]*sumodbpb.SearchHit, len(hits))}
+
+	for i, hit := range hits {
+		res.Results[i] = &sumodbpb.SearchHit{
+			Kind:      hit.Kind,
+			Value:     hit.Value,
+			Rikishi:   hit.Rikishi,
+			Score:     hit.Score,
+			Highlight: hit.Highlight,
+		}
+	}
+
+	return res, nil
+}
+
+func (s *grpcServer) RegisterUser(ctx context.Context, req *sumodbpb.RegisterUserRequest) (*sumodbpb.User, error) {
+	user := &data.User{
+		Name:      req.Name,
+		Email:     req.Email,
+		Activated: false,
+	}
+
+	err := user.Password.Set(req.Password)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	v := validator.New()
+
+	if data.ValidateUser(v, user); !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.Users.Insert(ctx, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateEmail):
+			v.AddError("email", "a user with this email address already exist")
+			return nil, s.app.grpcFailedValidation(v.Errors)
+		default:
+			return nil, s.app.grpcError(ctx, err)
+		}
+	}
+
+	return &sumodbpb.User{
+		Id:        user.ID,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		Name:      user.Name,
+		Email:     user.Email,
+		Activated: user.Activated,
+	}, nil
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/events"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcBoutEventTypes = map[string]sumodbpb.BoutEvent_Type{
+	events.BoutCreated: sumodbpb.BoutEvent_TYPE_CREATED,
+	events.BoutUpdated: sumodbpb.BoutEvent_TYPE_UPDATED,
+	events.BoutDeleted: sumodbpb.BoutEvent_TYPE_DELETED,
+}
+
+func grpcBout(bout *data.Bout) *sumodbpb.Bout {
+	reading := data.KimariteReadings[strings.ToLower(bout.Kimarite)]
+
+	return &sumodbpb.Bout{
+		Id:            bout.ID,
+		Tournament:    bout.Tournament,
+		Day:           bout.Day,
+		Division:      bout.Division,
+		Winner:        bout.Winner,
+		Loser:         bout.Loser,
+		Kimarite:      bout.Kimarite,
+		KimariteKanji: reading.Kanji,
+		KimariteKana:  reading.Kana,
+		Version:       bout.Version,
+	}
+}
+
+func (s *grpcServer) CreateBout(ctx context.Context, req *sumodbpb.CreateBoutRequest) (*sumodbpb.Bout, error) {
+	bout := &data.Bout{
+		Tournament: req.Tournament,
+		Day:        req.Day,
+		Division:   req.Division,
+		Winner:     req.Winner,
+		Loser:      req.Loser,
+		Kimarite:   req.Kimarite,
+	}
+
+	v := validator.New()
+	err := data.ValidateBout(ctx, v, bout, s.app.models.Rikishis)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.Bouts.Insert(ctx, bout)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.events.Publish(events.BoutCreated, bout)
+
+	return grpcBout(bout), nil
+}
+
+func (s *grpcServer) CreateBouts(ctx context.Context, req *sumodbpb.CreateBoutsRequest) (*sumodbpb.CreateBoutsResponse, error) {
+	input := req.Bouts
+
+	v := validator.New()
+	v.Check(len(input) > 0, "bouts", "must contain at least 1 bout")
+	v.Check(len(input) <= s.app.config.batch.maxSize, "bouts", fmt.Sprintf("must not contain more than %d bouts", s.app.config.batch.maxSize))
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	bouts := make([]*data.Bout, len(input))
+	shikonas := make([]string, 0, 2*len(input))
+
+	for i, item := range input {
+		bouts[i] = &data.Bout{
+			Tournament: item.Tournament,
+			Day:        item.Day,
+			Division:   item.Division,
+			Winner:     item.Winner,
+			Loser:      item.Loser,
+			Kimarite:   item.Kimarite,
+		}
+		shikonas = append(shikonas, item.Winner, item.Loser)
+	}
+
+	existing, err := s.app.models.Rikishis.ExistingShikonas(ctx, shikonas)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	batchErrors := make(map[int]map[string]string)
+
+	for i, bout := range bouts {
+		v := validator.New()
+		err = data.ValidateBout(ctx, v, bout, existing)
+		if err != nil {
+			return nil, s.app.grpcError(ctx, err)
+		}
+
+		if !v.Valid() {
+			batchErrors[i] = v.Errors
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		return nil, s.app.grpcFailedBatchValidation("bouts", batchErrors)
+	}
+
+	err = s.app.models.Bouts.InsertBatch(ctx, bouts)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.CreateBoutsResponse{Bouts: make([]*sumodbpb.Bout, len(bouts))}
+
+	for i, bout := range bouts {
+		s.app.events.Publish(events.BoutCreated, bout)
+		res.Bouts[i] = grpcBout(bout)
+	}
+
+	return res, nil
+}
+
+func (s *grpcServer) GetBout(ctx context.Context, req *sumodbpb.GetBoutRequest) (*sumodbpb.Bout, error) {
+	bout, err := s.app.models.Bouts.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcBout(bout), nil
+}
+
+func (s *grpcServer) UpdateBout(ctx context.Context, req *sumodbpb.UpdateBoutRequest) (*sumodbpb.Bout, error) {
+	bout, err := s.app.models.Bouts.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, bout.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Tournament != nil {
+		bout.Tournament = *req.Tournament
+	}
+
+	if req.Day != nil {
+		bout.Day = *req.Day
+	}
+
+	if req.Division != nil {
+		bout.Division = *req.Division
+	}
+
+	if req.Winner != nil {
+		bout.Winner = *req.Winner
+	}
+
+	if req.Loser != nil {
+		bout.Loser = *req.Loser
+	}
+
+	if req.Kimarite != nil {
+		bout.Kimarite = *req.Kimarite
+	}
+
+	v := validator.New()
+	err = data.ValidateBout(ctx, v, bout, s.app.models.Rikishis)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.Bouts.Update(ctx, bout)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.events.Publish(events.BoutUpdated, bout)
+
+	return grpcBout(bout), nil
+}
+
+func (s *grpcServer) DeleteBout(ctx context.Context, req *sumodbpb.DeleteBoutRequest) (*sumodbpb.DeleteResponse, error) {
+	bout, err := s.app.models.Bouts.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, bout.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app.models.Bouts.Delete(ctx, req.Id, bout.Version)
+	if errors.Is(err, data.ErrEditConflict) {
+		return nil, s.app.grpcPreconditionFailed()
+	}
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.events.Publish(events.BoutDeleted, bout)
+
+	return &sumodbpb.DeleteResponse{Message: "bout successfully deleted"}, nil
+}
+
+func (s *grpcServer) ListBouts(ctx context.Context, req *sumodbpb.ListBoutsRequest) (*sumodbpb.ListBoutsResponse, error) {
+	v := validator.New()
+
+	filters := s.app.grpcFilters(req.Page, "id", []string{"id", "tournament", "day", "winner", "loser", "kimarite", "-id", "-tournament", "-day", "-winner", "-loser", "-kimarite"}, v)
+
+	v.Check(req.Tournament == "" || validator.ValidTournament(req.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+	v.Check(req.Day == "" || validator.ValidDay(req.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+	v.Check(req.Division == "" || validator.In(req.Division, data.Divisions...), "division", "must be one of "+strings.Join(data.Divisions, ", "))
+	v.Check(req.From == "" || validator.ValidTournament(req.From), "from", "must be a tournament. Example: 2022 Nov")
+	v.Check(req.To == "" || validator.ValidTournament(req.To), "to", "must be a tournament. Example: 2022 Nov")
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	if filters.Unlimited && s.app.grpcAPIKey(ctx) == "" {
+		return nil, s.app.grpcAPIKeyRequired()
+	}
+
+	var shikonas [4][]string
+
+	for i, rikishi := range []string{req.Rikishi1, req.Rikishi2, req.Winner, req.Loser} {
+		history, err := s.app.models.Rikishis.GetShikonaHistory(ctx, rikishi)
+		if err != nil {
+			return nil, s.app.grpcError(ctx, err)
+		}
+		shikonas[i] = history
+	}
+
+	bouts, metadata, err := s.app.models.Bouts.GetAll(ctx, req.Tournament, req.Day, req.Division, req.Kimarite, shikonas[0], shikonas[1], shikonas[2], shikonas[3], req.From, req.To, filters)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.ListBoutsResponse{
+		Bouts:    make([]*sumodbpb.Bout, len(bouts)),
+		Metadata: grpcMetadata(metadata),
+	}
+
+	for i, bout := range bouts {
+		res.Bouts[i] = grpcBout(bout)
+	}
+
+	return res, nil
+}
+
+func (s *grpcServer) WatchBouts(req *sumodbpb.WatchBoutsRequest, stream grpc.ServerStreamingServer[sumodbpb.BoutEvent]) error {
+	ctx := stream.Context()
+
+	v := validator.New()
+	v.Check(req.Tournament == "" || validator.ValidTournament(req.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+	v.Check(req.Day == "" || validator.ValidDay(req.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+
+	if !v.Valid() {
+		return s.app.grpcFailedValidation(v.Errors)
+	}
+
+	shikonas, err := s.app.models.Rikishis.GetShikonaHistory(ctx, req.Rikishi)
+	if err != nil {
+		return s.app.grpcError(ctx, err)
+	}
+
+	if req.Rikishi != "" && len(shikonas) == 0 {
+		shikonas = []string{req.Rikishi}
+	}
+
+	filter := events.BoutFilter{Tournament: req.Tournament, Day: req.Day, Shikonas: shikonas}
+
+	sub := s.app.events.Subscribe(64)
+	defer sub.Close()
+
+	// Sending headers tells the client the subscription is live, so events
+	// published after it sees them will be delivered.
+	err = stream.SendHeader(nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.Unavailable, "the event stream was closed, please reconnect")
+			}
+
+			if !filter.Matches(event.Bout) {
+				continue
+			}
+
+			err := stream.Send(&sumodbpb.BoutEvent{
+				Id:   event.ID,
+				Type: grpcBoutEventTypes[event.Type],
+				Bout: grpcBout(&event.Bout),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+)
+
+func grpcRikishi(rikishi *data.Rikishi) *sumodbpb.Rikishi {
+	return &sumodbpb.Rikishi{
+		Shikona:        rikishi.Shikona,
+		HighestRank:    rikishi.HighestRank,
+		Heya:           rikishi.Heya,
+		ShikonaHistory: rikishi.ShikonaHistory,
+		ShikonaKanji:   rikishi.ShikonaKanji,
+		ShikonaKana:    rikishi.ShikonaKana,
+		HeyaKanji:      rikishi.HeyaKanji,
+		HeyaKana:       rikishi.HeyaKana,
+		Version:        rikishi.Version,
+	}
+}
+
+func (s *grpcServer) CreateRikishi(ctx context.Context, req *sumodbpb.CreateRikishiRequest) (*sumodbpb.Rikishi, error) {
+	rikishi := &data.Rikishi{
+		Shikona:        req.Shikona,
+		HighestRank:    req.HighestRank,
+		Heya:           req.Heya,
+		ShikonaHistory: req.ShikonaHistory,
+		ShikonaKanji:   req.ShikonaKanji,
+		ShikonaKana:    req.ShikonaKana,
+		HeyaKanji:      req.HeyaKanji,
+		HeyaKana:       req.HeyaKana,
+	}
+
+	v := validator.New()
+
+	if data.ValidateRikishi(v, rikishi); !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err := s.app.models.Rikishis.Insert(ctx, rikishi)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcRikishi(rikishi), nil
+}
+
+func (s *grpcServer) GetRikishi(ctx context.Context, req *sumodbpb.GetRikishiRequest) (*sumodbpb.Rikishi, error) {
+	rikishi, err := s.app.models.Rikishis.Get(ctx, req.Shikona)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcRikishi(rikishi), nil
+}
+
+func (s *grpcServer) UpdateRikishi(ctx context.Context, req *sumodbpb.UpdateRikishiRequest) (*sumodbpb.Rikishi, error) {
+	rikishi, err := s.app.models.Rikishis.Get(ctx, req.Shikona)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, rikishi.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.NewShikona != nil {
+		rikishi.Shikona = *req.NewShikona
+	}
+
+	if req.HighestRank != nil {
+		rikishi.HighestRank = *req.HighestRank
+	}
+
+	if req.Heya != nil {
+		rikishi.Heya = *req.Heya
+	}
+
+	if req.ShikonaHistory != nil {
+		rikishi.ShikonaHistory = req.ShikonaHistory.Values
+	}
+
+	if req.ShikonaKanji != nil {
+		rikishi.ShikonaKanji = *req.ShikonaKanji
+	}
+
+	if req.ShikonaKana != nil {
+		rikishi.ShikonaKana = *req.ShikonaKana
+	}
+
+	if req.HeyaKanji != nil {
+		rikishi.HeyaKanji = *req.HeyaKanji
+	}
+
+	if req.HeyaKana != nil {
+		rikishi.HeyaKana = *req.HeyaKana
+	}
+
+	v := validator.New()
+
+	if data.ValidateRikishi(v, rikishi); !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.Rikishis.Update(ctx, rikishi)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcRikishi(rikishi), nil
+}
+
+func (s *grpcServer) DeleteRikishi(ctx context.Context, req *sumodbpb.DeleteRikishiRequest) (*sumodbpb.DeleteResponse, error) {
+	rikishi, err := s.app.models.Rikishis.Get(ctx, req.Shikona)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, rikishi.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app.models.Rikishis.Delete(ctx, req.Shikona, rikishi.Version)
+	if errors.Is(err, data.ErrEditConflict) {
+		return nil, s.app.grpcPreconditionFailed()
+	}
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return &sumodbpb.DeleteResponse{Message: "rikishi successfully deleted"}, nil
+}
+
+func (s *grpcServer) ListRikishis(ctx context.Context, req *sumodbpb.ListRikishisRequest) (*sumodbpb.ListRikishisResponse, error) {
+	v := validator.New()
+
+	filters := s.app.grpcFilters(req.Page, "shikona", []string{"shikona", "highest_rank", "heya", "-shikona", "-highest_rank", "-heya"}, v)
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	if filters.Unlimited && s.app.grpcAPIKey(ctx) == "" {
+		return nil, s.app.grpcAPIKeyRequired()
+	}
+
+	rikishis, metadata, err := s.app.models.Rikishis.GetAll(ctx, req.Shikona, req.HighestRank, req.Heya, filters)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.ListRikishisResponse{
+		Rikishis: make([]*sumodbpb.Rikishi, len(rikishis)),
+		Metadata: grpcMetadata(metadata),
+	}
+
+	for i, rikishi := range rikishis {
+		res.Rikishis[i] = grpcRikishi(rikishi)
+	}
+
+	return res, nil
+}
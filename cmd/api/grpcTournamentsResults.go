@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+)
+
+func grpcTournamentResult(tr *data.TournamentResult) *sumodbpb.TournamentResult {
+	return &sumodbpb.TournamentResult{
+		Id:         tr.ID,
+		Tournament: tr.Tournament,
+		Rikishi:    tr.Rikishi,
+		Rank:       tr.Rank,
+		Wins:       tr.Wins,
+		Losses:     tr.Losses,
+		Absent:     tr.Absent,
+		Version:    tr.Version,
+	}
+}
+
+func (s *grpcServer) CreateTournamentResult(ctx context.Context, req *sumodbpb.CreateTournamentResultRequest) (*sumodbpb.TournamentResult, error) {
+	tr := &data.TournamentResult{
+		Tournament: req.Tournament,
+		Rikishi:    req.Rikishi,
+		Rank:       req.Rank,
+		Wins:       req.Wins,
+		Losses:     req.Losses,
+		Absent:     req.Absent,
+	}
+
+	v := validator.New()
+	err := data.ValidateTournamentResult(ctx, v, tr, s.app.models.Rikishis)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.TournamentsResults.Insert(ctx, tr)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcTournamentResult(tr), nil
+}
+
+func (s *grpcServer) CreateTournamentsResults(ctx context.Context, req *sumodbpb.CreateTournamentsResultsRequest) (*sumodbpb.CreateTournamentsResultsResponse, error) {
+	input := req.TournamentsResults
+
+	v := validator.New()
+	v.Check(len(input) > 0, "tournaments_results", "must contain at least 1 tournament result")
+	v.Check(len(input) <= s.app.config.batch.maxSize, "tournaments_results", fmt.Sprintf("must not contain more than %d tournament results", s.app.config.batch.maxSize))
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	trs := make([]*data.TournamentResult, len(input))
+	shikonas := make([]string, 0, len(input))
+
+	for i, item := range input {
+		trs[i] = &data.TournamentResult{
+			Tournament: item.Tournament,
+			Rikishi:    item.Rikishi,
+			Rank:       item.Rank,
+			Wins:       item.Wins,
+			Losses:     item.Losses,
+			Absent:     item.Absent,
+		}
+		shikonas = append(shikonas, item.Rikishi)
+	}
+
+	existing, err := s.app.models.Rikishis.ExistingShikonas(ctx, shikonas)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	batchErrors := make(map[int]map[string]string)
+
+	for i, tr := range trs {
+		v := validator.New()
+		err = data.ValidateTournamentResult(ctx, v, tr, existing)
+		if err != nil {
+			return nil, s.app.grpcError(ctx, err)
+		}
+
+		if !v.Valid() {
+			batchErrors[i] = v.Errors
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		return nil, s.app.grpcFailedBatchValidation("tournaments_results", batchErrors)
+	}
+
+	err = s.app.models.TournamentsResults.InsertBatch(ctx, trs)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.CreateTournamentsResultsResponse{TournamentsResults: make([]*sumodbpb.TournamentResult, len(trs))}
+
+	for i, tr := range trs {
+		res.TournamentsResults[i] = grpcTournamentResult(tr)
+	}
+
+	return res, nil
+}
+
+func (s *grpcServer) GetTournamentResult(ctx context.Context, req *sumodbpb.GetTournamentResultRequest) (*sumodbpb.TournamentResult, error) {
+	tr, err := s.app.models.TournamentsResults.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcTournamentResult(tr), nil
+}
+
+func (s *grpcServer) UpdateTournamentResult(ctx context.Context, req *sumodbpb.UpdateTournamentResultRequest) (*sumodbpb.TournamentResult, error) {
+	tr, err := s.app.models.TournamentsResults.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, tr.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Tournament != nil {
+		tr.Tournament = *req.Tournament
+	}
+
+	if req.Rikishi != nil {
+		tr.Rikishi = *req.Rikishi
+	}
+
+	if req.Rank != nil {
+		tr.Rank = *req.Rank
+	}
+
+	if req.Wins != nil {
+		tr.Wins = *req.Wins
+	}
+
+	if req.Losses != nil {
+		tr.Losses = *req.Losses
+	}
+
+	if req.Absent != nil {
+		tr.Absent = *req.Absent
+	}
+
+	v := validator.New()
+	err = data.ValidateTournamentResult(ctx, v, tr, s.app.models.Rikishis)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	err = s.app.models.TournamentsResults.Update(ctx, tr)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return grpcTournamentResult(tr), nil
+}
+
+func (s *grpcServer) DeleteTournamentResult(ctx context.Context, req *sumodbpb.DeleteTournamentResultRequest) (*sumodbpb.DeleteResponse, error) {
+	tr, err := s.app.models.TournamentsResults.Get(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	err = s.app.grpcCheckVersion(req.Version, tr.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.app.models.TournamentsResults.Delete(ctx, req.Id, tr.Version)
+	if errors.Is(err, data.ErrEditConflict) {
+		return nil, s.app.grpcPreconditionFailed()
+	}
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return &sumodbpb.DeleteResponse{Message: "tournament record successfully deleted"}, nil
+}
+
+func (s *grpcServer) ListTournamentsResults(ctx context.Context, req *sumodbpb.ListTournamentsResultsRequest) (*sumodbpb.ListTournamentsResultsResponse, error) {
+	v := validator.New()
+
+	filters := s.app.grpcFilters(req.Page, "id", []string{"id", "tournament", "rikishi", "rank", "wins", "-id", "-tournament", "-rikishi", "-rank", "-wins"}, v)
+
+	if !v.Valid() {
+		return nil, s.app.grpcFailedValidation(v.Errors)
+	}
+
+	if filters.Unlimited && s.app.grpcAPIKey(ctx) == "" {
+		return nil, s.app.grpcAPIKeyRequired()
+	}
+
+	shikonas, err := s.app.models.Rikishis.GetShikonaHistory(ctx, req.Rikishi)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	trs, metadata, err := s.app.models.TournamentsResults.GetAll(ctx, req.Tournament, req.Rank, int(req.Wins), shikonas, filters)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	res := &sumodbpb.ListTournamentsResultsResponse{
+		TournamentsResults: make([]*sumodbpb.TournamentResult, len(trs)),
+		Metadata:           grpcMetadata(metadata),
+	}
+
+	for i, tr := range trs {
+		res.TournamentsResults[i] = grpcTournamentResult(tr)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestGRPCClient(t *testing.T, app *application) sumodbpb.SumodbClient {
+	lis := bufconn.Listen(1 << 20)
+
+	srv := app.newGRPCServer()
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return sumodbpb.NewSumodbClient(conn)
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("got code %s; want %s; error: %v", got, want, err)
+	}
+}
+
+func TestGRPCRikishis(t *testing.T) {
+	app := newTestApplication(t)
+	client := newTestGRPCClient(t, app)
+	ctx := context.Background()
+
+	created, err := client.CreateRikishi(ctx, &sumodbpb.CreateRikishiRequest{
+		Shikona:        "Terunofuji",
+		HighestRank:    "Yokozuna",
+		Heya:           "Isegahama",
+		ShikonaHistory: []string{"Terunofuji"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetRikishi(ctx, &sumodbpb.GetRikishiRequest{Shikona: "Terunofuji"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, created) {
+		t.Errorf("got %v; want %v", got, created)
+	}
+
+	updated, err := client.UpdateRikishi(ctx, &sumodbpb.UpdateRikishiRequest{
+		Shikona: "Terunofuji",
+		Version: created.Version,
+		Heya:    proto.String("Miyagino"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Heya != "Miyagino" || updated.Version != created.Version+1 {
+		t.Errorf("got heya %q version %d; want Miyagino version %d", updated.Heya, updated.Version, created.Version+1)
+	}
+
+	_, err = client.UpdateRikishi(ctx, &sumodbpb.UpdateRikishiRequest{
+		Shikona: "Terunofuji",
+		Version: created.Version,
+		Heya:    proto.String("Isegahama"),
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	list, err := client.ListRikishis(ctx, &sumodbpb.ListRikishisRequest{Heya: "Miyagino"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Rikishis) != 1 || list.Metadata.TotalRecords != 1 {
+		t.Errorf("got %d rikishis and %d total records; want 1", len(list.Rikishis), list.Metadata.TotalRecords)
+	}
+
+	_, err = client.DeleteRikishi(ctx, &sumodbpb.DeleteRikishiRequest{Shikona: "Terunofuji"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetRikishi(ctx, &sumodbpb.GetRikishiRequest{Shikona: "Terunofuji"})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestGRPCErrors(t *testing.T) {
+	app := newTestApplication(t)
+	client := newTestGRPCClient(t, app)
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := client.CreateBout(context.Background(), &sumodbpb.CreateBoutRequest{
+			Tournament: "2022 Nov",
+			Day:        "1",
+			Division:   "Makuuchi",
+			Winner:     "Terunofuji",
+			Loser:      "Hakuho",
+			Kimarite:   "yorikiri",
+		})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("Batch validation", func(t *testing.T) {
+		valid := &sumodbpb.CreateBoutRequest{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Takayasu", Kimarite: "yorikiri"}
+		invalid := &sumodbpb.CreateBoutRequest{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Hakuho", Loser: "Takayasu", Kimarite: "yorikiri"}
+
+		_, err := client.CreateBouts(context.Background(), &sumodbpb.CreateBoutsRequest{Bouts: []*sumodbpb.CreateBoutRequest{valid, invalid}})
+		assertCode(t, err, codes.InvalidArgument)
+
+		var fields []string
+		for _, detail := range status.Convert(err).Details() {
+			if br, ok := detail.(*errdetails.BadRequest); ok {
+				for _, violation := range br.FieldViolations {
+					fields = append(fields, violation.Field)
+				}
+			}
+		}
+
+		if len(fields) != 1 || fields[0] != "bouts[1].winner" {
+			t.Errorf("got field violations %q; want [bouts[1].winner]", fields)
+		}
+	})
+
+	t.Run("Batch too large", func(t *testing.T) {
+		bout := &sumodbpb.CreateBoutRequest{}
+
+		_, err := client.CreateBouts(context.Background(), &sumodbpb.CreateBoutsRequest{Bouts: []*sumodbpb.CreateBoutRequest{bout, bout, bout, bout}})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := client.GetBout(context.Background(), &sumodbpb.GetBoutRequest{Id: 42})
+		assertCode(t, err, codes.NotFound)
+	})
+
+	t.Run("Request ID", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+
+		var header metadata.MD
+
+		_, err := client.GetBout(ctx, &sumodbpb.GetBoutRequest{Id: 42}, grpc.Header(&header))
+		assertCode(t, err, codes.NotFound)
+
+		if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+			t.Errorf("got x-request-id %q; want [req-1]", got)
+		}
+	})
+
+	t.Run("Invalid API key", func(t *testing.T) {
+		_, err := client.Healthcheck(withKey("wrong-key"), &sumodbpb.HealthcheckRequest{})
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("All without API key", func(t *testing.T) {
+		_, err := client.ListBouts(context.Background(), &sumodbpb.ListBoutsRequest{Page: &sumodbpb.PageRequest{All: true}})
+		assertCode(t, err, codes.Unauthenticated)
+	})
+
+	t.Run("All with API key", func(t *testing.T) {
+		_, err := client.ListBouts(withKey("test-key"), &sumodbpb.ListBoutsRequest{Page: &sumodbpb.PageRequest{All: true}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		_, err := client.ListBouts(context.Background(), &sumodbpb.ListBoutsRequest{Page: &sumodbpb.PageRequest{PageSize: 1000}})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("Version required", func(t *testing.T) {
+		app.config.requireIfMatch = true
+		defer func() { app.config.requireIfMatch = false }()
+
+		_, err := client.DeleteRikishi(context.Background(), &sumodbpb.DeleteRikishiRequest{Shikona: "Takayasu"})
+		assertCode(t, err, codes.FailedPrecondition)
+	})
+}
+
+func TestGRPCWatchBouts(t *testing.T) {
+	app := newTestApplication(t)
+	client := newTestGRPCClient(t, app)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchBouts(ctx, &sumodbpb.WatchBoutsRequest{Rikishi: "Terunofuji"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stream.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := ts.do(t, http.MethodPost, "/v1/bouts", `{"tournament": "2022 Nov", "day": "1", "division": "Makuuchi", "winner": "Takakeisho", "loser": "Takayasu", "kimarite": "oshidashi"}`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	created, err := client.CreateBout(context.Background(), &sumodbpb.CreateBoutRequest{
+		Tournament: "2022 Nov",
+		Day:        "2",
+		Division:   "Makuuchi",
+		Winner:     "Terunofuji",
+		Loser:      "Takayasu",
+		Kimarite:   "yorikiri",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.DeleteBout(context.Background(), &sumodbpb.DeleteBoutRequest{Id: created.Id, Version: created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []sumodbpb.BoutEvent_Type{sumodbpb.BoutEvent_TYPE_CREATED, sumodbpb.BoutEvent_TYPE_DELETED} {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.Type != want || event.Bout.Id != created.Id || event.Bout.KimariteKanji != "寄り切り" {
+			t.Errorf("got event %v; want %s for bout %d", event, want, created.Id)
+		}
+	}
+
+	app.events.Close()
+
+	_, err = stream.Recv()
+	assertCode(t, err, codes.Unavailable)
+}
@@ -38,7 +38,10 @@ func (app *application) readShikonaParam(r *http.Request) (string, error) {
 	return strings.ReplaceAll(shikona, "-", " "), nil
 }
 
-func (app *application) writeJSON(w http.ResponseWriter, status int, data envelope, headers http.Header) error {
+func (app *application) writeJSON(w http.ResponseWriter, r *http.Request, status int, data envelope, headers http.Header) error {
+	_, span := app.tracerProvider.Tracer(tracerName).Start(r.Context(), "writeJSON")
+	defer span.End()
+
 	js, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -57,6 +60,69 @@ func (app *application) writeJSON(w http.ResponseWriter, status int, data envelo
 	return nil
 }
 
+func (app *application) etag(version int32) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func (app *application) etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (app *application) checkIfMatch(w http.ResponseWriter, r *http.Request, version int32) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if app.config.requireIfMatch {
+			app.preconditionRequiredResponse(w, r)
+			return false
+		}
+		return true
+	}
+
+	if !app.etagMatches(header, app.etag(version), false) {
+		app.preconditionFailedResponse(w, r)
+		return false
+	}
+
+	return true
+}
+
+func (app *application) checkIfNoneMatch(w http.ResponseWriter, r *http.Request, version int32) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	etag := app.etag(version)
+
+	if !app.etagMatches(header, etag, true) {
+		return false
+	}
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+
+	return true
+}
+
 func (app *application) readJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
 	maxBytes := 1_048_576
 
@@ -128,6 +194,19 @@ func (app *application) readCSV(qs url.Values, key string, defaultValue []string
 	return strings.Split(csv, ",")
 }
 
+func (app *application) readList(qs url.Values, key string, safelist []string, v *validator.Validator) []string {
+	values := app.readCSV(qs, key, []string{})
+
+	for _, value := range values {
+		if !validator.In(value, safelist...) {
+			v.AddError(key, "must only contain values from: "+strings.Join(safelist, ", "))
+			break
+		}
+	}
+
+	return values
+}
+
 func (app *application) readInt(qs url.Values, key string, defaultValue int, v *validator.Validator) int {
 	s := qs.Get(key)
 	if s == "" {
@@ -142,3 +221,18 @@ func (app *application) readInt(qs url.Values, key string, defaultValue int, v *
 
 	return i
 }
+
+func (app *application) readBool(qs url.Values, key string, defaultValue bool, v *validator.Validator) bool {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddError(key, "must be a boolean value")
+		return defaultValue
+	}
+
+	return b
+}
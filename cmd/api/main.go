@@ -3,60 +3,186 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"flag"
+	"fmt"
+	"net/netip"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/events"
+	"github.com/corsairconstantine/sumodb/internal/graphql"
 	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"github.com/corsairconstantine/sumodb/internal/migrate"
+	"github.com/corsairconstantine/sumodb/migrations"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	_ "modernc.org/sqlite"
 )
 
 const version = "1.0.0"
 
 type config struct {
-	port int
-	env  string
-	db   struct {
+	port           int
+	adminAddr      string
+	adminPort      int
+	env            string
+	requireIfMatch bool
+	apiKeys        []string
+	trustedProxies []netip.Prefix
+	db             struct {
+		driver       string
 		dsn          string
 		maxOpenConns int
 		maxIdleConns int
 		maxIdleTime  string
+		autoMigrate  bool
+		timeouts     data.Timeouts
 	}
 	limiter struct {
-		rps     float64
-		burst   int
-		enabled bool
+		rps            float64
+		burst          int
+		keyRPS         float64
+		keyBurst       int
+		routeQuotas    map[string]data.RateLimit
+		keyRouteQuotas map[string]data.RateLimit
+		store          string
+		enabled        bool
+	}
+	batch struct {
+		maxSize int
+	}
+	graphql struct {
+		maxDepth      int
+		maxComplexity int
+	}
+	grpc struct {
+		port int
+	}
+	stream struct {
+		heartbeat time.Duration
+		logSize   int
+	}
+	webhooks struct {
+		maxAttempts       int
+		backoff           time.Duration
+		timeout           time.Duration
+		pollInterval      time.Duration
+		allowPrivateHosts bool
+	}
+	tracing struct {
+		exporter    string
+		file        string
+		sampleRatio float64
+	}
+	log struct {
+		level       jsonlog.Level
+		errorStacks bool
+		sampling    jsonlog.Sampling
 	}
 }
 
 type application struct {
-	config config
-	logger *jsonlog.Logger
-	models data.Models
+	config         config
+	logger         *jsonlog.Logger
+	models         data.Models
+	events         *events.Hub
+	metrics        *appMetrics
+	tracerProvider trace.TracerProvider
+	graphql        *graphql.Schema
 }
 
 func main() {
 	var cfg config
 
 	flag.IntVar(&cfg.port, "port", 4000, "API server port")
+	flag.StringVar(&cfg.adminAddr, "admin-addr", "127.0.0.1", "Admin server listen address")
+	flag.IntVar(&cfg.adminPort, "admin-port", 4002, "Admin server port, serving /metrics and /log-level")
 	flag.StringVar(&cfg.env, "env", "development", "Environment (development|staging|production)")
 
-	flag.StringVar(&cfg.db.dsn, "db-dsn", os.Getenv("SUMODB_DSN"), "PostgreSQL DSN")
+	flag.BoolVar(&cfg.requireIfMatch, "require-if-match", false, "Reject PATCH and DELETE requests without an If-Match header")
+
+	flag.StringVar(&cfg.db.driver, "db-driver", "postgres", "Database driver (postgres|sqlite)")
+	flag.StringVar(&cfg.db.dsn, "db-dsn", os.Getenv("SUMODB_DSN"), "PostgreSQL DSN or SQLite database file")
 
 	flag.IntVar(&cfg.db.maxOpenConns, "db-max-open-conns", 25, "PostgreSQL max open connections")
 	flag.IntVar(&cfg.db.maxIdleConns, "db-max-idle-conns", 25, "PostgreSQL max idle connections")
 	flag.StringVar(&cfg.db.maxIdleTime, "db-max-idle-time", "15m", "PostgreSQL max idle time")
+	flag.BoolVar(&cfg.db.autoMigrate, "auto-migrate", false, "Apply pending database migrations on startup")
+	flag.DurationVar(&cfg.db.timeouts.Read, "db-read-timeout", data.DefaultTimeouts.Read, "Timeout for single database reads (0 disables the limit)")
+	flag.DurationVar(&cfg.db.timeouts.Write, "db-write-timeout", data.DefaultTimeouts.Write, "Timeout for database inserts, updates and deletes (0 disables the limit)")
+	flag.DurationVar(&cfg.db.timeouts.Batch, "db-batch-timeout", data.DefaultTimeouts.Batch, "Timeout for batch inserts and full-table reads (0 disables the limit)")
+	flag.DurationVar(&cfg.db.timeouts.Search, "db-search-timeout", data.DefaultTimeouts.Search, "Timeout for search queries (0 disables the limit)")
 
 	flag.Float64Var(&cfg.limiter.rps, "limiter-rps", 2, "Rate limiter max requests per second")
 	flag.IntVar(&cfg.limiter.burst, "limiter-burst", 4, "Rate limiter max burst")
+	flag.Float64Var(&cfg.limiter.keyRPS, "limiter-key-rps", 20, "Rate limiter max requests per second for each API key")
+	flag.IntVar(&cfg.limiter.keyBurst, "limiter-key-burst", 40, "Rate limiter max burst for each API key")
+	flag.Func("limiter-route-quotas", "Extra quotas per client IP for route groups (search|graphql|stream|write), as space separated group=rps:burst", func(val string) (err error) {
+		cfg.limiter.routeQuotas, err = parseRateLimitQuotas(val)
+		return err
+	})
+	flag.Func("limiter-key-route-quotas", "Extra quotas per API key for route groups, as space separated group=rps:burst", func(val string) (err error) {
+		cfg.limiter.keyRouteQuotas, err = parseRateLimitQuotas(val)
+		return err
+	})
+	flag.StringVar(&cfg.limiter.store, "limiter-store", "memory", "Rate limiter store (memory|database); database shares quotas across replicas")
 	flag.BoolVar(&cfg.limiter.enabled, "limiter-enabled", true, "Enable rate limiter")
 
+	flag.Func("trusted-proxies", "Proxy addresses or CIDR ranges whose X-Forwarded-For header is trusted (space separated)", func(val string) (err error) {
+		cfg.trustedProxies, err = parseTrustedProxies(val)
+		return err
+	})
+
+	flag.Func("api-keys", "API keys allowed to run unbounded exports (space separated)", func(val string) error {
+		cfg.apiKeys = strings.Fields(val)
+		return nil
+	})
+
+	flag.IntVar(&cfg.batch.maxSize, "batch-max-size", 100, "Maximum number of items in a batch request")
+
+	flag.IntVar(&cfg.graphql.maxDepth, "graphql-max-depth", 8, "Maximum GraphQL query depth (0 disables the limit)")
+	flag.IntVar(&cfg.graphql.maxComplexity, "graphql-max-complexity", 10000, "Maximum GraphQL query complexity (0 disables the limit)")
+
+	flag.IntVar(&cfg.grpc.port, "grpc-port", 4001, "gRPC server port")
+
+	flag.DurationVar(&cfg.stream.heartbeat, "stream-heartbeat", 15*time.Second, "Interval between heartbeats on event streams")
+	flag.IntVar(&cfg.stream.logSize, "stream-log-size", 1000, "Number of recent bout events kept for stream resumption")
+
+	flag.IntVar(&cfg.webhooks.maxAttempts, "webhook-max-attempts", 8, "Attempts before a webhook delivery is dead-lettered")
+	flag.DurationVar(&cfg.webhooks.backoff, "webhook-backoff", 30*time.Second, "Delay before the first webhook retry, doubled on each later attempt")
+	flag.DurationVar(&cfg.webhooks.timeout, "webhook-timeout", 10*time.Second, "Timeout for a single webhook delivery")
+	flag.DurationVar(&cfg.webhooks.pollInterval, "webhook-poll-interval", 5*time.Second, "Interval between checks for due webhook deliveries")
+	flag.BoolVar(&cfg.webhooks.allowPrivateHosts, "webhook-allow-private-hosts", false, "Allow webhooks to loopback and private addresses")
+
+	flag.StringVar(&cfg.tracing.exporter, "trace-exporter", "none", "Trace exporter (none|stdout|file)")
+	flag.StringVar(&cfg.tracing.file, "trace-file", "traces.jsonl", "File the file trace exporter appends spans to")
+	flag.Float64Var(&cfg.tracing.sampleRatio, "trace-sample-ratio", 1, "Fraction of new traces to sample; incoming sampled traces are always continued")
+
+	flag.TextVar(&cfg.log.level, "log-level", jsonlog.LevelInfo, "Minimum log level (debug|info|warn|error|fatal|off)")
+	flag.BoolVar(&cfg.log.errorStacks, "log-error-stacks", true, "Include stack traces in error logs; fatal logs always include them")
+	flag.IntVar(&cfg.log.sampling.First, "log-sample-first", 0, "Identical log messages written per tick before sampling starts (0 disables sampling)")
+	flag.IntVar(&cfg.log.sampling.Thereafter, "log-sample-thereafter", 100, "Once sampling starts, write every nth identical log message (0 drops them)")
+	flag.DurationVar(&cfg.log.sampling.Tick, "log-sample-tick", time.Second, "Interval over which identical log messages are counted for sampling")
+
 	flag.Parse()
 
-	logger := jsonlog.New(os.Stdout, jsonlog.LevelInfo)
+	logger := jsonlog.NewWithOptions(os.Stdout, jsonlog.Options{
+		MinLevel:        cfg.log.level,
+		FatalStacksOnly: !cfg.log.errorStacks,
+		Sampling:        &cfg.log.sampling,
+	})
 
-	db, err := openDB(cfg)
+	tracerProvider, shutdownTracing, err := newTracerProvider(cfg)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	db, err := openDB(cfg, tracerProvider)
 	if err != nil {
 		logger.PrintFatal(err, nil)
 	}
@@ -64,20 +190,64 @@ func main() {
 
 	logger.PrintInfo("database connection pool established", nil)
 
+	if cfg.db.autoMigrate {
+		err = migrateDB(db, cfg.db.driver, logger)
+		if err != nil {
+			logger.PrintFatal(err, nil)
+		}
+	}
+
+	models, err := newModels(cfg, db)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	metrics := newAppMetrics()
+	metrics.registerDBStats(db)
+
 	app := &application{
-		config: cfg,
-		logger: logger,
-		models: data.NewModels(db),
+		config:         cfg,
+		logger:         logger,
+		models:         data.InstrumentModels(models, tracerProvider, metrics.observeQuery),
+		events:         events.NewHub(cfg.stream.logSize),
+		metrics:        metrics,
+		tracerProvider: tracerProvider,
 	}
+	app.graphql = app.graphqlSchema()
 
 	err = app.serve()
 	if err != nil {
 		logger.PrintFatal(err, nil)
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = shutdownTracing(ctx)
+	if err != nil {
+		logger.PrintError(err, nil)
+	}
 }
 
-func openDB(cfg config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", cfg.db.dsn)
+// openDB wraps the driver so every statement gets a span under the calling
+// model method's.
+func openDB(cfg config, tp trace.TracerProvider) (*sql.DB, error) {
+	var db *sql.DB
+	var err error
+
+	options := []otelsql.Option{
+		otelsql.WithTracerProvider(tp),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true}),
+	}
+
+	switch cfg.db.driver {
+	case "postgres":
+		db, err = otelsql.Open("postgres", cfg.db.dsn, append(options, otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL))...)
+	case "sqlite":
+		db, err = otelsql.Open("sqlite", data.SQLiteDSN(cfg.db.dsn), append(options, otelsql.WithAttributes(semconv.DBSystemNameSQLite))...)
+	default:
+		err = fmt.Errorf("unsupported database driver %q", cfg.db.driver)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -102,3 +272,46 @@ func openDB(cfg config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+func newModels(cfg config, db *sql.DB) (data.Models, error) {
+	models := data.NewModels(db, cfg.db.timeouts)
+	if cfg.db.driver == "sqlite" {
+		models = data.NewSQLiteModels(db, cfg.db.timeouts)
+	}
+
+	switch cfg.limiter.store {
+	case "memory":
+		models.RateLimits = data.NewMemoryRateLimitStore()
+	case "database":
+	default:
+		return data.Models{}, fmt.Errorf("unsupported rate limiter store %q", cfg.limiter.store)
+	}
+
+	return models, nil
+}
+
+func migrateDB(db *sql.DB, driver string, logger *jsonlog.Logger) error {
+	m, err := migrate.New(db, driver, migrations.For(driver))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	err = m.Up(ctx)
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.PrintInfo("database migrations applied", jsonlog.Properties{
+		"version": version,
+	})
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/metrics"
+	"github.com/julienschmidt/httprouter"
+)
+
+type appMetrics struct {
+	registry        *metrics.Registry
+	requests        *metrics.CounterVec
+	requestDuration *metrics.HistogramVec
+	rateLimited     *metrics.CounterVec
+	panics          *metrics.CounterVec
+	queryDuration   *metrics.HistogramVec
+}
+
+func newAppMetrics() *appMetrics {
+	r := metrics.NewRegistry()
+
+	return &appMetrics{
+		registry:        r,
+		requests:        r.NewCounterVec("sumodb_http_requests_total", "HTTP requests served, by route pattern.", "method", "route", "status"),
+		requestDuration: r.NewHistogramVec("sumodb_http_request_duration_seconds", "Time taken to serve HTTP requests, by route pattern.", metrics.DefaultBuckets, "method", "route", "status"),
+		rateLimited:     r.NewCounterVec("sumodb_rate_limited_requests_total", "Requests rejected by the rate limiter."),
+		panics:          r.NewCounterVec("sumodb_panics_recovered_total", "Panics recovered while serving HTTP requests."),
+		queryDuration:   r.NewHistogramVec("sumodb_db_query_duration_seconds", "Time taken by model methods, by model and method.", metrics.DefaultBuckets, "model", "method"),
+	}
+}
+
+func (m *appMetrics) observeQuery(model, method string, d time.Duration) {
+	m.queryDuration.Observe(d.Seconds(), model, method)
+}
+
+func (m *appMetrics) registerDBStats(db *sql.DB) {
+	gauge := func(name, help string, value func(sql.DBStats) int) {
+		m.registry.NewGaugeFunc(name, help, func() float64 { return float64(value(db.Stats())) })
+	}
+
+	counter := func(name, help string, value func(sql.DBStats) int64) {
+		m.registry.NewCounterFunc(name, help, func() float64 { return float64(value(db.Stats())) })
+	}
+
+	gauge("sumodb_db_max_open_connections", "Maximum number of open connections to the database.", func(s sql.DBStats) int { return s.MaxOpenConnections })
+	gauge("sumodb_db_open_connections", "Established connections, both in use and idle.", func(s sql.DBStats) int { return s.OpenConnections })
+	gauge("sumodb_db_in_use_connections", "Connections currently in use.", func(s sql.DBStats) int { return s.InUse })
+	gauge("sumodb_db_idle_connections", "Idle connections.", func(s sql.DBStats) int { return s.Idle })
+
+	counter("sumodb_db_wait_count_total", "Connections waited for.", func(s sql.DBStats) int64 { return s.WaitCount })
+	counter("sumodb_db_max_idle_closed_total", "Connections closed due to the max idle connections limit.", func(s sql.DBStats) int64 { return s.MaxIdleClosed })
+	counter("sumodb_db_max_idle_time_closed_total", "Connections closed due to the max idle time.", func(s sql.DBStats) int64 { return s.MaxIdleTimeClosed })
+	counter("sumodb_db_max_lifetime_closed_total", "Connections closed due to the max connection lifetime.", func(s sql.DBStats) int64 { return s.MaxLifetimeClosed })
+
+	m.registry.NewCounterFunc("sumodb_db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+}
+
+// patternRouter records the pattern of the matched route, so metrics are
+// labelled by route rather than by every distinct URL.
+type patternRouter struct {
+	*httprouter.Router
+	app *application
+}
+
+func (app *application) newRouter() patternRouter {
+	return patternRouter{httprouter.New(), app}
+}
+
+func (pr patternRouter) HandlerFunc(method, path string, handler http.HandlerFunc) {
+	pr.Router.HandlerFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		pr.app.contextSetRoute(r, path)
+		handler(w, r)
+	})
+}
+
+func (app *application) recordMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		// Requests rejected before routing or matching no route are
+		// grouped together, so probing random paths cannot create
+		// unbounded label values.
+		pattern := app.contextGetRequestInfo(r).route
+		if pattern == "" {
+			pattern = "unmatched"
+		}
+
+		status := strconv.Itoa(rw.status)
+
+		app.metrics.requests.Inc(r.Method, pattern, status)
+		app.metrics.requestDuration.Observe(time.Since(start).Seconds(), r.Method, pattern, status)
+	})
+}
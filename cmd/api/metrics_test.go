@@ -0,0 +1,75 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestMetrics(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.limiter.enabled = true
+	app.config.limiter.rps = 0.001
+	app.config.limiter.burst = 4
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.SetMaxOpenConns(7)
+	app.metrics.registerDBStats(db)
+
+	ts := newTestServer(t, app.routes())
+	admin := newTestServer(t, app.adminRoutes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+	seedBouts(t, app, &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"})
+
+	assertStatus(t, ts.do(t, http.MethodGet, "/v1/bouts/1", "", nil), http.StatusOK)
+	assertStatus(t, ts.do(t, http.MethodGet, "/v1/bouts/2", "", nil), http.StatusNotFound)
+	assertStatus(t, ts.do(t, http.MethodGet, "/v1/nowhere", "", nil), http.StatusNotFound)
+	assertStatus(t, ts.do(t, http.MethodGet, "/v1/bouts/stream?day=16", "", nil), http.StatusUnprocessableEntity)
+	assertStatus(t, ts.do(t, http.MethodGet, "/v1/healthcheck", "", nil), http.StatusTooManyRequests)
+
+	panicking := newTestServer(t, app.recordMetrics(app.recoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))))
+	assertStatus(t, panicking.do(t, http.MethodGet, "/", "", nil), http.StatusInternalServerError)
+
+	res := admin.do(t, http.MethodGet, "/metrics", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	if ct := res.header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("got Content-Type %q; want the Prometheus text format", ct)
+	}
+
+	body := string(res.body)
+
+	for _, want := range []string{
+		`sumodb_http_requests_total{method="GET",route="/v1/bouts/:id",status="200"} 1`,
+		`sumodb_http_requests_total{method="GET",route="/v1/bouts/:id",status="404"} 1`,
+		`sumodb_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`sumodb_http_requests_total{method="GET",route="/v1/bouts/stream",status="422"} 1`,
+		`sumodb_http_requests_total{method="GET",route="unmatched",status="429"} 1`,
+		`sumodb_http_request_duration_seconds_count{method="GET",route="/v1/bouts/:id",status="200"} 1`,
+		`sumodb_rate_limited_requests_total 1`,
+		`sumodb_panics_recovered_total 1`,
+		`sumodb_db_query_duration_seconds_count{model="bouts",method="Get"} 2`,
+		`sumodb_db_max_open_connections 7`,
+		`# TYPE sumodb_db_wait_duration_seconds_total counter`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("got metrics without %q", want)
+		}
+	}
+
+	assertStatus(t, admin.do(t, http.MethodGet, "/v1/healthcheck", "", nil), http.StatusNotFound)
+}
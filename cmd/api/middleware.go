@@ -1,81 +1,252 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"net/http"
-	"sync"
+	"net/netip"
+	"strings"
 	"time"
 
-	"golang.org/x/time/rate"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
 )
 
-func (app *application) rateLimit(next http.Handler) http.Handler {
-	type client struct {
-		limiter  *rate.Limiter
-		lastSeen time.Time
+func (app *application) recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				app.metrics.panics.Inc()
+				w.Header().Set("Connection", "close")
+				app.serverErrorResponse(w, r, fmt.Errorf("%s", err))
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (app *application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Authorization")
+
+		authorizationHeader := r.Header.Get("Authorization")
+		if authorizationHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, ok := app.parseAPIKey(authorizationHeader)
+		if !ok {
+			app.invalidAPIKeyResponse(w, r)
+			return
+		}
+
+		r = app.contextSetAPIKey(r, key)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseAPIKey returns the key from a Bearer Authorization header if it is one
+// of the configured API keys.
+func (app *application) parseAPIKey(authorizationHeader string) (string, bool) {
+	headerParts := strings.Split(authorizationHeader, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return "", false
 	}
-	var (
-		mu      sync.Mutex
-		clients = make(map[string]*client)
-	)
 
-	go func() {
-		for {
-			time.Sleep(time.Minute)
+	key := headerParts[1]
 
-			mu.Lock()
+	for _, apiKey := range app.config.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return key, true
+		}
+	}
 
-			for ip, client := range clients {
-				if time.Since(client.lastSeen) > 3*time.Minute {
-					delete(clients, ip)
-				}
-			}
+	return "", false
+}
 
-			mu.Unlock()
+func (app *application) requireAPIKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if app.contextGetAPIKey(r) == "" {
+			app.apiKeyRequiredResponse(w, r)
+			return
 		}
 
-	}()
+		next.ServeHTTP(w, r)
+	}
+}
 
+// requestID reuses the client's X-Request-ID when it is safe to echo back,
+// so IDs can be correlated across services, and otherwise generates one.
+func (app *application) requestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if app.config.limiter.enabled {
-			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		id := r.Header.Get("X-Request-ID")
+
+		if !validRequestID(id) {
+			var err error
+
+			id, err = generateRequestID()
 			if err != nil {
 				app.serverErrorResponse(w, r, err)
 				return
 			}
+		}
 
-			mu.Lock()
+		w.Header().Set("X-Request-ID", id)
 
-			if _, found := clients[ip]; !found {
-				clients[ip] = &client{
-					limiter: rate.NewLimiter(rate.Limit(app.config.limiter.rps), app.config.limiter.burst),
-				}
-			}
+		r = app.contextSetRequestInfo(r, &requestInfo{id: id})
 
-			clients[ip].lastSeen = time.Now()
+		next.ServeHTTP(w, r)
+	})
+}
 
-			if !clients[ip].limiter.Allow() {
-				mu.Unlock()
-				app.rateLimitExceededResponse(w, r)
-				return
-			}
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
 
-			mu.Unlock()
+	return true
+}
 
-			next.ServeHTTP(w, r)
+func generateRequestID() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// clientIP is the peer address, unless the peer is a trusted proxy. Then it is
+// the rightmost X-Forwarded-For entry not added by a trusted proxy, as entries
+// further left are whatever the client chose to send.
+func (app *application) clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !app.trustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			break
 		}
-	})
+
+		ip = addr.Unmap().String()
+
+		if !app.trustedProxy(ip) {
+			break
+		}
+	}
+
+	return ip
 }
 
-func (app *application) recoverPanic(next http.Handler) http.Handler {
+func (app *application) trustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range app.config.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiKeyID identifies the caller in logs. API keys are the only identity
+// clients have, so a fingerprint is logged rather than the key itself.
+func apiKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:6])
+}
+
+func (app *application) logRequest(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				w.Header().Set("Connection", "close")
-				app.serverErrorResponse(w, r, fmt.Errorf("%s", err))
-			}
-		}()
-		next.ServeHTTP(w, r)
+		start := time.Now()
+
+		rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		info := app.contextGetRequestInfo(r)
+
+		properties := jsonlog.Properties{
+			"request_id":     info.id,
+			"request_method": r.Method,
+			"request_url":    r.URL.String(),
+			"status":         rw.status,
+			"bytes":          rw.bytes,
+			"duration":       time.Since(start),
+			"client_ip":      app.clientIP(r),
+		}
+
+		if info.route != "" {
+			properties["route"] = info.route
+		}
+
+		if info.apiKey != "" {
+			properties["user_id"] = apiKeyID(info.apiKey)
+		}
+
+		app.logger.PrintInfo("request completed", properties)
 	})
 }
+
+// responseRecorder records the status and size of a response. Unwrap lets
+// http.ResponseController reach the underlying writer, so streaming handlers
+// can still flush.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (rw *responseRecorder) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseRecorder) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+
+	return n, err
+}
+
+func (rw *responseRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
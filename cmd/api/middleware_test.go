@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+)
+
+type logLine struct {
+	Level      string                 `json:"level"`
+	Message    string                 `json:"message"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func readLogLines(t *testing.T, buf *bytes.Buffer) []logLine {
+	t.Helper()
+
+	var lines []logLine
+
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		var line logLine
+
+		err := json.Unmarshal(scanner.Bytes(), &line)
+		if err != nil {
+			t.Fatalf("decoding log line %q: %s", scanner.Text(), err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	generated := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"Generated", "", ""},
+		{"Propagated", "edge-7f3a.42", "edge-7f3a.42"},
+		{"Unsafe", "bad id", ""},
+		{"Too long", strings.Repeat("a", 129), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.header != "" {
+				headers["X-Request-ID"] = tt.header
+			}
+
+			res := ts.do(t, http.MethodGet, "/v1/bouts/1", "", headers)
+			assertStatus(t, res, http.StatusNotFound)
+
+			id := res.header.Get("X-Request-ID")
+
+			switch {
+			case tt.want != "" && id != tt.want:
+				t.Errorf("got X-Request-ID %q; want %q", id, tt.want)
+			case tt.want == "" && !generated.MatchString(id):
+				t.Errorf("got X-Request-ID %q; want a generated ID", id)
+			}
+
+			var body struct {
+				RequestID string `json:"request_id"`
+			}
+			res.decode(t, &body)
+
+			if body.RequestID != id {
+				t.Errorf("got request_id %q in the error envelope; want %q", body.RequestID, id)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	app := newTestApplication(t)
+
+	var buf bytes.Buffer
+	app.logger = jsonlog.New(&buf, jsonlog.LevelInfo)
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+	seedBouts(t, app, &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/bouts/1", nil)
+	req.RemoteAddr = "203.0.113.9:5123"
+	req.Header.Set("Authorization", "Bearer test-key")
+	req.Header.Set("X-Request-ID", "req-1")
+
+	rr := httptest.NewRecorder()
+	app.routes().ServeHTTP(rr, req)
+
+	panicking := app.requestID(app.logRequest(app.recoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "req-2")
+
+	panicking.ServeHTTP(httptest.NewRecorder(), req)
+
+	if strings.Contains(buf.String(), "test-key") {
+		t.Error("got the API key in the logs")
+	}
+
+	lines := readLogLines(t, &buf)
+	if len(lines) != 3 {
+		t.Fatalf("got %d log lines; want 3: %+v", len(lines), lines)
+	}
+
+	access := lines[0].Properties
+	for key, want := range map[string]interface{}{
+		"request_id":     "req-1",
+		"request_method": "GET",
+		"request_url":    "/v1/bouts/1",
+		"route":          "/v1/bouts/:id",
+		"status":         float64(200),
+		"bytes":          float64(rr.Body.Len()),
+		"client_ip":      "203.0.113.9",
+		"user_id":        apiKeyID("test-key"),
+	} {
+		if access[key] != want {
+			t.Errorf("got %s %v in the access log; want %v", key, access[key], want)
+		}
+	}
+
+	if access["duration"] == nil {
+		t.Error("got no duration in the access log")
+	}
+
+	if lines[1].Level != "ERROR" || lines[1].Message != "boom" || lines[1].Properties["request_id"] != "req-2" {
+		t.Errorf("got %+v; want the panic logged with request_id req-2", lines[1])
+	}
+
+	if lines[2].Properties["status"] != float64(500) || lines[2].Properties["request_id"] != "req-2" {
+		t.Errorf("got %+v; want a 500 access log for req-2", lines[2])
+	}
+
+	if _, ok := lines[2].Properties["user_id"]; ok {
+		t.Error("got a user_id for an anonymous request")
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "routes.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes := []string{}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 3 {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "HandlerFunc" {
+			return true
+		}
+
+		method, ok := call.Args[0].(*ast.SelectorExpr)
+		path, ok2 := call.Args[1].(*ast.BasicLit)
+		if !ok || !ok2 {
+			t.Errorf("route at %v is not registered with a constant method and path", call.Pos())
+			return true
+		}
+
+		p, err := strconv.Unquote(path.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(method.Sel.Name, "Method"))
+		routes = append(routes, name+" "+pathParam.ReplaceAllString(p, "{$1}"))
+
+		return true
+	})
+
+	return routes
+}
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	var doc openAPIDocument
+
+	err := json.Unmarshal(openAPISpec, &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("got openapi version %q; want 3.x", doc.OpenAPI)
+	}
+
+	documented := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented[method+" "+path] = true
+		}
+	}
+
+	routes := registeredRoutes(t)
+	if len(routes) == 0 {
+		t.Fatal("found no routes in routes.go")
+	}
+
+	for _, route := range routes {
+		if !documented[route] {
+			t.Errorf("route %q is missing from openapi.json", route)
+		}
+		delete(documented, route)
+	}
+
+	stale := []string{}
+	for route := range documented {
+		stale = append(stale, route)
+	}
+	sort.Strings(stale)
+
+	for _, route := range stale {
+		t.Errorf("openapi.json documents %q, which is not registered in routes.go", route)
+	}
+
+	for _, schema := range []string{"Rikishi", "Bout", "TournamentResult", "User", "Metadata", "Error", "ValidationError"} {
+		if _, ok := doc.Components.Schemas[schema]; !ok {
+			t.Errorf("schema %q is missing from openapi.json", schema)
+		}
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	res := ts.do(t, http.MethodGet, "/v1/openapi.json", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	if ct := res.header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got Content-Type %q; want application/json", ct)
+	}
+
+	var doc openAPIDocument
+	res.decode(t, &doc)
+
+	if len(doc.Paths) == 0 {
+		t.Error("served document has no paths")
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+var rateLimitGroups = []string{"search", "graphql", "stream", "write"}
+
+// rateLimitGroup names the quota group of a request, or "" when it only
+// counts against the caller's overall quota. The rate limiter runs before
+// routing, so groups go by path rather than route.
+func rateLimitGroup(r *http.Request) string {
+	switch {
+	case r.URL.Path == "/v1/search":
+		return "search"
+	case r.URL.Path == "/v1/graphql":
+		return "graphql"
+	case r.URL.Path == "/v1/bouts/stream":
+		return "stream"
+	case r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions:
+		return "write"
+	default:
+		return ""
+	}
+}
+
+// parseRateLimitQuotas reads space separated group=rps:burst quotas, for
+// example "search=1:2 write=0.5:4".
+func parseRateLimitQuotas(val string) (map[string]data.RateLimit, error) {
+	quotas := make(map[string]data.RateLimit)
+
+	for _, field := range strings.Fields(val) {
+		group, quota, _ := strings.Cut(field, "=")
+		if !slices.Contains(rateLimitGroups, group) {
+			return nil, fmt.Errorf("invalid quota %q: group must be one of %s", field, strings.Join(rateLimitGroups, ", "))
+		}
+
+		rps, burst, _ := strings.Cut(quota, ":")
+
+		limit, err := parseRateLimit(rps, burst)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota %q: %w", field, err)
+		}
+
+		quotas[group] = limit
+	}
+
+	return quotas, nil
+}
+
+func parseRateLimit(rps, burst string) (data.RateLimit, error) {
+	r, err := strconv.ParseFloat(rps, 64)
+	if err != nil || r <= 0 {
+		return data.RateLimit{}, fmt.Errorf("rate must be a positive number")
+	}
+
+	b, err := strconv.Atoi(burst)
+	if err != nil || b < 1 {
+		return data.RateLimit{}, fmt.Errorf("burst must be a positive integer")
+	}
+
+	return data.RateLimit{Rate: r, Burst: b}, nil
+}
+
+// parseTrustedProxies reads space separated addresses and CIDR prefixes.
+func parseTrustedProxies(val string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	for _, field := range strings.Fields(val) {
+		if addr, err := netip.ParseAddr(field); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", field)
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+type rateLimitBucket struct {
+	key   string
+	limit data.RateLimit
+}
+
+// rateLimit takes each request from the caller's bucket and, for grouped
+// routes, from the caller's bucket for that group. Callers with a valid API key
+// are counted by key with their own quotas, everyone else by client IP.
+func (app *application) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !app.config.limiter.enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity := "ip:" + app.clientIP(r)
+		limit := data.RateLimit{Rate: app.config.limiter.rps, Burst: app.config.limiter.burst}
+		quotas := app.config.limiter.routeQuotas
+
+		if key, ok := app.parseAPIKey(r.Header.Get("Authorization")); ok {
+			identity = "key:" + apiKeyID(key)
+			limit = data.RateLimit{Rate: app.config.limiter.keyRPS, Burst: app.config.limiter.keyBurst}
+			quotas = app.config.limiter.keyRouteQuotas
+		}
+
+		buckets := []rateLimitBucket{{identity, limit}}
+
+		// Group quotas are usually the tighter ones, so they are checked first
+		// to avoid spending the overall quota on a request they reject. A
+		// request the overall quota rejects gets its group token back.
+		group := rateLimitGroup(r)
+		if quota, ok := quotas[group]; ok {
+			buckets = append([]rateLimitBucket{{identity + ":" + group, quota}}, buckets...)
+		}
+
+		now := time.Now()
+
+		var result data.RateLimitResult
+
+		for i, bucket := range buckets {
+			bucketResult, err := app.models.RateLimits.Allow(r.Context(), bucket.key, bucket.limit, now)
+			if err != nil {
+				// An unavailable limiter store should not take the API down
+				// with it, so the request is let through.
+				app.logError(r, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if i == 0 || !bucketResult.Allowed || bucketResult.Remaining < result.Remaining {
+				result = bucketResult
+			}
+
+			if !bucketResult.Allowed {
+				for _, taken := range buckets[:i] {
+					err = app.models.RateLimits.Refund(r.Context(), taken.key, taken.limit)
+					if err != nil {
+						app.logError(r, err)
+					}
+				}
+				break
+			}
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+			app.metrics.rateLimited.Inc()
+			app.rateLimitExceededResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// runRateLimitSweeper deletes buckets which have refilled, as they hold
+// nothing a new bucket would not.
+func (app *application) runRateLimitSweeper(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := app.models.RateLimits.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				app.logger.PrintError(err, nil)
+			}
+		}
+	}
+}
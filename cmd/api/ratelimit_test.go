@@ -0,0 +1,160 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.limiter.enabled = true
+	app.config.limiter.rps = 0.001
+	app.config.limiter.burst = 2
+	app.config.limiter.keyRPS = 0.001
+	app.config.limiter.keyBurst = 3
+	app.config.limiter.routeQuotas = map[string]data.RateLimit{"search": {Rate: 0.001, Burst: 1}, "graphql": {Rate: 0.001, Burst: 2}}
+	app.config.trustedProxies = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+
+	ts := newTestServer(t, app.routes())
+
+	assertQuota := func(t *testing.T, res testResponse, limit, remaining int) {
+		t.Helper()
+
+		if got := res.header.Get("RateLimit-Limit"); got != strconv.Itoa(limit) {
+			t.Errorf("got RateLimit-Limit %q; want %d", got, limit)
+		}
+
+		if got := res.header.Get("RateLimit-Remaining"); got != strconv.Itoa(remaining) {
+			t.Errorf("got RateLimit-Remaining %q; want %d", got, remaining)
+		}
+
+		if got, err := strconv.Atoi(res.header.Get("RateLimit-Reset")); err != nil || got < 1 {
+			t.Errorf("got RateLimit-Reset %q; want a number of seconds", res.header.Get("RateLimit-Reset"))
+		}
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		for remaining := 1; remaining >= 0; remaining-- {
+			res := ts.do(t, http.MethodGet, "/v1/healthcheck", "", nil)
+			assertStatus(t, res, http.StatusOK)
+			assertQuota(t, res, 2, remaining)
+		}
+
+		res := ts.do(t, http.MethodGet, "/v1/healthcheck", "", nil)
+		assertStatus(t, res, http.StatusTooManyRequests)
+		assertQuota(t, res, 2, 0)
+
+		if got, err := strconv.Atoi(res.header.Get("Retry-After")); err != nil || got < 1 {
+			t.Errorf("got Retry-After %q; want a number of seconds", res.header.Get("Retry-After"))
+		}
+	})
+
+	t.Run("API key", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/healthcheck", "", map[string]string{"Authorization": "Bearer test-key"})
+		assertStatus(t, res, http.StatusOK)
+		assertQuota(t, res, 3, 2)
+
+		res = ts.do(t, http.MethodGet, "/v1/healthcheck", "", map[string]string{"Authorization": "Bearer wrong-key"})
+		assertStatus(t, res, http.StatusTooManyRequests)
+	})
+
+	t.Run("Route group", func(t *testing.T) {
+		headers := map[string]string{"X-Forwarded-For": "198.51.100.7"}
+
+		res := ts.do(t, http.MethodGet, "/v1/search?q=Takakeisho", "", headers)
+		assertStatus(t, res, http.StatusOK)
+		assertQuota(t, res, 1, 0)
+
+		res = ts.do(t, http.MethodGet, "/v1/search?q=Takakeisho", "", headers)
+		assertStatus(t, res, http.StatusTooManyRequests)
+		assertQuota(t, res, 1, 0)
+
+		res = ts.do(t, http.MethodGet, "/v1/healthcheck", "", headers)
+		assertStatus(t, res, http.StatusOK)
+		assertQuota(t, res, 2, 0)
+	})
+
+	t.Run("Overall limit with group quota left", func(t *testing.T) {
+		headers := map[string]string{"X-Forwarded-For": "198.51.100.8"}
+		query := `{"query": "{ rikishi(shikona: \"Hakuho\") { shikona } }"}`
+
+		res := ts.do(t, http.MethodGet, "/v1/healthcheck", "", headers)
+		assertStatus(t, res, http.StatusOK)
+
+		res = ts.do(t, http.MethodPost, "/v1/graphql", query, headers)
+		assertStatus(t, res, http.StatusOK)
+		assertQuota(t, res, 2, 0)
+
+		res = ts.do(t, http.MethodPost, "/v1/graphql", query, headers)
+		assertStatus(t, res, http.StatusTooManyRequests)
+
+		result, err := app.models.RateLimits.Allow(t.Context(), "ip:198.51.100.8:graphql", app.config.limiter.routeQuotas["graphql"], time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Allowed || result.Remaining != 0 {
+			t.Errorf("got %+v; want the group token of the rejected request refunded", result)
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.trustedProxies = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor []string
+		want          string
+	}{
+		{"Direct", "198.51.100.7:4321", nil, "198.51.100.7"},
+		{"Untrusted peer", "198.51.100.7:4321", []string{"203.0.113.9"}, "198.51.100.7"},
+		{"Trusted proxy", "10.0.0.2:4321", []string{"203.0.113.9"}, "203.0.113.9"},
+		{"Spoofed entries", "10.0.0.2:4321", []string{"192.0.2.1, 203.0.113.9, 10.0.0.3"}, "203.0.113.9"},
+		{"Repeated headers", "10.0.0.2:4321", []string{"192.0.2.1", "203.0.113.9"}, "203.0.113.9"},
+		{"Only proxies", "10.0.0.2:4321", []string{"10.0.0.4, 10.0.0.3"}, "10.0.0.4"},
+		{"Malformed entry", "10.0.0.2:4321", []string{"203.0.113.9, unknown"}, "10.0.0.2"},
+		{"IPv6 proxy", "[::1]:4321", []string{"2001:db8::1"}, "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1/healthcheck", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			for _, value := range tt.xForwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := app.clientIP(r); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRateLimitQuotas(t *testing.T) {
+	quotas, err := parseRateLimitQuotas("search=1:2 write=0.5:4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quotas["search"] != (data.RateLimit{Rate: 1, Burst: 2}) || quotas["write"] != (data.RateLimit{Rate: 0.5, Burst: 4}) || len(quotas) != 2 {
+		t.Errorf("got %v", quotas)
+	}
+
+	for _, val := range []string{"search", "exports=1:2", "search=0:2", "search=1:0", "search=1"} {
+		_, err := parseRateLimitQuotas(val)
+		if err == nil {
+			t.Errorf("got no error for %q", val)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+var (
+	rikishiFields  = []string{"shikona", "highest_rank", "heya", "shikona_history", "shikona_kanji", "shikona_kana", "heya_kanji", "heya_kana", "version"}
+	rikishiInclude = []string{"tournaments_results"}
+
+	tournamentResultFields  = []string{"id", "tournament", "rikishi", "rank", "wins", "losses", "absent", "version"}
+	tournamentResultInclude = []string{"rikishi"}
+
+	boutFields  = []string{"id", "tournament", "day", "division", "winner", "loser", "kimarite", "kimarite_kanji", "kimarite_kana", "version"}
+	boutInclude = []string{"winner", "loser", "tournament"}
+)
+
+type resource map[string]interface{}
+
+func newResource(v interface{}) (resource, error) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+
+	var res resource
+
+	err = dec.Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (res resource) project(fields, include []string) resource {
+	if len(fields) == 0 {
+		return res
+	}
+
+	projected := make(resource, len(fields)+len(include))
+
+	for _, list := range [][]string{fields, include} {
+		for _, field := range list {
+			if value, ok := res[field]; ok {
+				projected[field] = value
+			}
+		}
+	}
+
+	return projected
+}
+
+func (app *application) rikishiResources(ctx context.Context, rikishis []*data.Rikishi, fields, include []string) ([]resource, error) {
+	results := make(map[string][]*data.TournamentResult)
+
+	if validator.In("tournaments_results", include...) {
+		owners := make(map[string]string)
+		shikonas := []string{}
+
+		for _, rikishi := range rikishis {
+			for _, shikona := range rikishi.ShikonaHistory {
+				owners[shikona] = rikishi.Shikona
+				shikonas = append(shikonas, shikona)
+			}
+		}
+
+		trs, err := app.models.TournamentsResults.GetForRikishis(ctx, nil, shikonas)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tr := range trs {
+			owner := owners[tr.Rikishi]
+			results[owner] = append(results[owner], tr)
+		}
+	}
+
+	resources := make([]resource, 0, len(rikishis))
+
+	for _, rikishi := range rikishis {
+		res, err := newResource(rikishi)
+		if err != nil {
+			return nil, err
+		}
+
+		if validator.In("tournaments_results", include...) {
+			trs := results[rikishi.Shikona]
+			if trs == nil {
+				trs = []*data.TournamentResult{}
+			}
+			res["tournaments_results"] = trs
+		}
+
+		resources = append(resources, res.project(fields, include))
+	}
+
+	return resources, nil
+}
+
+func (app *application) tournamentResultResources(ctx context.Context, trs []*data.TournamentResult, fields, include []string) ([]resource, error) {
+	rikishis := make(map[string]*data.Rikishi)
+
+	if validator.In("rikishi", include...) {
+		shikonas := make([]string, 0, len(trs))
+		for _, tr := range trs {
+			shikonas = append(shikonas, tr.Rikishi)
+		}
+
+		var err error
+
+		rikishis, err = app.models.Rikishis.GetByShikonas(ctx, shikonas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resources := make([]resource, 0, len(trs))
+
+	for _, tr := range trs {
+		res, err := newResource(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		if rikishi, ok := rikishis[tr.Rikishi]; ok {
+			res["rikishi"] = rikishi
+		}
+
+		resources = append(resources, res.project(fields, include))
+	}
+
+	return resources, nil
+}
+
+func (app *application) boutResources(ctx context.Context, bouts []*data.Bout, fields, include []string) ([]resource, error) {
+	includeRikishis := validator.In("winner", include...) || validator.In("loser", include...)
+	includeTournament := validator.In("tournament", include...)
+
+	shikonas := make([]string, 0, 2*len(bouts))
+	tournaments := make([]string, 0, len(bouts))
+
+	for _, bout := range bouts {
+		shikonas = append(shikonas, bout.Winner, bout.Loser)
+		tournaments = append(tournaments, bout.Tournament)
+	}
+
+	rikishis := make(map[string]*data.Rikishi)
+
+	if includeRikishis {
+		var err error
+
+		rikishis, err = app.models.Rikishis.GetByShikonas(ctx, shikonas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(map[[2]string]*data.TournamentResult)
+
+	if includeTournament {
+		trs, err := app.models.TournamentsResults.GetForRikishis(ctx, tournaments, shikonas)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tr := range trs {
+			results[[2]string{tr.Tournament, tr.Rikishi}] = tr
+		}
+	}
+
+	resources := make([]resource, 0, len(bouts))
+
+	for _, bout := range bouts {
+		res, err := newResource(bout)
+		if err != nil {
+			return nil, err
+		}
+
+		if winner, ok := rikishis[bout.Winner]; ok && validator.In("winner", include...) {
+			res["winner"] = winner
+		}
+
+		if loser, ok := rikishis[bout.Loser]; ok && validator.In("loser", include...) {
+			res["loser"] = loser
+		}
+
+		if includeTournament {
+			trs := []*data.TournamentResult{}
+
+			for _, shikona := range []string{bout.Winner, bout.Loser} {
+				if tr, ok := results[[2]string{bout.Tournament, shikona}]; ok {
+					trs = append(trs, tr)
+				}
+			}
+
+			res["tournament"] = envelope{"name": bout.Tournament, "results": trs}
+		}
+
+		resources = append(resources, res.project(fields, include))
+	}
+
+	return resources, nil
+}
@@ -16,6 +16,10 @@ func (app *application) createRikishiHandler(w http.ResponseWriter, r *http.Requ
 		HighestRank    string   `json:"highest_rank"`
 		Heya           string   `json:"heya"`
 		ShikonaHistory []string `json:"shikona_history"`
+		ShikonaKanji   string   `json:"shikona_kanji"`
+		ShikonaKana    string   `json:"shikona_kana"`
+		HeyaKanji      string   `json:"heya_kanji"`
+		HeyaKana       string   `json:"heya_kana"`
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -29,6 +33,10 @@ func (app *application) createRikishiHandler(w http.ResponseWriter, r *http.Requ
 		HighestRank:    input.HighestRank,
 		Heya:           input.Heya,
 		ShikonaHistory: input.ShikonaHistory,
+		ShikonaKanji:   input.ShikonaKanji,
+		ShikonaKana:    input.ShikonaKana,
+		HeyaKanji:      input.HeyaKanji,
+		HeyaKana:       input.HeyaKana,
 	}
 
 	v := validator.New()
@@ -38,7 +46,7 @@ func (app *application) createRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = app.models.Rikishis.Insert(rikishi)
+	err = app.models.Rikishis.Insert(r.Context(), rikishi)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
@@ -46,8 +54,9 @@ func (app *application) createRikishiHandler(w http.ResponseWriter, r *http.Requ
 
 	headers := make(http.Header)
 	headers.Set("Location", fmt.Sprintf("/v1/rikishis/%s", strings.ReplaceAll(rikishi.Shikona, " ", "-")))
+	headers.Set("ETag", app.etag(rikishi.Version))
 
-	err = app.writeJSON(w, http.StatusCreated, envelope{"rikishi": rikishi}, headers)
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"rikishi": rikishi}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -60,7 +69,18 @@ func (app *application) showRikishiHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	rikishi, err := app.models.Rikishis.Get(shikona)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	fields := app.readList(qs, "fields", rikishiFields, v)
+	include := app.readList(qs, "include", rikishiInclude, v)
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	rikishi, err := app.models.Rikishis.Get(r.Context(), shikona)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -71,7 +91,23 @@ func (app *application) showRikishiHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"rikishi": rikishi}, nil)
+	headers := make(http.Header)
+
+	if len(include) == 0 {
+		if app.checkIfNoneMatch(w, r, rikishi.Version) {
+			return
+		}
+
+		headers.Set("ETag", app.etag(rikishi.Version))
+	}
+
+	resources, err := app.rikishiResources(r.Context(), []*data.Rikishi{rikishi}, fields, include)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"rikishi": resources[0]}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -84,7 +120,7 @@ func (app *application) updateRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	rikishi, err := app.models.Rikishis.Get(shikona)
+	rikishi, err := app.models.Rikishis.Get(r.Context(), shikona)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -95,12 +131,20 @@ func (app *application) updateRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if !app.checkIfMatch(w, r, rikishi.Version) {
+		return
+	}
+
 	var input struct {
 		Shikona        *string  `json:"shikona"`
 		NewShikona     *string  `json:"new_shikona"`
 		HighestRank    *string  `json:"highest_rank"`
 		Heya           *string  `json:"heya"`
 		ShikonaHistory []string `json:"shikona_history"`
+		ShikonaKanji   *string  `json:"shikona_kanji"`
+		ShikonaKana    *string  `json:"shikona_kana"`
+		HeyaKanji      *string  `json:"heya_kanji"`
+		HeyaKana       *string  `json:"heya_kana"`
 	}
 
 	err = app.readJSON(w, r, &input)
@@ -129,6 +173,22 @@ func (app *application) updateRikishiHandler(w http.ResponseWriter, r *http.Requ
 		rikishi.ShikonaHistory = input.ShikonaHistory
 	}
 
+	if input.ShikonaKanji != nil {
+		rikishi.ShikonaKanji = *input.ShikonaKanji
+	}
+
+	if input.ShikonaKana != nil {
+		rikishi.ShikonaKana = *input.ShikonaKana
+	}
+
+	if input.HeyaKanji != nil {
+		rikishi.HeyaKanji = *input.HeyaKanji
+	}
+
+	if input.HeyaKana != nil {
+		rikishi.HeyaKana = *input.HeyaKana
+	}
+
 	v := validator.New()
 
 	if data.ValidateRikishi(v, rikishi); !v.Valid() {
@@ -136,7 +196,7 @@ func (app *application) updateRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = app.models.Rikishis.Update(rikishi)
+	err = app.models.Rikishis.Update(r.Context(), rikishi)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrEditConflict):
@@ -147,7 +207,10 @@ func (app *application) updateRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"rikishi": rikishi}, nil)
+	headers := make(http.Header)
+	headers.Set("ETag", app.etag(rikishi.Version))
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"rikishi": rikishi}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -160,18 +223,35 @@ func (app *application) deleteRikishiHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = app.models.Rikishis.Delete(shikona)
+	rikishi, err := app.models.Rikishis.Get(r.Context(), shikona)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	if !app.checkIfMatch(w, r, rikishi.Version) {
+		return
+	}
+
+	err = app.models.Rikishis.Delete(r.Context(), shikona, rikishi.Version)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
 			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrEditConflict):
+			app.preconditionFailedResponse(w, r)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"message": "rikishi successfully deleted"}, nil)
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"message": "rikishi successfully deleted"}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -192,24 +272,57 @@ func (app *application) listRikishisHandler(w http.ResponseWriter, r *http.Reque
 	input.HighestRank = app.readString(qs, "highest_rank", "")
 	input.Heya = app.readString(qs, "heya", "")
 
+	fields := app.readList(qs, "fields", rikishiFields, v)
+	include := app.readList(qs, "include", rikishiInclude, v)
+
+	format := app.readFormat(r, v)
+	unlimited := app.readExportOptions(r, format, include, v)
+
 	input.Filters.Page = app.readInt(qs, "page", 1, v)
 	input.Filters.PageSize = app.readInt(qs, "page_size", 20, v)
 	input.Filters.Sort = app.readString(qs, "sort", "shikona")
+	input.Filters.Cursor = app.readString(qs, "cursor", "")
+	input.Filters.IncludeTotal = app.readBool(qs, "total", input.Filters.Cursor == "", v)
+	input.Filters.Unlimited = unlimited
 
 	input.Filters.SortSafelist = []string{"shikona", "highest_rank", "heya", "-shikona", "-highest_rank", "-heya"}
 
-	if !v.Valid() {
+	if data.ValidateFilters(v, input.Filters); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	rikishis, metadata, err := app.models.Rikishis.GetAll(input.Shikona, input.HighestRank, input.Heya, input.Filters)
+	if input.Filters.Unlimited && app.contextGetAPIKey(r) == "" {
+		app.apiKeyRequiredResponse(w, r)
+		return
+	}
+
+	if format != formatJSON {
+		input.Filters.IncludeTotal = false
+
+		exp := app.newExporter(w, format, "rikishis", fields, rikishiFields)
+
+		err := app.models.Rikishis.Stream(r.Context(), input.Shikona, input.HighestRank, input.Heya, input.Filters, func(rikishi *data.Rikishi) error {
+			return exp.write(rikishi)
+		})
+
+		app.finishExport(w, r, exp, err)
+		return
+	}
+
+	rikishis, metadata, err := app.models.Rikishis.GetAll(r.Context(), input.Shikona, input.HighestRank, input.Heya, input.Filters)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	resources, err := app.rikishiResources(r.Context(), rikishis, fields, include)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"rikishis": rikishis, "metadata": metadata}, nil)
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"rikishis": resources, "metadata": metadata}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestCreateRikishiHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"Valid", `{"shikona": "Terunofuji", "highest_rank": "Yokozuna", "heya": "Isegahama", "shikona_history": ["Wakamisho", "Terunofuji"]}`, http.StatusCreated},
+		{"Missing heya", `{"shikona": "Kirishima", "highest_rank": "Ozeki", "shikona_history": ["Kirishima"]}`, http.StatusUnprocessableEntity},
+		{"Duplicate history", `{"shikona": "Kiribayama", "highest_rank": "Ozeki", "heya": "Michinoku", "shikona_history": ["Kiribayama", "Kiribayama"]}`, http.StatusUnprocessableEntity},
+		{"Unknown field", `{"shikona": "Hoshoryu", "rank": "Sekiwake"}`, http.StatusBadRequest},
+		{"Malformed JSON", `{"shikona": `, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodPost, "/v1/rikishis", tt.body, nil)
+			assertStatus(t, res, tt.wantCode)
+		})
+	}
+
+	rikishi, err := app.models.Rikishis.Get(t.Context(), "Terunofuji")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rikishi.Version != 1 || rikishi.Heya != "Isegahama" {
+		t.Errorf("got %+v", rikishi)
+	}
+}
+
+func TestLocalizedValidationErrors(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	body := `{"shikona": "Kirishima", "highest_rank": "Ozeki", "shikona_history": ["Kirishima"], "shikona_kana": "キリシマ"}`
+
+	tests := []struct {
+		name         string
+		language     string
+		wantLanguage string
+		wantErrors   map[string]string
+	}{
+		{"Default", "", "en", map[string]string{"heya": "must be provided", "shikona_kana": "must only contain hiragana"}},
+		{"Japanese", "ja-JP,ja;q=0.9,en;q=0.8", "ja", map[string]string{"heya": "部屋: 入力してください", "shikona_kana": "四股名（ひらがな）: ひらがなで入力してください"}},
+		{"Preferred English", "fr, en;q=0.9, ja;q=0.5", "en", map[string]string{"heya": "must be provided", "shikona_kana": "must only contain hiragana"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodPost, "/v1/rikishis", body, map[string]string{"Accept-Language": tt.language})
+			assertStatus(t, res, http.StatusUnprocessableEntity)
+
+			if got := res.header.Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("got Content-Language %q; want %q", got, tt.wantLanguage)
+			}
+
+			var got struct {
+				Error map[string]string `json:"error"`
+			}
+			res.decode(t, &got)
+
+			if !reflect.DeepEqual(got.Error, tt.wantErrors) {
+				t.Errorf("got %v; want %v", got.Error, tt.wantErrors)
+			}
+		})
+	}
+
+	res := ts.do(t, http.MethodGet, "/v1/rikishis/Nobody", "", map[string]string{"Accept-Language": "ja"})
+	assertStatus(t, res, http.StatusNotFound)
+
+	var notFound struct {
+		Error string `json:"error"`
+	}
+	res.decode(t, &notFound)
+
+	if notFound.Error != "指定されたリソースが見つかりません" {
+		t.Errorf("got %q; want a Japanese message", notFound.Error)
+	}
+}
+
+func TestShowRikishiHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app, &data.Rikishi{Shikona: "Wakatakakage", HighestRank: "Sekiwake", Heya: "Arashio"})
+
+	res := ts.do(t, http.MethodGet, "/v1/rikishis/Wakatakakage", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	if etag := res.header.Get("ETag"); etag != `"1"` {
+		t.Errorf("got ETag %q; want %q", etag, `"1"`)
+	}
+
+	var body struct {
+		Rikishi data.Rikishi `json:"rikishi"`
+	}
+	res.decode(t, &body)
+
+	if body.Rikishi.Heya != "Arashio" {
+		t.Errorf("got heya %q; want %q", body.Rikishi.Heya, "Arashio")
+	}
+
+	res = ts.do(t, http.MethodGet, "/v1/rikishis/Wakatakakage", "", map[string]string{"If-None-Match": `"1"`})
+	assertStatus(t, res, http.StatusNotModified)
+
+	res = ts.do(t, http.MethodGet, "/v1/rikishis/Wakatakakage?fields=heya", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	var projected struct {
+		Rikishi map[string]interface{} `json:"rikishi"`
+	}
+	res.decode(t, &projected)
+
+	if len(projected.Rikishi) != 1 || projected.Rikishi["heya"] != "Arashio" {
+		t.Errorf("got %v; want only heya", projected.Rikishi)
+	}
+
+	res = ts.do(t, http.MethodGet, "/v1/rikishis/Wakatakakage?fields=weight", "", nil)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+
+	res = ts.do(t, http.MethodGet, "/v1/rikishis/Takakeisho", "", nil)
+	assertStatus(t, res, http.StatusNotFound)
+}
+
+func TestUpdateRikishiHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app, &data.Rikishi{Shikona: "Kiribayama", HighestRank: "Sekiwake", Heya: "Michinoku"})
+
+	res := ts.do(t, http.MethodPatch, "/v1/rikishis/Kiribayama", `{"new_shikona": "Kirishima", "highest_rank": "Ozeki"}`, map[string]string{"If-Match": `"1"`})
+	assertStatus(t, res, http.StatusOK)
+
+	rikishi, err := app.models.Rikishis.Get(t.Context(), "Kirishima")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"Kiribayama", "Kirishima"}; !reflect.DeepEqual(rikishi.ShikonaHistory, want) {
+		t.Errorf("got shikona history %v; want %v", rikishi.ShikonaHistory, want)
+	}
+
+	if rikishi.Version != 2 {
+		t.Errorf("got version %d; want 2", rikishi.Version)
+	}
+
+	res = ts.do(t, http.MethodPatch, "/v1/rikishis/Kirishima", `{"heya": "Otowayama"}`, map[string]string{"If-Match": `"1"`})
+	assertStatus(t, res, http.StatusPreconditionFailed)
+
+	res = ts.do(t, http.MethodPatch, "/v1/rikishis/Kiribayama", `{"heya": "Otowayama"}`, nil)
+	assertStatus(t, res, http.StatusNotFound)
+
+	app.config.requireIfMatch = true
+
+	res = ts.do(t, http.MethodPatch, "/v1/rikishis/Kirishima", `{"heya": "Otowayama"}`, nil)
+	assertStatus(t, res, http.StatusPreconditionRequired)
+}
+
+func TestDeleteRikishiHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app, &data.Rikishi{Shikona: "Tochinoshin", HighestRank: "Ozeki", Heya: "Kasugano"})
+
+	res := ts.do(t, http.MethodDelete, "/v1/rikishis/Tochinoshin", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodDelete, "/v1/rikishis/Tochinoshin", "", nil)
+	assertStatus(t, res, http.StatusNotFound)
+}
+
+func TestListRikishisHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Hakuho", HighestRank: "Yokozuna", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Enho", HighestRank: "Maegashira", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Hokuseiho", HighestRank: "Maegashira", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Asanoyama", HighestRank: "Ozeki", Heya: "Takasago"},
+		&data.Rikishi{Shikona: "Kisenosato", HighestRank: "Yokozuna", Heya: "Tagonoura", ShikonaHistory: []string{"Hagiwara", "Kisenosato"}},
+	)
+
+	type listBody struct {
+		Rikishis []data.Rikishi `json:"rikishis"`
+		Metadata data.Metadata  `json:"metadata"`
+	}
+
+	shikonas := func(body listBody) []string {
+		names := []string{}
+		for _, rikishi := range body.Rikishis {
+			names = append(names, rikishi.Shikona)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"Default sort", "", []string{"Asanoyama", "Enho", "Hakuho", "Hokuseiho", "Kisenosato"}},
+		{"Heya", "?heya=miyagino", []string{"Enho", "Hakuho", "Hokuseiho"}},
+		{"Highest rank descending", "?highest_rank=Yokozuna&sort=-shikona", []string{"Kisenosato", "Hakuho"}},
+		{"Historical shikona", "?shikona=hagiwara", []string{"Kisenosato"}},
+		{"Sort by heya", "?sort=heya&page_size=2", []string{"Enho", "Hakuho"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodGet, "/v1/rikishis"+tt.query, "", nil)
+			assertStatus(t, res, http.StatusOK)
+
+			var body listBody
+			res.decode(t, &body)
+
+			if got := shikonas(body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Cursor pagination", func(t *testing.T) {
+		var seen []string
+		var prev string
+
+		path := "/v1/rikishis?page_size=2"
+
+		for path != "" {
+			res := ts.do(t, http.MethodGet, path, "", nil)
+			assertStatus(t, res, http.StatusOK)
+
+			var body listBody
+			res.decode(t, &body)
+
+			seen = append(seen, shikonas(body)...)
+			prev = body.Metadata.PrevCursor
+
+			path = ""
+			if body.Metadata.NextCursor != "" {
+				path = "/v1/rikishis?page_size=2&cursor=" + body.Metadata.NextCursor
+			}
+		}
+
+		if want := []string{"Asanoyama", "Enho", "Hakuho", "Hokuseiho", "Kisenosato"}; !reflect.DeepEqual(seen, want) {
+			t.Fatalf("got %v; want %v", seen, want)
+		}
+
+		res := ts.do(t, http.MethodGet, "/v1/rikishis?page_size=2&cursor="+prev, "", nil)
+		assertStatus(t, res, http.StatusOK)
+
+		var body listBody
+		res.decode(t, &body)
+
+		if want := []string{"Hakuho", "Hokuseiho"}; !reflect.DeepEqual(shikonas(body), want) {
+			t.Errorf("got %v; want %v", shikonas(body), want)
+		}
+	})
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/rikishis?sort=weight", "", nil)
+		assertStatus(t, res, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Unlimited export requires an API key", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/rikishis?format=csv&all=true", "", nil)
+		assertStatus(t, res, http.StatusUnauthorized)
+
+		res = ts.do(t, http.MethodGet, "/v1/rikishis?format=csv&all=true&fields=shikona", "", map[string]string{"Authorization": "Bearer test-key"})
+		assertStatus(t, res, http.StatusOK)
+
+		want := "shikona\nAsanoyama\nEnho\nHakuho\nHokuseiho\nKisenosato\n"
+		if string(res.body) != want {
+			t.Errorf("got %q; want %q", res.body, want)
+		}
+	})
+}
@@ -2,17 +2,16 @@ package main
 
 import (
 	"net/http"
-
-	"github.com/julienschmidt/httprouter"
 )
 
 func (app *application) routes() http.Handler {
-	router := httprouter.New()
+	router := app.newRouter()
 
 	router.NotFound = http.HandlerFunc(app.notFoundResponse)
 	router.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
 
 	router.HandlerFunc(http.MethodGet, "/v1/healthcheck", app.healshcheckHandler)
+	router.HandlerFunc(http.MethodGet, "/v1/openapi.json", app.openAPIHandler)
 
 	router.HandlerFunc(http.MethodGet, "/v1/rikishis", app.listRikishisHandler)
 	router.HandlerFunc(http.MethodPost, "/v1/rikishis", app.createRikishiHandler)
@@ -22,17 +21,40 @@ func (app *application) routes() http.Handler {
 
 	router.HandlerFunc(http.MethodGet, "/v1/tournamentsresults", app.listTournamentsResultsHandler)
 	router.HandlerFunc(http.MethodPost, "/v1/tournamentsresults", app.createTournamentResultHandler)
+	router.HandlerFunc(http.MethodPost, "/v1/tournamentsresults/batch", app.createTournamentsResultsBatchHandler)
 	router.HandlerFunc(http.MethodGet, "/v1/tournamentsresults/:id", app.showTournamentResultHandler)
 	router.HandlerFunc(http.MethodPatch, "/v1/tournamentsresults/:id", app.updateTournamentResultHandler)
 	router.HandlerFunc(http.MethodDelete, "/v1/tournamentsresults/:id", app.deleteTournamentResultHandler)
 
 	router.HandlerFunc(http.MethodGet, "/v1/bouts", app.listBoutsHandler)
 	router.HandlerFunc(http.MethodPost, "/v1/bouts", app.createBoutHandler)
+	router.HandlerFunc(http.MethodPost, "/v1/bouts/batch", app.createBoutsBatchHandler)
 	router.HandlerFunc(http.MethodGet, "/v1/bouts/:id", app.showBoutHandler)
 	router.HandlerFunc(http.MethodPatch, "/v1/bouts/:id", app.updateBoutHandler)
 	router.HandlerFunc(http.MethodDelete, "/v1/bouts/:id", app.deleteBoutHandler)
 
+	router.HandlerFunc(http.MethodGet, "/v1/search", app.searchHandler)
+
+	router.HandlerFunc(http.MethodGet, "/v1/graphql", app.graphqlHandler)
+	router.HandlerFunc(http.MethodPost, "/v1/graphql", app.graphqlHandler)
+
 	router.HandlerFunc(http.MethodPost, "/v1/users", app.registerUserHandler)
 
-	return app.recoverPanic(app.rateLimit(router))
+	router.HandlerFunc(http.MethodGet, "/v1/webhooks", app.requireAPIKey(app.listWebhooksHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/webhooks", app.requireAPIKey(app.createWebhookHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/webhooks/:id", app.requireAPIKey(app.showWebhookHandler))
+	router.HandlerFunc(http.MethodPatch, "/v1/webhooks/:id", app.requireAPIKey(app.updateWebhookHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/webhooks/:id", app.requireAPIKey(app.deleteWebhookHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/webhooks/:id/deliveries", app.requireAPIKey(app.listWebhookDeliveriesHandler))
+
+	// httprouter cannot register a static segment next to /v1/bouts/:id, so
+	// streams get their own router which falls through to the main one.
+	streams := app.newRouter()
+
+	streams.NotFound = router
+	streams.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
+
+	streams.HandlerFunc(http.MethodGet, "/v1/bouts/stream", app.streamBoutsHandler)
+
+	return app.requestID(app.traceRequest(app.logRequest(app.recordMetrics(app.recoverPanic(app.rateLimit(app.authenticate(streams)))))))
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+func (app *application) searchHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Query string
+		Types []string
+		Limit int
+	}
+
+	v := validator.New()
+	qs := r.URL.Query()
+
+	input.Query = app.readString(qs, "q", "")
+	input.Types = app.readList(qs, "types", data.SearchKinds, v)
+	input.Limit = app.readInt(qs, "limit", 20, v)
+
+	if data.ValidateSearch(v, input.Query, input.Limit); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	if len(input.Types) == 0 {
+		input.Types = data.SearchKinds
+	}
+
+	hits, err := app.models.Search.Search(r.Context(), input.Query, input.Types, input.Limit)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"results": hits}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
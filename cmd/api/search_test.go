@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestSearchHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Hakuho", HighestRank: "Yokozuna", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Hakuoho", HighestRank: "Maegashira", Heya: "Miyagino"},
+	)
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{"Valid", "/v1/search?q=Hakuh%C5%8D", http.StatusOK},
+		{"Missing query", "/v1/search", http.StatusUnprocessableEntity},
+		{"Unknown type", "/v1/search?q=hakuho&types=stable", http.StatusUnprocessableEntity},
+		{"Limit too large", "/v1/search?q=hakuho&limit=1000", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodGet, tt.path, "", nil)
+			assertStatus(t, res, tt.wantCode)
+		})
+	}
+
+	res := ts.do(t, http.MethodGet, "/v1/search?q=Hakuh%C5%8D&types=rikishi", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	var body struct {
+		Results []data.SearchHit `json:"results"`
+	}
+	res.decode(t, &body)
+
+	if len(body.Results) != 2 {
+		t.Fatalf("got %d results; want 2", len(body.Results))
+	}
+
+	if got := body.Results[0]; got.Value != "Hakuho" || got.Highlight != "<em>Hakuho</em>" {
+		t.Errorf("got %+v; want exact match first", got)
+	}
+}
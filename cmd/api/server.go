@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
 )
 
 func (app *application) serve() error {
@@ -20,7 +24,42 @@ func (app *application) serve() error {
 		WriteTimeout: 30 * time.Second,
 	}
 
+	adminSrv := &http.Server{
+		Addr:         net.JoinHostPort(app.config.adminAddr, strconv.Itoa(app.config.adminPort)),
+		Handler:      app.adminRoutes(),
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	grpcSrv := app.newGRPCServer()
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", app.config.grpc.port))
+	if err != nil {
+		return err
+	}
+
+	adminListener, err := net.Listen("tcp", adminSrv.Addr)
+	if err != nil {
+		grpcListener.Close()
+		return err
+	}
+
 	shutdownError := make(chan error)
+	grpcError := make(chan error, 1)
+	adminError := make(chan error, 1)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	dispatcherDone := make(chan struct{})
+
+	go func() {
+		app.runWebhookDispatcher(backgroundCtx)
+		close(dispatcherDone)
+	}()
+
+	if app.config.limiter.enabled {
+		go app.runRateLimitSweeper(backgroundCtx)
+	}
 
 	go func() {
 		quit := make(chan os.Signal, 1)
@@ -29,23 +68,73 @@ func (app *application) serve() error {
 
 		s := <-quit
 
-		app.logger.PrintInfo("shutting down server", map[string]string{
+		app.logger.PrintInfo("shutting down server", jsonlog.Properties{
 			"signal": s.String(),
 		})
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		shutdownError <- srv.Shutdown(ctx)
+		// Closing the hub first ends open event streams, which would
+		// otherwise hold both servers open until the deadline.
+		app.events.Close()
+
+		err := errors.Join(srv.Shutdown(ctx), adminSrv.Shutdown(ctx))
+
+		stopped := make(chan struct{})
+
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcSrv.Stop()
+		}
+
+		stopBackground()
+
+		select {
+		case <-dispatcherDone:
+		case <-ctx.Done():
+		}
+
+		shutdownError <- err
+	}()
+
+	go func() {
+		app.logger.PrintInfo("starting gRPC server", jsonlog.Properties{
+			"addr": grpcListener.Addr().String(),
+		})
+
+		grpcError <- grpcSrv.Serve(grpcListener)
 	}()
 
-	app.logger.PrintInfo("Starting server", map[string]string{
+	go func() {
+		app.logger.PrintInfo("starting admin server", jsonlog.Properties{
+			"addr": adminListener.Addr().String(),
+		})
+
+		err := adminSrv.Serve(adminListener)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+
+		adminError <- err
+	}()
+
+	app.logger.PrintInfo("Starting server", jsonlog.Properties{
 		"addr": srv.Addr,
 		"env":  app.config.env,
 	})
 
-	err := srv.ListenAndServe()
+	err = srv.ListenAndServe()
 	if !errors.Is(err, http.ErrServerClosed) {
+		grpcSrv.Stop()
+		adminSrv.Close()
+		stopBackground()
 		return err
 	}
 
@@ -54,7 +143,17 @@ func (app *application) serve() error {
 		return err
 	}
 
-	app.logger.PrintInfo("stopped server", map[string]string{
+	err = <-grpcError
+	if err != nil {
+		return err
+	}
+
+	err = <-adminError
+	if err != nil {
+		return err
+	}
+
+	app.logger.PrintInfo("stopped server", jsonlog.Properties{
 		"addr": srv.Addr,
 	})
 
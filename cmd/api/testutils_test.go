@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/events"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type testServer struct {
+	*httptest.Server
+}
+
+type testResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func newTestApplication(t *testing.T) *application {
+	var cfg config
+
+	cfg.env = "testing"
+	cfg.apiKeys = []string{"test-key"}
+	cfg.batch.maxSize = 3
+	cfg.graphql.maxDepth = 6
+	cfg.graphql.maxComplexity = 1000
+	cfg.stream.heartbeat = time.Minute
+	cfg.stream.logSize = 10
+	cfg.webhooks.maxAttempts = 3
+	cfg.webhooks.backoff = time.Minute
+	cfg.webhooks.timeout = 5 * time.Second
+
+	metrics := newAppMetrics()
+
+	tracerProvider := noop.NewTracerProvider()
+
+	app := &application{
+		config:         cfg,
+		logger:         jsonlog.New(io.Discard, jsonlog.LevelOff),
+		models:         data.InstrumentModels(data.NewMemoryModels(), tracerProvider, metrics.observeQuery),
+		events:         events.NewHub(cfg.stream.logSize),
+		metrics:        metrics,
+		tracerProvider: tracerProvider,
+	}
+	app.graphql = app.graphqlSchema()
+
+	return app
+}
+
+func newTestServer(t *testing.T, h http.Handler) *testServer {
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	return &testServer{ts}
+}
+
+func (ts *testServer) do(t *testing.T, method, path, body string, headers map[string]string) testResponse {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testResponse{status: res.StatusCode, header: res.Header, body: resBody}
+}
+
+func (res testResponse) decode(t *testing.T, dst interface{}) {
+	t.Helper()
+
+	err := json.Unmarshal(res.body, dst)
+	if err != nil {
+		t.Fatalf("decoding %q: %s", res.body, err)
+	}
+}
+
+func seedRikishis(t *testing.T, app *application, rikishis ...*data.Rikishi) {
+	t.Helper()
+
+	for _, rikishi := range rikishis {
+		if rikishi.ShikonaHistory == nil {
+			rikishi.ShikonaHistory = []string{rikishi.Shikona}
+		}
+
+		err := app.models.Rikishis.Insert(t.Context(), rikishi)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func seedBouts(t *testing.T, app *application, bouts ...*data.Bout) {
+	t.Helper()
+
+	err := app.models.Bouts.InsertBatch(t.Context(), bouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func seedTournamentsResults(t *testing.T, app *application, trs ...*data.TournamentResult) {
+	t.Helper()
+
+	err := app.models.TournamentsResults.InsertBatch(t.Context(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertStatus(t *testing.T, res testResponse, want int) {
+	t.Helper()
+
+	if res.status != want {
+		t.Fatalf("got status %d; want %d; body: %s", res.status, want, res.body)
+	}
+}
@@ -35,12 +35,18 @@ func (app *application) createTournamentResultHandler(w http.ResponseWriter, r *
 	}
 
 	v := validator.New()
-	if data.ValidateTournamentResult(v, tr, app.models.Rikishis); !v.Valid() {
+	err = data.ValidateTournamentResult(r.Context(), v, tr, app.models.Rikishis)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	err = app.models.TournamentsResults.Insert(tr)
+	err = app.models.TournamentsResults.Insert(r.Context(), tr)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
@@ -48,8 +54,87 @@ func (app *application) createTournamentResultHandler(w http.ResponseWriter, r *
 
 	headers := make(http.Header)
 	headers.Set("Location", fmt.Sprintf("/v1/tournamentsresults/%d", tr.ID))
+	headers.Set("ETag", app.etag(tr.Version))
+
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"tournament_result": tr}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) createTournamentsResultsBatchHandler(w http.ResponseWriter, r *http.Request) {
+	var input []struct {
+		Tournament string `json:"tournament"`
+		Rikishi    string `json:"rikishi"`
+		Rank       string `json:"rank"`
+		Wins       int32  `json:"wins"`
+		Losses     int32  `json:"losses"`
+		Absent     int32  `json:"absent"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(input) > 0, "tournaments_results", "must contain at least 1 tournament result")
+	v.Check(len(input) <= app.config.batch.maxSize, "tournaments_results", fmt.Sprintf("must not contain more than %d tournament results", app.config.batch.maxSize))
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	trs := make([]*data.TournamentResult, len(input))
+	shikonas := make([]string, 0, len(input))
+
+	for i, item := range input {
+		trs[i] = &data.TournamentResult{
+			Tournament: item.Tournament,
+			Rikishi:    item.Rikishi,
+			Rank:       item.Rank,
+			Wins:       item.Wins,
+			Losses:     item.Losses,
+			Absent:     item.Absent,
+		}
+		shikonas = append(shikonas, item.Rikishi)
+	}
+
+	existing, err := app.models.Rikishis.ExistingShikonas(r.Context(), shikonas)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	batchErrors := make(map[int]map[string]string)
+
+	for i, tr := range trs {
+		v := validator.New()
+		err = data.ValidateTournamentResult(r.Context(), v, tr, existing)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
 
-	err = app.writeJSON(w, http.StatusCreated, envelope{"tournament_result": tr}, headers)
+		if !v.Valid() {
+			batchErrors[i] = v.Errors
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		app.failedBatchValidationResponse(w, r, batchErrors)
+		return
+	}
+
+	err = app.models.TournamentsResults.InsertBatch(r.Context(), trs)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"tournaments_results": trs}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -62,7 +147,18 @@ func (app *application) showTournamentResultHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	tr, err := app.models.TournamentsResults.Get(id)
+	v := validator.New()
+	qs := r.URL.Query()
+
+	fields := app.readList(qs, "fields", tournamentResultFields, v)
+	include := app.readList(qs, "include", tournamentResultInclude, v)
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	tr, err := app.models.TournamentsResults.Get(r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -73,7 +169,23 @@ func (app *application) showTournamentResultHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"tournament_result": tr}, nil)
+	headers := make(http.Header)
+
+	if len(include) == 0 {
+		if app.checkIfNoneMatch(w, r, tr.Version) {
+			return
+		}
+
+		headers.Set("ETag", app.etag(tr.Version))
+	}
+
+	resources, err := app.tournamentResultResources(r.Context(), []*data.TournamentResult{tr}, fields, include)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"tournament_result": resources[0]}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -86,7 +198,7 @@ func (app *application) updateTournamentResultHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	tr, err := app.models.TournamentsResults.Get(id)
+	tr, err := app.models.TournamentsResults.Get(r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -97,11 +209,15 @@ func (app *application) updateTournamentResultHandler(w http.ResponseWriter, r *
 		return
 	}
 
+	if !app.checkIfMatch(w, r, tr.Version) {
+		return
+	}
+
 	var input struct {
 		Tournament *string `json:"tournament"`
 		Rikishi    *string `json:"rikishi"`
 		Rank       *string `json:"rank"`
-		Wins       *int32  `json:"result"`
+		Wins       *int32  `json:"wins"`
 		Losses     *int32  `json:"losses"`
 		Absent     *int32  `json:"absent"`
 	}
@@ -137,12 +253,18 @@ func (app *application) updateTournamentResultHandler(w http.ResponseWriter, r *
 	}
 
 	v := validator.New()
-	if data.ValidateTournamentResult(v, tr, app.models.Rikishis); !v.Valid() {
+	err = data.ValidateTournamentResult(r.Context(), v, tr, app.models.Rikishis)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	err = app.models.TournamentsResults.Update(tr)
+	err = app.models.TournamentsResults.Update(r.Context(), tr)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrEditConflict):
@@ -153,7 +275,10 @@ func (app *application) updateTournamentResultHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"tournament_result": tr}, nil)
+	headers := make(http.Header)
+	headers.Set("ETag", app.etag(tr.Version))
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"tournament_result": tr}, headers)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -166,18 +291,35 @@ func (app *application) deleteTournamentResultHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	err = app.models.TournamentsResults.Delete(id)
+	tr, err := app.models.TournamentsResults.Get(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	if !app.checkIfMatch(w, r, tr.Version) {
+		return
+	}
+
+	err = app.models.TournamentsResults.Delete(r.Context(), id, tr.Version)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
 			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrEditConflict):
+			app.preconditionFailedResponse(w, r)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"message": "tournament record successfully deleted"}, nil)
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"message": "tournament record successfully deleted"}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -201,9 +343,18 @@ func (app *application) listTournamentsResultsHandler(w http.ResponseWriter, r *
 	input.Rank = app.readString(qs, "rank", "")
 	input.Wins = app.readInt(qs, "wins", 0, v)
 
+	fields := app.readList(qs, "fields", tournamentResultFields, v)
+	include := app.readList(qs, "include", tournamentResultInclude, v)
+
+	format := app.readFormat(r, v)
+	unlimited := app.readExportOptions(r, format, include, v)
+
 	input.Filters.Page = app.readInt(qs, "page", 1, v)
 	input.Filters.PageSize = app.readInt(qs, "page_size", 20, v)
 	input.Filters.Sort = app.readString(qs, "sort", "id")
+	input.Filters.Cursor = app.readString(qs, "cursor", "")
+	input.Filters.IncludeTotal = app.readBool(qs, "total", input.Filters.Cursor == "", v)
+	input.Filters.Unlimited = unlimited
 
 	input.Filters.SortSafelist = []string{"id", "tournament", "rikishi", "rank", "wins", "-id", "-tournament", "-rikishi", "-rank", "-wins"}
 
@@ -212,19 +363,43 @@ func (app *application) listTournamentsResultsHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	shikonas, err := app.models.Rikishis.GetShikonaHistory(input.Rikishi)
+	if input.Filters.Unlimited && app.contextGetAPIKey(r) == "" {
+		app.apiKeyRequiredResponse(w, r)
+		return
+	}
+
+	shikonas, err := app.models.Rikishis.GetShikonaHistory(r.Context(), input.Rikishi)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if format != formatJSON {
+		input.Filters.IncludeTotal = false
+
+		exp := app.newExporter(w, format, "tournaments_results", fields, tournamentResultFields)
+
+		err := app.models.TournamentsResults.Stream(r.Context(), input.Tournament, input.Rank, input.Wins, shikonas, input.Filters, func(tr *data.TournamentResult) error {
+			return exp.write(tr)
+		})
+
+		app.finishExport(w, r, exp, err)
+		return
+	}
+
+	trs, metadata, err := app.models.TournamentsResults.GetAll(r.Context(), input.Tournament, input.Rank, input.Wins, shikonas, input.Filters)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	trs, metadata, err := app.models.TournamentsResults.GetAll(input.Tournament, input.Rank, input.Wins, shikonas, input.Filters)
+	resources, err := app.tournamentResultResources(r.Context(), trs, fields, include)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"tournaments_results": trs, "metadata": metadata}, nil)
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"tournaments_results": resources, "metadata": metadata}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestTournamentsResultsHandlers(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+	)
+
+	seedTournamentsResults(t, app,
+		&data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Abi", Rank: "Maegashira 9", Wins: 12, Losses: 3},
+		&data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 12, Losses: 3},
+		&data.TournamentResult{Tournament: "2022 Sep", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 8, Losses: 7},
+	)
+
+	t.Run("Create", func(t *testing.T) {
+		res := ts.do(t, http.MethodPost, "/v1/tournamentsresults", `{"tournament": "2023 Jan", "rikishi": "Takakeisho", "rank": "Ozeki", "wins": 12, "losses": 3}`, nil)
+		assertStatus(t, res, http.StatusCreated)
+
+		res = ts.do(t, http.MethodPost, "/v1/tournamentsresults", `{"tournament": "2023 Jan", "rikishi": "Hakuho", "rank": "Yokozuna", "wins": 16}`, nil)
+		assertStatus(t, res, http.StatusUnprocessableEntity)
+
+		var body struct {
+			Error map[string]string `json:"error"`
+		}
+		res.decode(t, &body)
+
+		for _, key := range []string{"rikishi", "wins"} {
+			if _, ok := body.Error[key]; !ok {
+				t.Errorf("got errors %v; want an error for %q", body.Error, key)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		res := ts.do(t, http.MethodGet, "/v1/tournamentsresults?wins=10&sort=-id&include=rikishi", "", nil)
+		assertStatus(t, res, http.StatusOK)
+
+		var body struct {
+			Results []struct {
+				ID      int64 `json:"id"`
+				Rikishi struct {
+					Heya string `json:"heya"`
+				} `json:"rikishi"`
+			} `json:"tournaments_results"`
+			Metadata data.Metadata `json:"metadata"`
+		}
+		res.decode(t, &body)
+
+		ids := []int64{}
+		for _, result := range body.Results {
+			ids = append(ids, result.ID)
+		}
+
+		if want := []int64{4, 2, 1}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("got %v; want %v", ids, want)
+		}
+
+		if body.Results[2].Rikishi.Heya != "Shikoroyama" {
+			t.Errorf("got included rikishi %+v; want Abi's heya", body.Results[2].Rikishi)
+		}
+
+		if body.Metadata.TotalRecords != 3 {
+			t.Errorf("got %d total records; want 3", body.Metadata.TotalRecords)
+		}
+	})
+
+	t.Run("Update conflict", func(t *testing.T) {
+		res := ts.do(t, http.MethodPatch, "/v1/tournamentsresults/3", `{"wins": 9, "losses": 6}`, map[string]string{"If-Match": `"1"`})
+		assertStatus(t, res, http.StatusOK)
+
+		res = ts.do(t, http.MethodPatch, "/v1/tournamentsresults/3", `{"wins": 10, "losses": 5}`, map[string]string{"If-Match": `"1"`})
+		assertStatus(t, res, http.StatusPreconditionFailed)
+
+		res = ts.do(t, http.MethodDelete, "/v1/tournamentsresults/3", "", map[string]string{"If-Match": `"2"`})
+		assertStatus(t, res, http.StatusOK)
+	})
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/corsairconstantine/sumodb/cmd/api"
+
+// propagator reads and writes the W3C traceparent, tracestate and baggage
+// headers.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// newTracerProvider returns the provider for the configured exporter and a
+// function which flushes buffered spans and closes the exporter.
+func newTracerProvider(cfg config) (trace.TracerProvider, func(context.Context) error, error) {
+	var out io.Writer
+
+	switch cfg.tracing.exporter {
+	case "none":
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	case "stdout":
+		out = os.Stdout
+	case "file":
+		f, err := os.OpenFile(cfg.tracing.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = f
+	default:
+		return nil, nil, fmt.Errorf("unsupported trace exporter %q", cfg.tracing.exporter)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.tracing.sampleRatio))),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			semconv.ServiceName("sumodb"),
+			semconv.ServiceVersion(version),
+			attribute.String("deployment.environment.name", cfg.env),
+		)),
+	)
+
+	shutdown := func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+
+		if f, ok := out.(*os.File); ok && f != os.Stdout {
+			f.Close()
+		}
+
+		return err
+	}
+
+	return tp, shutdown, nil
+}
+
+// traceRequest starts a server span for each request, continuing the caller's
+// trace when it sends propagation headers. The span is renamed after the
+// matched route once the handler returns.
+func (app *application) traceRequest(next http.Handler) http.Handler {
+	tracer := app.tracerProvider.Tracer(tracerName)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := tracer.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+			semconv.ClientAddress(app.clientIP(r)),
+			semconv.UserAgentOriginal(r.UserAgent()),
+			attribute.String("sumodb.request_id", app.contextGetRequestID(r)),
+		))
+		defer span.End()
+
+		rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		if route := app.contextGetRequestInfo(r).route; route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rw.status))
+
+		if rw.status >= http.StatusInternalServerError {
+			span.SetStatus(otelcodes.Error, http.StatusText(rw.status))
+		}
+	})
+}
+
+// metadataCarrier adapts gRPC metadata for the propagator.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func (app *application) grpcStartSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = propagator.Extract(ctx, metadataCarrier(md))
+
+	attrs := []attribute.KeyValue{semconv.RPCSystemNameGRPC, semconv.RPCMethod(method)}
+
+	if info, ok := ctx.Value(requestInfoContextKey).(*requestInfo); ok {
+		attrs = append(attrs, attribute.String("sumodb.request_id", info.id))
+	}
+
+	return app.tracerProvider.Tracer(tracerName).Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// grpcEndSpan marks the span as failed only for codes which indicate a
+// server problem, as with HTTP 5xx responses.
+func grpcEndSpan(span trace.Span, err error) {
+	code := status.Code(err)
+
+	span.SetAttributes(semconv.RPCResponseStatusCode(code.String()))
+
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func (app *application) grpcTraceUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, span := app.grpcStartSpan(ctx, info.FullMethod)
+	defer func() { grpcEndSpan(span, err) }()
+
+	return handler(ctx, req)
+}
+
+func (app *application) grpcTraceStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx, span := app.grpcStartSpan(ss.Context(), info.FullMethod)
+	defer func() { grpcEndSpan(span, err) }()
+
+	return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+}
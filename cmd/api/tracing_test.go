@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/pkg/sumodbpb"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testTraceparent = "00-" + testTraceID + "-00f067aa0ba902b7-01"
+)
+
+func newTracedTestApplication(t *testing.T) (*application, *tracetest.SpanRecorder) {
+	app := newTestApplication(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+
+	app.tracerProvider = tp
+	app.models = data.InstrumentModels(data.NewMemoryModels(), tp, app.metrics.observeQuery)
+
+	return app, recorder
+}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+
+	t.Fatalf("got spans %q; want one named %q", names, name)
+	return nil
+}
+
+func assertChild(t *testing.T, child, parent sdktrace.ReadOnlySpan) {
+	t.Helper()
+
+	if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("got %q with parent %s; want %q (%s)", child.Name(), child.Parent().SpanID(), parent.Name(), parent.SpanContext().SpanID())
+	}
+}
+
+func TestTraceRequest(t *testing.T) {
+	app, recorder := newTracedTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+	seedBouts(t, app, &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"})
+
+	recorder.Reset()
+
+	res := ts.do(t, http.MethodGet, "/v1/bouts/1", "", map[string]string{"traceparent": testTraceparent})
+	assertStatus(t, res, http.StatusOK)
+
+	spans := recorder.Ended()
+
+	server := findSpan(t, spans, "GET /v1/bouts/:id")
+
+	if server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("got span kind %s; want server", server.SpanKind())
+	}
+
+	if got := server.SpanContext().TraceID().String(); got != testTraceID {
+		t.Errorf("got trace ID %s; want the caller's %s", got, testTraceID)
+	}
+
+	attrs := make(map[string]string)
+	for _, kv := range server.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	for key, want := range map[string]string{
+		"http.route":                "/v1/bouts/:id",
+		"http.response.status_code": "200",
+		"sumodb.request_id":         res.header.Get("X-Request-ID"),
+	} {
+		if attrs[key] != want {
+			t.Errorf("got %s %q; want %q", key, attrs[key], want)
+		}
+	}
+
+	assertChild(t, findSpan(t, spans, "bouts.Get"), server)
+	assertChild(t, findSpan(t, spans, "writeJSON"), server)
+}
+
+func TestTraceGRPC(t *testing.T) {
+	app, recorder := newTracedTestApplication(t)
+	client := newTestGRPCClient(t, app)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", testTraceparent)
+
+	_, err := client.GetBout(ctx, &sumodbpb.GetBoutRequest{Id: 42})
+	assertCode(t, err, codes.NotFound)
+
+	spans := recorder.Ended()
+
+	server := findSpan(t, spans, sumodbpb.Sumodb_GetBout_FullMethodName)
+
+	if got := server.SpanContext().TraceID().String(); got != testTraceID {
+		t.Errorf("got trace ID %s; want the caller's %s", got, testTraceID)
+	}
+
+	get := findSpan(t, spans, "bouts.Get")
+	assertChild(t, get, server)
+
+	if get.Status().Code != 0 || server.Status().Code != 0 {
+		t.Errorf("got a not found lookup marked as failed: %v, %v", get.Status(), server.Status())
+	}
+}
+
+func TestTraceSQL(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var cfg config
+	cfg.db.driver = "sqlite"
+	cfg.db.dsn = filepath.Join(t.TempDir(), "sumodb.db")
+	cfg.db.maxOpenConns = 1
+	cfg.db.maxIdleTime = "15m"
+
+	db, err := openDB(cfg, tp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "bouts.Get")
+
+	_, err = db.ExecContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent.End()
+
+	spans := recorder.Ended()
+
+	stmt := findSpan(t, spans, "sql.conn.exec")
+	assertChild(t, stmt, findSpan(t, spans, "bouts.Get"))
+
+	for _, kv := range stmt.Attributes() {
+		if kv.Key == "db.query.text" && kv.Value.AsString() == "SELECT 1" {
+			return
+		}
+	}
+
+	t.Errorf("got attributes %v; want the statement text", stmt.Attributes())
+}
+
+func TestTraceFileExporter(t *testing.T) {
+	var cfg config
+	cfg.tracing.exporter = "file"
+	cfg.tracing.file = filepath.Join(t.TempDir(), "traces.jsonl")
+	cfg.tracing.sampleRatio = 1
+
+	tp, shutdown, err := newTracerProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "exported")
+	span.End()
+
+	err = shutdown(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(cfg.tracing.file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(b), `"Name":"exported"`) {
+		t.Errorf("got %s; want the exported span", b)
+	}
+
+	cfg.tracing.exporter = "zipkin"
+
+	_, _, err = newTracerProvider(cfg)
+	if err == nil {
+		t.Error("got no error for an unsupported exporter")
+	}
+}
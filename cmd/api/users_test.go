@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRegisterUserHandler(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"Valid", `{"name": "Gyoji", "email": "gyoji@example.com", "password": "pa55word123"}`, http.StatusCreated},
+		{"Duplicate email", `{"name": "Gyoji", "email": "GYOJI@example.com", "password": "pa55word123"}`, http.StatusUnprocessableEntity},
+		{"Short password", `{"name": "Yobidashi", "email": "yobidashi@example.com", "password": "pa55"}`, http.StatusUnprocessableEntity},
+		{"Invalid email", `{"name": "Yobidashi", "email": "yobidashi", "password": "pa55word123"}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodPost, "/v1/users", tt.body, nil)
+			assertStatus(t, res, tt.wantCode)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+func (app *application) createWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret"`
+		Active *bool    `json:"active"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	webhook := &data.Webhook{
+		URL:    input.URL,
+		Events: input.Events,
+		Secret: input.Secret,
+		Active: true,
+		Owner:  app.webhookOwner(r),
+	}
+
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret, err = generateWebhookSecret()
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	v := validator.New()
+	if data.ValidateWebhook(v, webhook, app.config.webhooks.allowPrivateHosts); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Webhooks.Insert(r.Context(), webhook)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/webhooks/%d", webhook.ID))
+	headers.Set("ETag", app.etag(webhook.Version))
+
+	// The secret is only ever returned here, so receivers must store it
+	// when subscribing.
+	err = app.writeJSON(w, r, http.StatusCreated, envelope{"webhook": webhook, "secret": webhook.Secret}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) showWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	if app.checkIfNoneMatch(w, r, webhook.Version) {
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("ETag", app.etag(webhook.Version))
+
+	err := app.writeJSON(w, r, http.StatusOK, envelope{"webhook": webhook}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) updateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	if !app.checkIfMatch(w, r, webhook.Version) {
+		return
+	}
+
+	var input struct {
+		URL    *string  `json:"url"`
+		Events []string `json:"events"`
+		Secret *string  `json:"secret"`
+		Active *bool    `json:"active"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.URL != nil {
+		webhook.URL = *input.URL
+	}
+
+	if input.Events != nil {
+		webhook.Events = input.Events
+	}
+
+	if input.Secret != nil {
+		webhook.Secret = *input.Secret
+	}
+
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+
+	v := validator.New()
+	if data.ValidateWebhook(v, webhook, app.config.webhooks.allowPrivateHosts); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Webhooks.Update(r.Context(), webhook)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrEditConflict):
+			app.editConflictResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("ETag", app.etag(webhook.Version))
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"webhook": webhook}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	if !app.checkIfMatch(w, r, webhook.Version) {
+		return
+	}
+
+	err := app.models.Webhooks.Delete(r.Context(), webhook.ID, webhook.Owner)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"message": "webhook successfully deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	filters, ok := app.readPageFilters(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, metadata, err := app.models.Webhooks.GetAll(r.Context(), app.webhookOwner(r), filters)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"webhooks": webhooks, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := app.readWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	filters, ok := app.readPageFilters(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, metadata, err := app.models.Webhooks.GetDeliveries(r.Context(), webhook.ID, webhook.Owner, filters)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, r, http.StatusOK, envelope{"deliveries": deliveries, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) readWebhook(w http.ResponseWriter, r *http.Request) (*data.Webhook, bool) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	webhook, err := app.models.Webhooks.Get(r.Context(), id, app.webhookOwner(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// webhookOwner identifies the API key of the request. Webhooks are only
+// visible to the key which created them, so partners cannot see or change
+// each other's subscriptions and deliveries.
+func (app *application) webhookOwner(r *http.Request) string {
+	sum := sha256.Sum256([]byte(app.contextGetAPIKey(r)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (app *application) readPageFilters(w http.ResponseWriter, r *http.Request) (data.Filters, bool) {
+	v := validator.New()
+	qs := r.URL.Query()
+
+	filters := data.Filters{
+		Page:         app.readInt(qs, "page", 1, v),
+		PageSize:     app.readInt(qs, "page_size", 20, v),
+		Sort:         "id",
+		SortSafelist: []string{"id"},
+	}
+
+	if data.ValidateFilters(v, filters); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return filters, false
+	}
+
+	return filters, true
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+)
+
+const webhookBatchSize = 50
+
+var errPrivateWebhookAddr = errors.New("webhook address is loopback or private")
+
+// newWebhookClient returns the client deliveries are sent with. Unless private
+// hosts are allowed, it refuses to connect to loopback and private addresses,
+// which a public host name may resolve to even though its URL was validated.
+// Proxies are not used, so the address checked is the one connected to.
+func (app *application) newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: app.config.webhooks.timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			if app.config.webhooks.allowPrivateHosts {
+				return nil
+			}
+
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !data.PublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errPrivateWebhookAddr, address)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   app.config.webhooks.timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// runWebhookDispatcher delivers due webhooks every poll interval until ctx is
+// cancelled. Deliveries in flight when ctx is cancelled are finished first.
+func (app *application) runWebhookDispatcher(ctx context.Context) {
+	client := app.newWebhookClient()
+
+	ticker := time.NewTicker(app.config.webhooks.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := app.dispatchWebhooks(context.WithoutCancel(ctx), client, time.Now())
+			if err != nil {
+				app.logger.PrintError(err, nil)
+			}
+		}
+	}
+}
+
+// dispatchWebhooks attempts every delivery due at now. Claimed deliveries are
+// leased for twice the request timeout, so another instance only picks them
+// up if this one dies mid-delivery.
+func (app *application) dispatchWebhooks(ctx context.Context, client *http.Client, now time.Time) error {
+	for {
+		deliveries, err := app.models.Webhooks.ClaimDeliveries(ctx, now, 2*app.config.webhooks.timeout, webhookBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(deliveries))
+
+		for i, delivery := range deliveries {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				errs[i] = app.deliverWebhook(ctx, client, delivery, now)
+			}()
+		}
+
+		wg.Wait()
+
+		err = errors.Join(errs...)
+		if err != nil || len(deliveries) < webhookBatchSize {
+			return err
+		}
+	}
+}
+
+func (app *application) deliverWebhook(ctx context.Context, client *http.Client, delivery *data.WebhookDelivery, now time.Time) error {
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.LastError = ""
+
+	webhook, err := app.models.Webhooks.GetForDelivery(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, data.ErrRecordNotFound):
+		delivery.Status = data.DeliveryDead
+		delivery.LastError = "webhook no longer exists"
+		return app.models.Webhooks.UpdateDelivery(ctx, delivery)
+	case err != nil:
+		return err
+	case !webhook.Active:
+		delivery.Status = data.DeliveryDead
+		delivery.LastError = "webhook is inactive"
+		return app.models.Webhooks.UpdateDelivery(ctx, delivery)
+	}
+
+	status, err := app.postWebhook(ctx, client, webhook, delivery)
+
+	delivery.ResponseStatus = status
+
+	switch {
+	case err != nil:
+		delivery.LastError = err.Error()
+	case status < 200 || status > 299:
+		delivery.LastError = fmt.Sprintf("unexpected response status %d", status)
+	}
+
+	switch {
+	case delivery.LastError == "":
+		delivery.Status = data.DeliveryDelivered
+	case delivery.Attempts >= app.config.webhooks.maxAttempts:
+		delivery.Status = data.DeliveryDead
+	default:
+		delivery.NextAttemptAt = now.Add(app.config.webhooks.backoff << (delivery.Attempts - 1))
+	}
+
+	properties := jsonlog.Properties{
+		"webhook_id":  webhook.ID,
+		"delivery_id": delivery.ID,
+		"event":       delivery.Event,
+		"attempts":    delivery.Attempts,
+		"status":      delivery.Status,
+	}
+
+	if delivery.LastError == "" {
+		app.logger.PrintDebug("webhook delivered", properties)
+	} else {
+		properties["error"] = delivery.LastError
+		app.logger.PrintWarn("webhook delivery failed", properties)
+	}
+
+	return app.models.Webhooks.UpdateDelivery(ctx, delivery)
+}
+
+func (app *application) postWebhook(ctx context.Context, client *http.Client, webhook *data.Webhook, delivery *data.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sumodb-webhooks/"+version)
+	req.Header.Set("X-Sumodb-Event", delivery.Event)
+	req.Header.Set("X-Sumodb-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Sumodb-Signature", "t="+timestamp+",v1="+signWebhook(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	return res.StatusCode, nil
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<payload>". Signing
+// the timestamp lets receivers reject replayed deliveries.
+func signWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+var apiKey = map[string]string{"Authorization": "Bearer test-key"}
+
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []webhookRequest
+}
+
+// newWebhookReceiver answers each delivery with the next of statuses, then
+// with 204 once they run out.
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	rec := &webhookReceiver{statuses: statuses}
+
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		rec.requests = append(rec.requests, webhookRequest{header: r.Header, body: body})
+
+		status := http.StatusNoContent
+		if len(rec.statuses) > 0 {
+			status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+		}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rec.Close)
+
+	return rec
+}
+
+func (rec *webhookReceiver) received() []webhookRequest {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return append([]webhookRequest(nil), rec.requests...)
+}
+
+func newWebhookBout() *data.Bout {
+	return &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"}
+}
+
+func createWebhook(t *testing.T, ts *testServer, body string) (int64, string) {
+	t.Helper()
+
+	res := ts.do(t, http.MethodPost, "/v1/webhooks", body, apiKey)
+	assertStatus(t, res, http.StatusCreated)
+
+	var got struct {
+		Webhook data.Webhook `json:"webhook"`
+		Secret  string       `json:"secret"`
+	}
+	res.decode(t, &got)
+
+	return got.Webhook.ID, got.Secret
+}
+
+func webhookDeliveries(t *testing.T, ts *testServer, id string) []data.WebhookDelivery {
+	t.Helper()
+
+	res := ts.do(t, http.MethodGet, "/v1/webhooks/"+id+"/deliveries", "", apiKey)
+	assertStatus(t, res, http.StatusOK)
+
+	var got struct {
+		Deliveries []data.WebhookDelivery `json:"deliveries"`
+	}
+	res.decode(t, &got)
+
+	return got.Deliveries
+}
+
+func TestWebhooksCRUD(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+
+	res := ts.do(t, http.MethodGet, "/v1/webhooks", "", nil)
+	assertStatus(t, res, http.StatusUnauthorized)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "Missing URL", body: `{"events": ["bout.created"]}`, want: "url"},
+		{name: "Relative URL", body: `{"url": "/hooks", "events": ["bout.created"]}`, want: "url"},
+		{name: "No events", body: `{"url": "https://example.com/hooks", "events": []}`, want: "events"},
+		{name: "Unknown event", body: `{"url": "https://example.com/hooks", "events": ["rikishi.created"]}`, want: "events"},
+		{name: "Short secret", body: `{"url": "https://example.com/hooks", "events": ["bout.created"], "secret": "short"}`, want: "secret"},
+		{name: "Loopback address", body: `{"url": "http://127.0.0.1:8080/hooks", "events": ["bout.created"]}`, want: "url"},
+		{name: "IPv6 loopback address", body: `{"url": "http://[::1]/hooks", "events": ["bout.created"]}`, want: "url"},
+		{name: "Private address", body: `{"url": "https://10.0.0.5/hooks", "events": ["bout.created"]}`, want: "url"},
+		{name: "Link-local address", body: `{"url": "http://169.254.169.254/latest/meta-data", "events": ["bout.created"]}`, want: "url"},
+		{name: "Localhost", body: `{"url": "http://LOCALHOST./hooks", "events": ["bout.created"]}`, want: "url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ts.do(t, http.MethodPost, "/v1/webhooks", tt.body, apiKey)
+			assertStatus(t, res, http.StatusUnprocessableEntity)
+
+			var got struct {
+				Error map[string]string `json:"error"`
+			}
+			res.decode(t, &got)
+
+			if _, ok := got.Error[tt.want]; !ok {
+				t.Errorf("got errors %v; want one for %q", got.Error, tt.want)
+			}
+		})
+	}
+
+	res = ts.do(t, http.MethodPost, "/v1/webhooks", `{"url": "https://example.com/hooks", "events": ["bout.created"]}`, apiKey)
+	assertStatus(t, res, http.StatusCreated)
+
+	if res.header.Get("Location") != "/v1/webhooks/1" {
+		t.Errorf("got Location %q; want /v1/webhooks/1", res.header.Get("Location"))
+	}
+
+	var created struct {
+		Webhook map[string]interface{} `json:"webhook"`
+		Secret  string                 `json:"secret"`
+	}
+	res.decode(t, &created)
+
+	if len(created.Secret) != 64 || created.Webhook["active"] != true {
+		t.Errorf("got %+v; want an active webhook with a generated secret", created)
+	}
+
+	res = ts.do(t, http.MethodGet, "/v1/webhooks/1", "", apiKey)
+	assertStatus(t, res, http.StatusOK)
+
+	if strings.Contains(string(res.body), created.Secret) {
+		t.Error("got the secret in a show response")
+	}
+
+	res = ts.do(t, http.MethodPatch, "/v1/webhooks/1", `{"active": false}`, map[string]string{"Authorization": "Bearer test-key", "If-Match": `"2"`})
+	assertStatus(t, res, http.StatusPreconditionFailed)
+
+	res = ts.do(t, http.MethodPatch, "/v1/webhooks/1", `{"events": ["bout.created", "tournament_result.updated"], "active": false}`, map[string]string{"Authorization": "Bearer test-key", "If-Match": `"1"`})
+	assertStatus(t, res, http.StatusOK)
+
+	var updated struct {
+		Webhook data.Webhook `json:"webhook"`
+	}
+	res.decode(t, &updated)
+
+	if updated.Webhook.Version != 2 || updated.Webhook.Active || len(updated.Webhook.Events) != 2 {
+		t.Errorf("got %+v after update", updated.Webhook)
+	}
+
+	res = ts.do(t, http.MethodGet, "/v1/webhooks?page_size=200", "", apiKey)
+	assertStatus(t, res, http.StatusUnprocessableEntity)
+
+	res = ts.do(t, http.MethodGet, "/v1/webhooks", "", apiKey)
+	assertStatus(t, res, http.StatusOK)
+
+	var list struct {
+		Webhooks []data.Webhook `json:"webhooks"`
+		Metadata data.Metadata  `json:"metadata"`
+	}
+	res.decode(t, &list)
+
+	if len(list.Webhooks) != 1 || list.Metadata.TotalRecords != 1 {
+		t.Errorf("got %d webhooks, %+v", len(list.Webhooks), list.Metadata)
+	}
+
+	res = ts.do(t, http.MethodDelete, "/v1/webhooks/1", "", apiKey)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodGet, "/v1/webhooks/1/deliveries", "", apiKey)
+	assertStatus(t, res, http.StatusNotFound)
+}
+
+func TestWebhooksOwner(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.apiKeys = append(app.config.apiKeys, "other-key")
+	ts := newTestServer(t, app.routes())
+
+	id, _ := createWebhook(t, ts, `{"url": "https://example.com/hooks", "events": ["bout.created"]}`)
+	path := "/v1/webhooks/" + strconv.FormatInt(id, 10)
+
+	other := map[string]string{"Authorization": "Bearer other-key", "If-Match": `"1"`}
+
+	for _, tt := range []struct{ method, path, body string }{
+		{http.MethodGet, path, ""},
+		{http.MethodPatch, path, `{"url": "https://example.org/hooks"}`},
+		{http.MethodDelete, path, ""},
+		{http.MethodGet, path + "/deliveries", ""},
+	} {
+		res := ts.do(t, tt.method, tt.path, tt.body, other)
+		assertStatus(t, res, http.StatusNotFound)
+	}
+
+	res := ts.do(t, http.MethodGet, "/v1/webhooks", "", other)
+	assertStatus(t, res, http.StatusOK)
+
+	var list struct {
+		Webhooks []data.Webhook `json:"webhooks"`
+	}
+	res.decode(t, &list)
+
+	if len(list.Webhooks) != 0 {
+		t.Errorf("got %d webhooks for another key; want 0", len(list.Webhooks))
+	}
+
+	res = ts.do(t, http.MethodGet, path, "", apiKey)
+	assertStatus(t, res, http.StatusOK)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.webhooks.allowPrivateHosts = true
+	ts := newTestServer(t, app.routes())
+	rec := newWebhookReceiver(t)
+
+	seedRikishis(t, app,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	_, secret := createWebhook(t, ts, `{"url": "`+rec.URL+`", "events": ["bout.created", "tournament_result.deleted"], "secret": "0123456789abcdef"}`)
+
+	if secret != "0123456789abcdef" {
+		t.Errorf("got secret %q; want the one provided", secret)
+	}
+
+	res := ts.do(t, http.MethodPost, "/v1/bouts", `{"tournament": "2022 Nov", "day": "1", "division": "Makuuchi", "winner": "Takakeisho", "loser": "Takayasu", "kimarite": "oshidashi"}`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	res = ts.do(t, http.MethodPatch, "/v1/bouts/1", `{"kimarite": "tsukiotoshi"}`, nil)
+	assertStatus(t, res, http.StatusOK)
+
+	res = ts.do(t, http.MethodPost, "/v1/tournamentsresults", `{"tournament": "2022 Nov", "rikishi": "Takakeisho", "rank": "Ozeki", "wins": 12, "losses": 3, "absent": 0}`, nil)
+	assertStatus(t, res, http.StatusCreated)
+
+	res = ts.do(t, http.MethodDelete, "/v1/tournamentsresults/1", "", nil)
+	assertStatus(t, res, http.StatusOK)
+
+	err := app.dispatchWebhooks(t.Context(), app.newWebhookClient(), time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := rec.received()
+	if len(requests) != 2 {
+		t.Fatalf("got %d deliveries; want 2", len(requests))
+	}
+
+	// Due deliveries are sent concurrently, so order them by delivery id.
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].header.Get("X-Sumodb-Delivery") < requests[j].header.Get("X-Sumodb-Delivery")
+	})
+
+	for i, want := range []string{"bout.created", "tournament_result.deleted"} {
+		req := requests[i]
+
+		if got := req.header.Get("X-Sumodb-Event"); got != want {
+			t.Errorf("got event %q; want %q", got, want)
+		}
+
+		var payload struct {
+			Event string                 `json:"event"`
+			Data  map[string]interface{} `json:"data"`
+		}
+
+		err := json.Unmarshal(req.body, &payload)
+		if err != nil || payload.Event != want || payload.Data["id"] != float64(1) {
+			t.Errorf("got payload %s; want the %s record", req.body, want)
+		}
+
+		var timestamp, signature string
+		for _, part := range strings.Split(req.header.Get("X-Sumodb-Signature"), ",") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "t":
+				timestamp = value
+			case "v1":
+				signature = value
+			}
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "." + string(req.body)))
+
+		if !hmac.Equal([]byte(signature), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+			t.Errorf("got signature %q; does not match the payload", req.header.Get("X-Sumodb-Signature"))
+		}
+	}
+
+	deliveries := webhookDeliveries(t, ts, "1")
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d logged deliveries; want 2", len(deliveries))
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.Status != data.DeliveryDelivered || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusNoContent {
+			t.Errorf("got delivery %+v; want delivered on the first attempt", delivery)
+		}
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.webhooks.allowPrivateHosts = true
+	ts := newTestServer(t, app.routes())
+
+	client := app.newWebhookClient()
+	now := time.Now().Add(time.Second)
+
+	dispatch := func(at time.Time) {
+		t.Helper()
+
+		err := app.dispatchWebhooks(t.Context(), client, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Retried", func(t *testing.T) {
+		rec := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusFound)
+		id, _ := createWebhook(t, ts, `{"url": "`+rec.URL+`", "events": ["bout.deleted"]}`)
+
+		bout := newWebhookBout()
+		seedBouts(t, app, bout)
+
+		err := app.models.Bouts.Delete(t.Context(), bout.ID, bout.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dispatch(now)
+
+		deliveries := webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+		if delivery := deliveries[0]; delivery.Status != data.DeliveryPending || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusInternalServerError {
+			t.Fatalf("got delivery %+v; want a pending retry", delivery)
+		}
+
+		dispatch(now.Add(30 * time.Second))
+
+		if len(rec.received()) != 1 {
+			t.Fatal("got a retry before the backoff elapsed")
+		}
+
+		dispatch(now.Add(time.Minute))
+
+		deliveries = webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+		if delivery := deliveries[0]; delivery.Status != data.DeliveryPending || delivery.Attempts != 2 || delivery.ResponseStatus != http.StatusFound {
+			t.Fatalf("got delivery %+v; want redirects treated as failures", delivery)
+		}
+
+		dispatch(now.Add(2 * time.Minute))
+
+		if len(rec.received()) != 2 {
+			t.Fatal("got a retry before the doubled backoff elapsed")
+		}
+
+		dispatch(now.Add(3 * time.Minute))
+
+		deliveries = webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+		if delivery := deliveries[0]; delivery.Status != data.DeliveryDelivered || delivery.Attempts != 3 {
+			t.Errorf("got delivery %+v; want delivered on the third attempt", delivery)
+		}
+	})
+
+	t.Run("Dead", func(t *testing.T) {
+		rec := newWebhookReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		id, _ := createWebhook(t, ts, `{"url": "`+rec.URL+`", "events": ["bout.updated"]}`)
+
+		bout := newWebhookBout()
+		seedBouts(t, app, bout)
+		bout.Kimarite = "yorikiri"
+
+		err := app.models.Bouts.Update(t.Context(), bout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 5; i++ {
+			dispatch(now.Add(time.Duration(i) * time.Hour))
+		}
+
+		if len(rec.received()) != app.config.webhooks.maxAttempts {
+			t.Errorf("got %d attempts; want %d", len(rec.received()), app.config.webhooks.maxAttempts)
+		}
+
+		deliveries := webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+		if delivery := deliveries[0]; delivery.Status != data.DeliveryDead || delivery.LastError == "" {
+			t.Errorf("got delivery %+v; want dead-lettered", delivery)
+		}
+	})
+
+	t.Run("Inactive", func(t *testing.T) {
+		rec := newWebhookReceiver(t)
+		id, _ := createWebhook(t, ts, `{"url": "`+rec.URL+`", "events": ["bout.created"]}`)
+
+		seedBouts(t, app, newWebhookBout())
+
+		res := ts.do(t, http.MethodPatch, "/v1/webhooks/"+strconv.FormatInt(id, 10), `{"active": false}`, apiKey)
+		assertStatus(t, res, http.StatusOK)
+
+		dispatch(now)
+
+		if len(rec.received()) != 0 {
+			t.Error("got a delivery to an inactive webhook")
+		}
+
+		deliveries := webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+		if delivery := deliveries[0]; delivery.Status != data.DeliveryDead {
+			t.Errorf("got delivery %+v; want dead-lettered", delivery)
+		}
+
+		seedBouts(t, app, newWebhookBout())
+
+		if len(webhookDeliveries(t, ts, strconv.FormatInt(id, 10))) != 1 {
+			t.Error("got a delivery queued for an inactive webhook")
+		}
+	})
+}
+
+func TestWebhookPrivateAddress(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.webhooks.allowPrivateHosts = true
+	ts := newTestServer(t, app.routes())
+	rec := newWebhookReceiver(t)
+
+	id, _ := createWebhook(t, ts, `{"url": "`+rec.URL+`", "events": ["bout.created"]}`)
+
+	// A host name passing validation may still resolve to a private address,
+	// so the dispatcher checks the address it connects to.
+	app.config.webhooks.allowPrivateHosts = false
+
+	seedBouts(t, app, newWebhookBout())
+
+	err := app.dispatchWebhooks(t.Context(), app.newWebhookClient(), time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.received()) != 0 {
+		t.Error("got a delivery to a loopback address")
+	}
+
+	deliveries := webhookDeliveries(t, ts, strconv.FormatInt(id, 10))
+	if delivery := deliveries[0]; delivery.Status != data.DeliveryPending || !strings.Contains(delivery.LastError, "loopback or private") {
+		t.Errorf("got delivery %+v; want the connection refused", delivery)
+	}
+}
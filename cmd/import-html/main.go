@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/importer"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"github.com/corsairconstantine/sumodb/internal/sumohtml"
+	_ "github.com/lib/pq"
+)
+
+type config struct {
+	db struct {
+		dsn string
+	}
+	batchSize int
+	dryRun    bool
+}
+
+type parsedPage struct {
+	path string
+	page *sumohtml.Page
+	date time.Time
+}
+
+type rikishiSeen struct {
+	source string
+	row    int
+	rank   string
+	heya   string
+}
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.db.dsn, "db-dsn", os.Getenv("SUMODB_DSN"), "PostgreSQL DSN")
+
+	flag.IntVar(&cfg.batchSize, "batch-size", 500, "Rows inserted per transaction")
+	flag.BoolVar(&cfg.dryRun, "dry-run", false, "Validate every record and report rejections without writing to the database")
+
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		out.Write([]byte("Usage: import-html [flags] <file or directory>...\n\nImports saved banzuke, results and torikumi pages.\n\n"))
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	logger := jsonlog.New(os.Stderr, jsonlog.LevelInfo)
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	pages, err := parsePages(flag.Args(), logger)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+	defer db.Close()
+
+	imp, err := importer.New(context.Background(), data.NewModels(db, data.DefaultTimeouts), logger, os.Stdout, cfg.dryRun, cfg.batchSize)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	for _, step := range []func(*importer.Importer, []parsedPage) error{importRikishis, importTournamentsResults, importBouts} {
+		err = step(imp, pages)
+		if err != nil {
+			logger.PrintFatal(err, nil)
+		}
+	}
+
+	err = imp.Flush()
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	imported, rejected, duplicates := imp.Summary()
+
+	logger.PrintInfo("import finished", jsonlog.Properties{
+		"pages":      len(pages),
+		"imported":   imported,
+		"rejected":   rejected,
+		"duplicates": duplicates,
+		"dry_run":    cfg.dryRun,
+	})
+}
+
+func openDB(cfg config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.db.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func parsePages(args []string, logger *jsonlog.Logger) ([]parsedPage, error) {
+	var pages []parsedPage
+
+	for _, arg := range args {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := strings.ToLower(filepath.Ext(path))
+			if d.IsDir() || (ext != ".html" && ext != ".htm") {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			page, err := sumohtml.Parse(f)
+			if err != nil {
+				logger.PrintError(err, jsonlog.Properties{"file": path})
+				return nil
+			}
+
+			date, _ := time.Parse("2006 Jan", page.Tournament)
+
+			pages = append(pages, parsedPage{path: path, page: page, date: date})
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pages[i].date.Before(pages[j].date)
+	})
+
+	return pages, nil
+}
+
+func importRikishis(imp *importer.Importer, pages []parsedPage) error {
+	seen := make(map[string]*rikishiSeen)
+	var order []string
+
+	for _, p := range pages {
+		if p.page.Kind == sumohtml.KindTorikumi {
+			continue
+		}
+
+		for _, entry := range p.page.Entries {
+			if imp.Known(entry.Shikona) {
+				continue
+			}
+
+			r, ok := seen[entry.Shikona]
+			if !ok {
+				r = &rikishiSeen{source: p.path, row: entry.Row, rank: entry.Rank}
+				seen[entry.Shikona] = r
+				order = append(order, entry.Shikona)
+			}
+
+			if sumohtml.RankOrder(entry.Rank) < sumohtml.RankOrder(r.rank) {
+				r.rank = entry.Rank
+			}
+
+			if entry.Heya != "" {
+				r.heya = entry.Heya
+			}
+		}
+	}
+
+	for _, shikona := range order {
+		r := seen[shikona]
+
+		rikishi := &data.Rikishi{
+			Shikona:        shikona,
+			HighestRank:    sumohtml.RankTitle(r.rank),
+			Heya:           r.heya,
+			ShikonaHistory: []string{shikona},
+		}
+
+		err := imp.Rikishi(r.source, r.row, rikishi)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func importTournamentsResults(imp *importer.Importer, pages []parsedPage) error {
+	for _, kind := range []sumohtml.PageKind{sumohtml.KindResults, sumohtml.KindBanzuke} {
+		for _, p := range pages {
+			if p.page.Kind != kind {
+				continue
+			}
+
+			rows := make([]int, 0, len(p.page.Entries))
+			for _, entry := range p.page.Entries {
+				if entry.HasRecord {
+					rows = append(rows, entry.Row)
+				}
+			}
+
+			for i, tr := range p.page.TournamentResults() {
+				err := imp.TournamentResult(p.path, rows[i], tr)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func importBouts(imp *importer.Importer, pages []parsedPage) error {
+	for _, p := range pages {
+		if p.page.Kind != sumohtml.KindTorikumi {
+			continue
+		}
+
+		for _, pairing := range p.page.Pairings {
+			if !pairing.Decided() {
+				imp.Reject(p.path, pairing.Row, map[string]string{
+					"result": "no winner recorded for " + pairing.East + " vs " + pairing.West,
+				})
+				continue
+			}
+
+			bout := &data.Bout{
+				Tournament: p.page.Tournament,
+				Day:        p.page.Day,
+				Division:   pairing.Division,
+				Winner:     pairing.Winner,
+				Loser:      pairing.Loser,
+				Kimarite:   pairing.Kimarite,
+			}
+
+			err := imp.Bout(p.path, pairing.Row, bout)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/importer"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	_ "github.com/lib/pq"
+)
+
+type config struct {
+	db struct {
+		dsn string
+	}
+	rikishis           string
+	tournamentsResults string
+	bouts              string
+	batchSize          int
+	dryRun             bool
+}
+
+type csvFile struct {
+	name    string
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.db.dsn, "db-dsn", os.Getenv("SUMODB_DSN"), "PostgreSQL DSN")
+
+	flag.StringVar(&cfg.rikishis, "rikishis", "", "CSV file with rikishis (shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana)")
+	flag.StringVar(&cfg.tournamentsResults, "tournaments-results", "", "CSV file with tournament results (tournament, rikishi, rank, wins, losses, absent)")
+	flag.StringVar(&cfg.bouts, "bouts", "", "CSV file with bouts (tournament, day, division, winner, loser, kimarite)")
+
+	flag.IntVar(&cfg.batchSize, "batch-size", 500, "Rows inserted per transaction")
+	flag.BoolVar(&cfg.dryRun, "dry-run", false, "Validate every row and report rejections without writing to the database")
+
+	flag.Parse()
+
+	logger := jsonlog.New(os.Stderr, jsonlog.LevelInfo)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+	defer db.Close()
+
+	imp, err := importer.New(context.Background(), data.NewModels(db, data.DefaultTimeouts), logger, os.Stdout, cfg.dryRun, cfg.batchSize)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	steps := []struct {
+		path string
+		load func(*importer.Importer, *csvFile) error
+	}{
+		{cfg.rikishis, loadRikishis},
+		{cfg.tournamentsResults, loadTournamentsResults},
+		{cfg.bouts, loadBouts},
+	}
+
+	for _, step := range steps {
+		if step.path == "" {
+			continue
+		}
+
+		err = importFile(imp, step.path, step.load)
+		if err != nil {
+			logger.PrintFatal(err, jsonlog.Properties{"file": step.path})
+		}
+	}
+
+	err = imp.Flush()
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	imported, rejected, duplicates := imp.Summary()
+
+	logger.PrintInfo("import finished", jsonlog.Properties{
+		"imported":   imported,
+		"rejected":   rejected,
+		"duplicates": duplicates,
+		"dry_run":    cfg.dryRun,
+	})
+}
+
+func openDB(cfg config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.db.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func importFile(imp *importer.Importer, path string, load func(*importer.Importer, *csvFile) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+
+	file := &csvFile{
+		name:    path,
+		reader:  reader,
+		columns: make(map[string]int),
+		line:    1,
+	}
+
+	for i, column := range header {
+		file.columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	err = load(imp, file)
+	if err != nil {
+		return err
+	}
+
+	return imp.Flush()
+}
+
+func (f *csvFile) require(columns ...string) error {
+	for _, column := range columns {
+		if _, ok := f.columns[column]; !ok {
+			return fmt.Errorf("%s: missing required column %q", f.name, column)
+		}
+	}
+
+	return nil
+}
+
+func (f *csvFile) next() ([]string, error) {
+	record, err := f.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	f.line, _ = f.reader.FieldPos(0)
+
+	return record, nil
+}
+
+func (f *csvFile) get(record []string, column string) string {
+	i, ok := f.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+func (f *csvFile) getInt(record []string, column string, parseErrors map[string]string) int32 {
+	s := f.get(record, column)
+	if s == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		parseErrors[column] = "must be an integer value"
+	}
+
+	return int32(i)
+}
+
+func (f *csvFile) each(fn func(record []string) error) error {
+	for {
+		record, err := f.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+
+		err = fn(record)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func loadRikishis(imp *importer.Importer, f *csvFile) error {
+	err := f.require("shikona", "highest_rank", "heya")
+	if err != nil {
+		return err
+	}
+
+	return f.each(func(record []string) error {
+		rikishi := &data.Rikishi{
+			Shikona:      f.get(record, "shikona"),
+			HighestRank:  f.get(record, "highest_rank"),
+			Heya:         f.get(record, "heya"),
+			ShikonaKanji: f.get(record, "shikona_kanji"),
+			ShikonaKana:  f.get(record, "shikona_kana"),
+			HeyaKanji:    f.get(record, "heya_kanji"),
+			HeyaKana:     f.get(record, "heya_kana"),
+		}
+
+		for _, shikona := range strings.Split(f.get(record, "shikona_history"), ";") {
+			if shikona = strings.TrimSpace(shikona); shikona != "" {
+				rikishi.ShikonaHistory = append(rikishi.ShikonaHistory, shikona)
+			}
+		}
+
+		return imp.Rikishi(f.name, f.line, rikishi)
+	})
+}
+
+func loadTournamentsResults(imp *importer.Importer, f *csvFile) error {
+	err := f.require("tournament", "rikishi", "rank", "wins", "losses")
+	if err != nil {
+		return err
+	}
+
+	return f.each(func(record []string) error {
+		parseErrors := make(map[string]string)
+
+		tr := &data.TournamentResult{
+			Tournament: f.get(record, "tournament"),
+			Rikishi:    f.get(record, "rikishi"),
+			Rank:       f.get(record, "rank"),
+			Wins:       f.getInt(record, "wins", parseErrors),
+			Losses:     f.getInt(record, "losses", parseErrors),
+			Absent:     f.getInt(record, "absent", parseErrors),
+		}
+
+		if len(parseErrors) > 0 {
+			imp.Reject(f.name, f.line, parseErrors)
+			return nil
+		}
+
+		return imp.TournamentResult(f.name, f.line, tr)
+	})
+}
+
+func loadBouts(imp *importer.Importer, f *csvFile) error {
+	err := f.require("tournament", "day", "winner", "loser")
+	if err != nil {
+		return err
+	}
+
+	return f.each(func(record []string) error {
+		bout := &data.Bout{
+			Tournament: f.get(record, "tournament"),
+			Day:        f.get(record, "day"),
+			Division:   f.get(record, "division"),
+			Winner:     f.get(record, "winner"),
+			Loser:      f.get(record, "loser"),
+			Kimarite:   f.get(record, "kimarite"),
+		}
+
+		return imp.Bout(f.name, f.line, bout)
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/migrate"
+	"github.com/corsairconstantine/sumodb/migrations"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+const usage = `Usage: sumodb <command> [flags] [arguments]
+
+Commands:
+  migrate up          apply all pending migrations
+  migrate down [N]    revert the last N migrations (default 1)
+  migrate status      list migrations and the current schema version
+  migrate goto N      migrate up or down to version N
+  migrate force N     set the schema version to N and clear the dirty flag
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "migrate" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	err := runMigrate(os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	driver := fs.String("db-driver", "postgres", "Database driver (postgres|sqlite)")
+	dsn := fs.String("db-dsn", os.Getenv("SUMODB_DSN"), "PostgreSQL DSN or SQLite database file")
+	timeout := fs.Duration("timeout", 5*time.Minute, "Maximum time to spend migrating")
+
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if *driver == "sqlite" {
+		*dsn = data.SQLiteDSN(*dsn)
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := migrate.New(db, *driver, migrations.For(*driver))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+
+	switch command {
+	case "up":
+		err = m.Up(ctx)
+	case "down":
+		steps := 1
+		if len(rest) > 0 {
+			steps, err = parseNumber(rest[0])
+			if err != nil {
+				return err
+			}
+		}
+		err = m.Down(ctx, steps)
+	case "goto", "force":
+		if len(rest) != 1 {
+			return fmt.Errorf("%s requires a version", command)
+		}
+
+		var version int
+
+		version, err = parseNumber(rest[0])
+		if err != nil {
+			return err
+		}
+
+		if command == "goto" {
+			err = m.Goto(ctx, version)
+		} else {
+			err = m.Force(ctx, version)
+		}
+	case "status":
+		return printStatus(ctx, m)
+	default:
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("schema version", version)
+
+	return nil
+}
+
+func printStatus(ctx context.Context, m *migrate.Migrator) error {
+	statuses, version, dirty, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	for _, s := range statuses {
+		state := "pending"
+		switch {
+		case s.Version == version && dirty:
+			state = "dirty"
+		case s.Applied:
+			state = "applied"
+		}
+
+		fmt.Fprintf(tw, "%06d\t%s\t%s\n", s.Version, s.Name, state)
+	}
+
+	tw.Flush()
+
+	fmt.Printf("\nschema version %d (latest %d)", version, m.Latest())
+	if dirty {
+		fmt.Print(", dirty")
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func parseNumber(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+
+	return n, nil
+}
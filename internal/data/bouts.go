@@ -4,59 +4,104 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/corsairconstantine/sumodb/internal/validator"
 	"github.com/lib/pq"
 )
 
+var Divisions = []string{"Makuuchi", "Juryo", "Makushita", "Sandanme", "Jonidan", "Jonokuchi"}
+
+const (
+	tournamentDateExpression = `to_date(split_part(tournament, ' ', 1) || ' ' || right(tournament, 3), 'YYYY Mon')`
+	dayNumberExpression      = `CASE WHEN day = 'Playoff' THEN 16 ELSE day::integer END`
+)
+
 type Bout struct {
-	ID         int64
-	Tournament string
-	Day        string
-	Winner     string
-	Loser      string
-	Kimarite   string
-	Version    int32
+	ID         int64  `json:"id"`
+	Tournament string `json:"tournament"`
+	Day        string `json:"day"`
+	Division   string `json:"division"`
+	Winner     string `json:"winner"`
+	Loser      string `json:"loser"`
+	Kimarite   string `json:"kimarite"`
+	Version    int32  `json:"version"`
 }
 
 type BoutModel struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts Timeouts
 }
 
-func (b BoutModel) Insert(bout *Bout) error {
-	query := `
-		INSERT INTO bouts (tournament, day, winner, loser, kimarite)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, version`
+const insertBoutQuery = `
+	INSERT INTO bouts (tournament, day, division, winner, loser, kimarite)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, version`
 
-	args := []interface{}{bout.Tournament, bout.Day, bout.Winner, bout.Loser, bout.Kimarite}
+func (b BoutModel) Insert(ctx context.Context, bout *Bout) error {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		return b.insert(ctx, tx, bout)
+	})
+}
+
+func (b BoutModel) InsertTx(ctx context.Context, tx *sql.Tx, bout *Bout) error {
+	args := []interface{}{bout.Tournament, bout.Day, bout.Division, bout.Winner, bout.Loser, bout.Kimarite}
+
+	return tx.QueryRowContext(ctx, insertBoutQuery, args...).Scan(&bout.ID, &bout.Version)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (b BoutModel) InsertBatch(ctx context.Context, bouts []*Bout) error {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Batch)
 	defer cancel()
 
-	return b.DB.QueryRowContext(ctx, query, args...).Scan(&bout.ID, &bout.Version)
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		for _, bout := range bouts {
+			err := b.insert(ctx, tx, bout)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// insert adds bout and queues its webhook deliveries in tx.
+func (b BoutModel) insert(ctx context.Context, tx *sql.Tx, bout *Bout) error {
+	err := b.InsertTx(ctx, tx, bout)
+	if err != nil {
+		return err
+	}
+
+	return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventBoutCreated, bout)
 }
 
-func (b BoutModel) Get(id int64) (*Bout, error) {
+func (b BoutModel) Get(ctx context.Context, id int64) (*Bout, error) {
 	if id < 1 {
 		return nil, ErrRecordNotFound
 	}
 
 	query := `
-		SELECT id, tournament, day, winner, loser, kimarite, version
+		SELECT id, tournament, day, division, winner, loser, kimarite, version
 		FROM bouts
 		WHERE id = $1`
 
 	var bout Bout
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
 	defer cancel()
 
 	err := b.DB.QueryRowContext(ctx, query, id).Scan(
 		&bout.ID,
 		&bout.Tournament,
 		&bout.Day,
+		&bout.Division,
 		&bout.Winner,
 		&bout.Loser,
 		&bout.Kimarite,
@@ -75,22 +120,145 @@ func (b BoutModel) Get(id int64) (*Bout, error) {
 	return &bout, nil
 }
 
-func (b BoutModel) GetAll(tournament, day, kimarite string, rikishi1, rikishi2 []string) ([]*Bout, error) {
-	query := `
-		SELECT id, tournament, day, winner, loser, kimarite, version
-		FROM bouts
-		WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
-		AND (day = $2 OR $2 = '')
-		AND (kimarite = $3 OR $3 = '')
-		AND (winner = ANY($4) OR loser = ANY($4) OR $4 = '{}')
-		AND (winner = ANY($5) OR loser = ANY($5) OR $5 = '{}')`
-
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (b BoutModel) GetAll(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) ([]*Bout, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
 	defer cancel()
 
-	args := []interface{}{tournament, day, kimarite, pq.Array(rikishi1), pq.Array(rikishi2)}
+	totalRecords := 0
+	bouts := []*Bout{}
+	keys := [][2]string{}
+
+	err := b.scan(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, func(total int, sortKey string, bout *Bout) error {
+		totalRecords = total
+		bouts = append(bouts, bout)
+		keys = append(keys, [2]string{sortKey, strconv.FormatInt(bout.ID, 10)})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	bouts, metadata := paginate(filters, bouts, keys, totalRecords)
+
+	return bouts, metadata, nil
+}
+
+func (b BoutModel) Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(*Bout) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := b.scan(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, func(_ int, _ string, bout *Bout) error {
+		return streamer.add(bout)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (b BoutModel) scan(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(totalRecords int, sortKey string, bout *Bout) error) error {
+	sortColumn := boutSortExpression(filters.SortColumn())
+
+	keyset, keysetArgs := filters.keysetCondition(sortColumn, "id", 13)
+
+	query := fmt.Sprintf(`
+		SELECT total, sort_key, id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT %s AS total, (%s)::text AS sort_key, id, tournament, day, division, winner, loser, kimarite, version
+			FROM bouts
+			WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
+			AND (day = $2 OR $2 = '')
+			AND (LOWER(division) = LOWER($3) OR $3 = '')
+			AND (kimarite = $4 OR $4 = '')
+			AND (winner = ANY($5) OR loser = ANY($5) OR $5 = '{}')
+			AND (winner = ANY($6) OR loser = ANY($6) OR $6 = '{}')
+			AND (winner = ANY($7) OR $7 = '{}')
+			AND (loser = ANY($8) OR $8 = '{}')
+			AND ($9::date IS NULL OR %s >= $9)
+			AND ($10::date IS NULL OR %s <= $10)
+		) AS bouts
+		WHERE %s
+		ORDER BY %s
+		LIMIT $11 OFFSET $12`,
+		filters.totalColumn(), sortColumn, tournamentDateExpression, tournamentDateExpression, keyset, filters.orderBy(sortColumn, "id"))
+
+	args := []interface{}{
+		tournament,
+		day,
+		division,
+		kimarite,
+		pq.Array(rikishi1),
+		pq.Array(rikishi2),
+		pq.Array(winner),
+		pq.Array(loser),
+		tournamentDate(from),
+		tournamentDate(to),
+		filters.limit(),
+		filters.offset(),
+	}
+	args = append(args, keysetArgs...)
 
 	rows, err := b.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bout Bout
+		var totalRecords int
+		var sortKey string
+
+		err := rows.Scan(
+			&totalRecords,
+			&sortKey,
+			&bout.ID,
+			&bout.Tournament,
+			&bout.Day,
+			&bout.Division,
+			&bout.Winner,
+			&bout.Loser,
+			&bout.Kimarite,
+			&bout.Version,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		err = fn(totalRecords, sortKey, &bout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetForTournaments returns up to limit bouts of each tournament, ordered by
+// id, in a single query.
+func (b BoutModel) GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) ([]*Bout, error) {
+	if len(tournaments) == 0 {
+		return []*Bout{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT id, tournament, day, division, winner, loser, kimarite, version,
+				row_number() OVER (PARTITION BY LOWER(tournament) ORDER BY id) AS n
+			FROM bouts
+			WHERE LOWER(tournament) = ANY($1)
+			AND (day = $2 OR $2 = '')
+			AND (LOWER(division) = LOWER($3) OR $3 = '')
+		) AS bouts
+		WHERE n <= $4
+		ORDER BY id`
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	rows, err := b.DB.QueryContext(ctx, query, pq.Array(lowerAll(tournaments)), day, division, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +273,12 @@ func (b BoutModel) GetAll(tournament, day, kimarite string, rikishi1, rikishi2 [
 			&bout.ID,
 			&bout.Tournament,
 			&bout.Day,
+			&bout.Division,
 			&bout.Winner,
 			&bout.Loser,
 			&bout.Kimarite,
 			&bout.Version,
 		)
-
 		if err != nil {
 			return nil, err
 		}
@@ -125,16 +293,96 @@ func (b BoutModel) GetAll(tournament, day, kimarite string, rikishi1, rikishi2 [
 	return bouts, nil
 }
 
-func (b BoutModel) Update(bout *Bout) error {
+// GetForRikishis returns up to limit bouts for each group, newest tournament
+// first, in a single query. The result holds the bouts of groups[i] at index i.
+func (b BoutModel) GetForRikishis(ctx context.Context, groups []BoutGroup, limit int) ([][]*Bout, error) {
+	indexes, shikonas, tournaments := boutGroupRows(groups)
+
+	query := fmt.Sprintf(`
+		SELECT grp, id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT grp, id, tournament, day, division, winner, loser, kimarite, version,
+				row_number() OVER (PARTITION BY grp ORDER BY %s DESC, id) AS n
+			FROM (
+				SELECT DISTINCT g.grp, b.id, b.tournament, b.day, b.division, b.winner, b.loser, b.kimarite, b.version
+				FROM unnest($1::bigint[], $2::text[], $3::text[]) AS g(grp, shikona, only_tournament)
+				JOIN bouts AS b ON (b.winner = g.shikona OR b.loser = g.shikona)
+				AND (LOWER(b.tournament) = LOWER(g.only_tournament) OR g.only_tournament = '')
+			) AS matches
+		) AS bouts
+		WHERE n <= $4
+		ORDER BY grp, n`, tournamentDateExpression)
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	rows, err := b.DB.QueryContext(ctx, query, pq.Array(indexes), pq.Array(shikonas), pq.Array(tournaments), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bouts := make([][]*Bout, len(groups))
+
+	for rows.Next() {
+		var bout Bout
+		var group int
+
+		err := rows.Scan(
+			&group,
+			&bout.ID,
+			&bout.Tournament,
+			&bout.Day,
+			&bout.Division,
+			&bout.Winner,
+			&bout.Loser,
+			&bout.Kimarite,
+			&bout.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		bouts[group] = append(bouts[group], &bout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bouts, nil
+}
+
+func (b BoutModel) IsDuplicate(ctx context.Context, bout *Bout) (bool, error) {
+	query := `
+		SELECT exists (
+			SELECT true FROM bouts
+			WHERE tournament = $1 AND day = $2
+			AND ((winner = $3 AND loser = $4) OR (winner = $4 AND loser = $3))
+			AND id <> $5
+		)`
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	var exists bool
+
+	err := b.DB.QueryRowContext(ctx, query, bout.Tournament, bout.Day, bout.Winner, bout.Loser, bout.ID).Scan(&exists)
+
+	return exists, err
+}
+
+func (b BoutModel) Update(ctx context.Context, bout *Bout) error {
 	query := `
 		UPDATE bouts
-		SET tournament = $1, day = $2, winner = $3, loser = $4, kimarite = $5, version = version + 1
-		WHERE id = $6 AND version = $7
+		SET tournament = $1, day = $2, division = $3, winner = $4, loser = $5, kimarite = $6, version = version + 1
+		WHERE id = $7 AND version = $8
 		RETURNING version`
 
 	args := []interface{}{
 		bout.Tournament,
 		bout.Day,
+		bout.Division,
 		bout.Winner,
 		bout.Loser,
 		bout.Kimarite,
@@ -142,60 +390,138 @@ func (b BoutModel) Update(bout *Bout) error {
 		bout.Version,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
 	defer cancel()
 
-	err := b.DB.QueryRowContext(ctx, query, args...).Scan(&bout.Version)
-	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return ErrEditConflict
-		default:
-			return err
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, args...).Scan(&bout.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrEditConflict
+			default:
+				return err
+			}
 		}
-	}
 
-	return nil
+		return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventBoutUpdated, bout)
+	})
 }
 
-func (b BoutModel) Delete(id int64) error {
+func (b BoutModel) Delete(ctx context.Context, id int64, version int32) error {
 	if id < 1 {
 		return ErrRecordNotFound
 	}
 
-	query := `DELETE FROM bouts WHERE id = $1`
+	// The deleted row is returned for the webhook payload.
+	query := `
+		DELETE FROM bouts
+		WHERE id = $1 AND version = $2
+		RETURNING id, tournament, day, division, winner, loser, kimarite, version`
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
 	defer cancel()
 
-	result, err := b.DB.ExecContext(ctx, query, id)
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		var deleted Bout
+
+		err := tx.QueryRowContext(ctx, query, id, version).Scan(
+			&deleted.ID,
+			&deleted.Tournament,
+			&deleted.Day,
+			&deleted.Division,
+			&deleted.Winner,
+			&deleted.Loser,
+			&deleted.Kimarite,
+			&deleted.Version,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return missedDelete(ctx, tx, "bouts", "id", id)
+			default:
+				return err
+			}
+		}
+
+		return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventBoutDeleted, &deleted)
+	})
+}
+
+// ValidateBout records the problems with b in v. It returns an error only when
+// rm fails to look up the rikishis.
+func ValidateBout(ctx context.Context, v *validator.Validator, b *Bout, rm ShikonaChecker) error {
+	v.Check(validator.ValidTournament(b.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+
+	v.Check(validator.ValidDay(b.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+
+	v.Check(b.Division == "" || validator.In(b.Division, Divisions...), "division", "must be one of "+strings.Join(Divisions, ", "))
+
+	v.Check(b.Winner != "", "winner", "must be provided")
+	v.Check(len(b.Winner) <= 500, "winner", "must not be more than 500 bytes long")
+
+	winnerExists, err := rm.Exists(ctx, b.Winner)
 	if err != nil {
 		return err
 	}
+	v.Check(winnerExists, "winner", "must exist in the database")
+
+	v.Check(b.Loser != "", "loser", "must be provided")
+	v.Check(len(b.Loser) <= 500, "loser", "must not be more than 500 bytes long")
 
-	rowsAffected, err := result.RowsAffected()
+	loserExists, err := rm.Exists(ctx, b.Loser)
 	if err != nil {
 		return err
 	}
+	v.Check(loserExists, "loser", "must exist in the database")
+
+	v.Check(len(b.Kimarite) <= 500, "kimarite", "must not be more than 500 bytes long")
 
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
-	}
 	return nil
 }
 
-func ValidateBout(v *validator.Validator, b *Bout, rm RikishiModel) {
-	v.Check(validator.ValidTournament(b.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
+func boutSortExpression(column string) string {
+	switch column {
+	case "tournament":
+		return tournamentDateExpression
+	case "day":
+		return dayNumberExpression
+	default:
+		return column
+	}
+}
 
-	v.Check(validator.ValidDay(b.Day), "day", "must be a number from 1 to 15. Alternatively can be 'Playoff'")
+// BoutGroup selects the bouts fought under any of Shikonas, only those of
+// Tournament when it is set.
+type BoutGroup struct {
+	Shikonas   []string
+	Tournament string
+}
 
-	v.Check(b.Winner != "", "winner", "must be provided")
-	v.Check(len(b.Winner) <= 500, "winner", "must not be more than 500 bytes long")
-	v.Check(rm.Exists(b.Winner), "winner", "must exist in the database")
+// boutGroupRows flattens groups into one row per shikona, for the database
+// stores to join bouts against.
+func boutGroupRows(groups []BoutGroup) (indexes []int64, shikonas, tournaments []string) {
+	for i, group := range groups {
+		for _, shikona := range group.Shikonas {
+			indexes = append(indexes, int64(i))
+			shikonas = append(shikonas, shikona)
+			tournaments = append(tournaments, group.Tournament)
+		}
+	}
 
-	v.Check(b.Loser != "", "loser", "must be provided")
-	v.Check(len(b.Loser) <= 500, "loser", "must not be more than 500 bytes long")
-	v.Check(rm.Exists(b.Loser), "loser", "must exist in the database")
+	return indexes, shikonas, tournaments
+}
 
-	v.Check(len(b.Kimarite) <= 500, "kimarite", "must not be more than 500 bytes long")
+func tournamentDate(tournament string) interface{} {
+	fields := strings.Fields(tournament)
+	if len(fields) < 2 {
+		return nil
+	}
+
+	date, err := time.Parse("2006 Jan", fields[0]+" "+fields[len(fields)-1])
+	if err != nil {
+		return nil
+	}
+
+	return date
 }
@@ -0,0 +1,1285 @@
+package data_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/migrate"
+	"github.com/corsairconstantine/sumodb/migrations"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+type backend struct {
+	name      string
+	newModels func(t *testing.T) data.Models
+}
+
+func backends() []backend {
+	backends := []backend{
+		{"memory", func(t *testing.T) data.Models {
+			return data.NewMemoryModels()
+		}},
+		{"sqlite", func(t *testing.T) data.Models {
+			db := openTestDB(t, "sqlite", data.SQLiteDSN(filepath.Join(t.TempDir(), "sumodb.db")))
+			return data.NewSQLiteModels(db, data.DefaultTimeouts)
+		}},
+	}
+
+	if dsn := os.Getenv("SUMODB_TEST_DSN"); dsn != "" {
+		backends = append(backends, backend{"postgres", func(t *testing.T) data.Models {
+			db := openTestDB(t, "postgres", dsn)
+
+			_, err := db.Exec(`TRUNCATE rate_limits, webhook_deliveries, webhooks, bouts, tournaments_results, users, rikishis RESTART IDENTITY`)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return data.NewModels(db, data.DefaultTimeouts)
+		}})
+	}
+
+	return backends
+}
+
+func openTestDB(t *testing.T, driver, dsn string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrate.New(db, driver, migrations.For(driver))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Up(context.Background())
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestConformance(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, models data.Models)
+	}{
+		{"Rikishis", testRikishis},
+		{"RikishisList", testRikishisList},
+		{"TournamentsResults", testTournamentsResults},
+		{"Bouts", testBouts},
+		{"Users", testUsers},
+		{"Search", testSearch},
+		{"Webhooks", testWebhooks},
+		{"RateLimits", testRateLimits},
+		{"Imports", testImports},
+	}
+
+	for _, b := range backends() {
+		t.Run(b.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.fn(t, b.newModels(t))
+				})
+			}
+		})
+	}
+}
+
+func TestQueryCancellation(t *testing.T) {
+	db := openTestDB(t, "sqlite", data.SQLiteDSN(filepath.Join(t.TempDir(), "sumodb.db")))
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := data.NewSQLiteModels(db, data.DefaultTimeouts).Bouts.Get(ctx, 1)
+		if !data.IsCanceled(err) {
+			t.Errorf("got %v; want a canceled query", err)
+		}
+	})
+
+	t.Run("Configured timeout", func(t *testing.T) {
+		timeouts := data.DefaultTimeouts
+		timeouts.Read = time.Nanosecond
+
+		_, err := data.NewSQLiteModels(db, timeouts).Bouts.Get(t.Context(), 1)
+		if !data.IsCanceled(err) {
+			t.Errorf("got %v; want a canceled query", err)
+		}
+
+		_, err = data.NewSQLiteModels(db, data.Timeouts{}).Bouts.Get(t.Context(), 1)
+		if !errors.Is(err, data.ErrRecordNotFound) {
+			t.Errorf("got %v with no timeout; want %v", err, data.ErrRecordNotFound)
+		}
+	})
+
+	t.Run("Interrupted query", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		var n int
+		err := db.QueryRowContext(ctx, `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT count(*) FROM c`).Scan(&n)
+		if !data.IsCanceled(err) {
+			t.Errorf("got %v; want a canceled query", err)
+		}
+	})
+
+	if data.IsCanceled(errors.New("connection refused")) {
+		t.Error("got a canceled query for an unrelated error")
+	}
+}
+
+func filters(sort string, safelist ...string) data.Filters {
+	return data.Filters{Page: 1, PageSize: 20, Sort: sort, SortSafelist: safelist, IncludeTotal: true}
+}
+
+func mustInsertRikishis(t *testing.T, models data.Models, rikishis ...*data.Rikishi) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	for _, rikishi := range rikishis {
+		if rikishi.ShikonaHistory == nil {
+			rikishi.ShikonaHistory = []string{rikishi.Shikona}
+		}
+
+		err := models.Rikishis.Insert(ctx, rikishi)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testRikishis(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	rikishi := &data.Rikishi{Shikona: "Kiribayama", HighestRank: "Sekiwake", Heya: "Michinoku"}
+	mustInsertRikishis(t, models, rikishi)
+
+	if rikishi.Version != 1 {
+		t.Errorf("got version %d after insert; want 1", rikishi.Version)
+	}
+
+	for shikona, want := range map[string]bool{"Kiribayama": true, "Kirishima": false} {
+		exists, err := models.Rikishis.Exists(ctx, shikona)
+		if err != nil || exists != want {
+			t.Errorf("got %v, %v for %s; want %v", exists, err, shikona, want)
+		}
+	}
+
+	_, err := models.Rikishis.Get(ctx, "Kirishima")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v for a missing rikishi; want ErrRecordNotFound", err)
+	}
+
+	stale, err := models.Rikishis.Get(ctx, "Kiribayama")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rikishi.Shikona = "Kirishima"
+	rikishi.HighestRank = "Ozeki"
+	rikishi.ShikonaKanji = "霧島"
+	rikishi.ShikonaKana = "きりしま"
+
+	err = models.Rikishis.Update(ctx, rikishi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := models.Rikishis.Get(ctx, "Kirishima")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &data.Rikishi{Shikona: "Kirishima", HighestRank: "Ozeki", Heya: "Michinoku", ShikonaHistory: []string{"Kiribayama", "Kirishima"}, ShikonaKanji: "霧島", ShikonaKana: "きりしま", Version: 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+
+	stale.Heya = "Otowayama"
+
+	err = models.Rikishis.Update(ctx, stale)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	err = models.Rikishis.Delete(ctx, "Kirishima", stale.Version)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale delete; want ErrEditConflict", err)
+	}
+
+	err = models.Rikishis.Delete(ctx, "Kirishima", got.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.Rikishis.Delete(ctx, "Kirishima", got.Version)
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v deleting twice; want ErrRecordNotFound", err)
+	}
+
+	mustInsertRikishis(t, models, &data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama"})
+
+	err = models.TournamentsResults.Insert(ctx, &data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Abi", Rank: "Maegashira 9", Wins: 12, Losses: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.Rikishis.Delete(ctx, "Abi", 1)
+	if err == nil || errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v deleting a referenced rikishi; want a constraint error", err)
+	}
+}
+
+func testRikishisList(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	mustInsertRikishis(t, models,
+		&data.Rikishi{Shikona: "Hakuho", HighestRank: "Yokozuna", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Enho", HighestRank: "Maegashira", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Hokuseiho", HighestRank: "Maegashira", Heya: "Miyagino"},
+		&data.Rikishi{Shikona: "Asanoyama", HighestRank: "Ozeki", Heya: "Takasago"},
+		&data.Rikishi{Shikona: "Kisenosato", HighestRank: "Yokozuna", Heya: "Tagonoura", ShikonaHistory: []string{"Hagiwara", "Kisenosato"}},
+	)
+
+	safelist := []string{"shikona", "highest_rank", "heya", "-shikona", "-highest_rank", "-heya"}
+
+	shikonas := func(rikishis []*data.Rikishi) []string {
+		names := []string{}
+		for _, rikishi := range rikishis {
+			names = append(names, rikishi.Shikona)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name        string
+		shikona     string
+		highestRank string
+		heya        string
+		sort        string
+		want        []string
+	}{
+		{"All", "", "", "", "shikona", []string{"Asanoyama", "Enho", "Hakuho", "Hokuseiho", "Kisenosato"}},
+		{"Historical shikona", "hagiwara", "", "", "shikona", []string{"Kisenosato"}},
+		{"Search misses partial words", "Haku", "", "", "shikona", []string{}},
+		{"Rank is case insensitive", "", "yokozuna", "", "-shikona", []string{"Kisenosato", "Hakuho"}},
+		{"Heya", "", "", "MIYAGINO", "shikona", []string{"Enho", "Hakuho", "Hokuseiho"}},
+		{"Sort by heya", "", "", "", "-heya", []string{"Asanoyama", "Kisenosato", "Enho", "Hakuho", "Hokuseiho"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rikishis, metadata, err := models.Rikishis.GetAll(ctx, tt.shikona, tt.highestRank, tt.heya, filters(tt.sort, safelist...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := shikonas(rikishis); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+
+			if metadata.TotalRecords != len(tt.want) {
+				t.Errorf("got %d total records; want %d", metadata.TotalRecords, len(tt.want))
+			}
+		})
+	}
+
+	t.Run("Cursor pagination", func(t *testing.T) {
+		f := filters("-shikona", safelist...)
+		f.PageSize = 2
+
+		var seen []string
+
+		for {
+			rikishis, metadata, err := models.Rikishis.GetAll(ctx, "", "", "", f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			seen = append(seen, shikonas(rikishis)...)
+
+			if metadata.NextCursor == "" {
+				f.Cursor = metadata.PrevCursor
+				break
+			}
+			f.Cursor = metadata.NextCursor
+		}
+
+		if want := []string{"Kisenosato", "Hokuseiho", "Hakuho", "Enho", "Asanoyama"}; !reflect.DeepEqual(seen, want) {
+			t.Fatalf("got %v; want %v", seen, want)
+		}
+
+		rikishis, _, err := models.Rikishis.GetAll(ctx, "", "", "", f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"Hakuho", "Enho"}; !reflect.DeepEqual(shikonas(rikishis), want) {
+			t.Errorf("got %v going backwards; want %v", shikonas(rikishis), want)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		f := filters("shikona", safelist...)
+		f.PageSize = 1
+		f.Unlimited = true
+
+		var streamed []string
+
+		err := models.Rikishis.Stream(ctx, "", "", "Miyagino", f, func(rikishi *data.Rikishi) error {
+			streamed = append(streamed, rikishi.Shikona)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"Enho", "Hakuho", "Hokuseiho"}; !reflect.DeepEqual(streamed, want) {
+			t.Errorf("got %v; want %v", streamed, want)
+		}
+	})
+
+	t.Run("Stream pages", func(t *testing.T) {
+		stream := func(f data.Filters) []string {
+			t.Helper()
+
+			streamed := []string{}
+
+			err := models.Rikishis.Stream(ctx, "", "", "", f, func(rikishi *data.Rikishi) error {
+				streamed = append(streamed, rikishi.Shikona)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return streamed
+		}
+
+		f := filters("shikona", safelist...)
+		f.PageSize = 2
+
+		_, metadata, err := models.Rikishis.GetAll(ctx, "", "", "", f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.Cursor = metadata.NextCursor
+
+		_, metadata, err = models.Rikishis.GetAll(ctx, "", "", "", f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.Cursor = metadata.PrevCursor
+
+		if got, want := stream(f), []string{"Asanoyama", "Enho"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v for a backward page; want %v", got, want)
+		}
+
+		f.PageSize = 1
+
+		if got, want := stream(f), []string{"Enho"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v for a short backward page; want %v", got, want)
+		}
+
+		f.Unlimited = true
+
+		if got, want := stream(f), []string{"Asanoyama", "Enho"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v for an unlimited backward stream; want %v", got, want)
+		}
+
+		f.Cursor = ""
+		f.Page = 3
+
+		if got, want := stream(f), []string{"Asanoyama", "Enho", "Hakuho", "Hokuseiho", "Kisenosato"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v for an unlimited stream past page 1; want %v", got, want)
+		}
+	})
+
+	t.Run("Lookups", func(t *testing.T) {
+		history, err := models.Rikishis.GetShikonaHistory(ctx, "Kisenosato")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"Hagiwara", "Kisenosato"}; !reflect.DeepEqual(history, want) {
+			t.Errorf("got history %v; want %v", history, want)
+		}
+
+		byShikona, err := models.Rikishis.GetByShikonas(ctx, []string{"Hagiwara", "Enho", "Takakeisho"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(byShikona) != 3 || byShikona["Hagiwara"].Shikona != "Kisenosato" || byShikona["Enho"].Heya != "Miyagino" {
+			t.Errorf("got %v", byShikona)
+		}
+
+		existing, err := models.Rikishis.ExistingShikonas(ctx, []string{"Hakuho", "Hagiwara", "Takakeisho"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := (data.ShikonaSet{"Hakuho": true}); !reflect.DeepEqual(existing, want) {
+			t.Errorf("got %v; want %v", existing, want)
+		}
+
+		aliases, err := models.Rikishis.Aliases(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if aliases["Hagiwara"] != "Kisenosato" || aliases["Enho"] != "Enho" || len(aliases) != 6 {
+			t.Errorf("got aliases %v", aliases)
+		}
+
+		forHeyas, err := models.Rikishis.GetForHeyas(ctx, []string{"miyagino", "Takasago", "Kokonoe"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"Asanoyama", "Enho", "Hakuho"}; !reflect.DeepEqual(shikonas(forHeyas), want) {
+			t.Errorf("got %v for heyas; want %v", shikonas(forHeyas), want)
+		}
+	})
+}
+
+func testTournamentsResults(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	mustInsertRikishis(t, models,
+		&data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+	)
+
+	trs := []*data.TournamentResult{
+		{Tournament: "2022 Nov", Rikishi: "Abi", Rank: "Maegashira 9", Wins: 12, Losses: 3},
+		{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 12, Losses: 3},
+		{Tournament: "2022 Sep", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 8, Losses: 7},
+		{Tournament: "2022 Sep", Rikishi: "Abi", Rank: "Maegashira 4", Wins: 4, Losses: 11},
+	}
+
+	err := models.TournamentsResults.InsertBatch(ctx, trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tr := range trs {
+		if tr.ID != int64(i+1) || tr.Version != 1 {
+			t.Errorf("got id %d version %d for row %d", tr.ID, tr.Version, i)
+		}
+	}
+
+	safelist := []string{"id", "tournament", "rikishi", "rank", "wins", "-id", "-tournament", "-rikishi", "-rank", "-wins"}
+
+	ids := func(trs []*data.TournamentResult) []int64 {
+		ids := []int64{}
+		for _, tr := range trs {
+			ids = append(ids, tr.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		tournament string
+		rank       string
+		wins       int
+		shikonas   []string
+		sort       string
+		want       []int64
+	}{
+		{"All", "", "", 0, nil, "id", []int64{1, 2, 3, 4}},
+		{"Tournament", "2022 sep", "", 0, nil, "-id", []int64{4, 3}},
+		{"Rank", "", "ozeki", 0, nil, "id", []int64{2, 3}},
+		{"Wins", "", "", 8, nil, "-wins", []int64{1, 2, 3}},
+		{"Rikishi", "", "", 0, []string{"Abi"}, "wins", []int64{4, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := models.TournamentsResults.GetAll(ctx, tt.tournament, tt.rank, tt.wins, tt.shikonas, filters(tt.sort, safelist...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("got %v; want %v", ids(got), tt.want)
+			}
+		})
+	}
+
+	t.Run("Cursor pagination by wins", func(t *testing.T) {
+		f := filters("wins", safelist...)
+		f.PageSize = 3
+
+		page, metadata, err := models.TournamentsResults.GetAll(ctx, "", "", 0, nil, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.Cursor = metadata.NextCursor
+
+		next, _, err := models.TournamentsResults.GetAll(ctx, "", "", 0, nil, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := append(ids(page), ids(next)...); !reflect.DeepEqual(got, []int64{4, 3, 1, 2}) {
+			t.Errorf("got %v; want [4 3 1 2]", got)
+		}
+	})
+
+	forRikishis, err := models.TournamentsResults.GetForRikishis(ctx, []string{"2022 Sep"}, []string{"Abi", "Takakeisho"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids(forRikishis), []int64{3, 4}) {
+		t.Errorf("got %v for rikishis; want [3 4]", ids(forRikishis))
+	}
+
+	forTournaments, err := models.TournamentsResults.GetForTournaments(ctx, []string{"2022 nov", "2022 Sep", "2023 Jan"}, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids(forTournaments), []int64{1, 3}) {
+		t.Errorf("got %v for tournaments; want [1 3]", ids(forTournaments))
+	}
+
+	forTournaments, err = models.TournamentsResults.GetForTournaments(ctx, []string{"2022 Nov", "2022 Sep"}, "ozeki", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids(forTournaments), []int64{2, 3}) {
+		t.Errorf("got %v for tournaments by rank; want [2 3]", ids(forTournaments))
+	}
+
+	duplicate, err := models.TournamentsResults.IsDuplicate(ctx, &data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Abi"})
+	if err != nil || !duplicate {
+		t.Errorf("got %v, %v; want a duplicate", duplicate, err)
+	}
+
+	duplicate, err = models.TournamentsResults.IsDuplicate(ctx, trs[0])
+	if err != nil || duplicate {
+		t.Errorf("got %v, %v; a row must not duplicate itself", duplicate, err)
+	}
+
+	stale := *trs[2]
+	trs[2].Wins, trs[2].Losses = 9, 6
+
+	err = models.TournamentsResults.Update(ctx, trs[2])
+	if err != nil || trs[2].Version != 2 {
+		t.Fatalf("got %v, version %d", err, trs[2].Version)
+	}
+
+	err = models.TournamentsResults.Update(ctx, &stale)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	err = models.TournamentsResults.Delete(ctx, 3, stale.Version)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale delete; want ErrEditConflict", err)
+	}
+
+	err = models.TournamentsResults.Delete(ctx, 3, trs[2].Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.TournamentsResults.Delete(ctx, 3, trs[2].Version)
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v deleting twice; want ErrRecordNotFound", err)
+	}
+
+	_, err = models.TournamentsResults.Get(ctx, 3)
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v after delete; want ErrRecordNotFound", err)
+	}
+}
+
+func testBouts(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	mustInsertRikishis(t, models,
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama"},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama"},
+	)
+
+	err := models.Bouts.InsertBatch(ctx, []*data.Bout{
+		{Tournament: "2022 Nov", Day: "15", Division: "Makuuchi", Winner: "Abi", Loser: "Takakeisho", Kimarite: "hikiotoshi"},
+		{Tournament: "2022 Nov", Day: "Playoff", Division: "Makuuchi", Winner: "Abi", Loser: "Takakeisho", Kimarite: "oshidashi"},
+		{Tournament: "2022 Nov", Day: "3", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Abi", Kimarite: "oshidashi"},
+		{Tournament: "2022 Sep", Day: "10", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Abi", Kimarite: "tsukiotoshi"},
+		{Tournament: "2021 Jan", Day: "1", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Takakeisho", Kimarite: "yorikiri"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	safelist := []string{"id", "tournament", "day", "winner", "loser", "kimarite", "-id", "-tournament", "-day", "-winner", "-loser", "-kimarite"}
+
+	ids := func(bouts []*data.Bout) []int64 {
+		ids := []int64{}
+		for _, bout := range bouts {
+			ids = append(ids, bout.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		tournament string
+		day        string
+		rikishi1   []string
+		rikishi2   []string
+		winner     []string
+		from, to   string
+		sort       string
+		want       []int64
+	}{
+		{"All", "", "", nil, nil, nil, "", "", "id", []int64{1, 2, 3, 4, 5}},
+		{"Tournament", "2022 NOV", "", nil, nil, nil, "", "", "-id", []int64{3, 2, 1}},
+		{"Day", "", "Playoff", nil, nil, nil, "", "", "id", []int64{2}},
+		{"Either rikishi", "", "", []string{"Terunofuji"}, nil, nil, "", "", "id", []int64{5}},
+		{"Head to head", "", "", []string{"Abi"}, []string{"Takakeisho"}, nil, "", "", "id", []int64{1, 2, 3, 4}},
+		{"Winner", "", "", nil, nil, []string{"Takakeisho"}, "", "", "id", []int64{3, 4}},
+		{"Date range", "", "", nil, nil, nil, "2022 Jan", "2022 Oct", "id", []int64{4}},
+		{"Sort by tournament date", "", "", nil, nil, nil, "", "", "-tournament", []int64{1, 2, 3, 4, 5}},
+		{"Playoff sorts after day 15", "2022 Nov", "", nil, nil, nil, "", "", "-day", []int64{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := models.Bouts.GetAll(ctx, tt.tournament, tt.day, "", "", tt.rikishi1, tt.rikishi2, tt.winner, nil, tt.from, tt.to, filters(tt.sort, safelist...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("got %v; want %v", ids(got), tt.want)
+			}
+		})
+	}
+
+	t.Run("Cursor pagination by day", func(t *testing.T) {
+		f := filters("day", safelist...)
+		f.PageSize = 2
+
+		var seen []int64
+
+		for {
+			bouts, metadata, err := models.Bouts.GetAll(ctx, "", "", "", "", nil, nil, nil, nil, "", "", f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			seen = append(seen, ids(bouts)...)
+
+			if metadata.NextCursor == "" {
+				break
+			}
+			f.Cursor = metadata.NextCursor
+			f.IncludeTotal = false
+		}
+
+		if want := []int64{5, 3, 4, 1, 2}; !reflect.DeepEqual(seen, want) {
+			t.Errorf("got %v; want %v", seen, want)
+		}
+	})
+
+	t.Run("Batched lookups", func(t *testing.T) {
+		forTournaments, err := models.Bouts.GetForTournaments(ctx, []string{"2022 nov", "2021 Jan"}, "", "makuuchi", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []int64{1, 2, 5}; !reflect.DeepEqual(ids(forTournaments), want) {
+			t.Errorf("got %v for tournaments; want %v", ids(forTournaments), want)
+		}
+
+		forTournaments, err = models.Bouts.GetForTournaments(ctx, []string{"2022 Nov"}, "Playoff", "", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []int64{2}; !reflect.DeepEqual(ids(forTournaments), want) {
+			t.Errorf("got %v for a day; want %v", ids(forTournaments), want)
+		}
+
+		forRikishis, err := models.Bouts.GetForRikishis(ctx, []data.BoutGroup{
+			{Shikonas: []string{"Takakeisho"}},
+			{Shikonas: []string{"Abi"}, Tournament: "2022 sep"},
+			{},
+			{Shikonas: []string{"Terunofuji", "Takakeisho"}, Tournament: "2021 Jan"},
+		}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := [][]int64{}
+		for _, bouts := range forRikishis {
+			got = append(got, ids(bouts))
+		}
+
+		if want := [][]int64{{1, 2}, {4}, {}, {5}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v for rikishis; want %v", got, want)
+		}
+	})
+
+	duplicate, err := models.Bouts.IsDuplicate(ctx, &data.Bout{Tournament: "2022 Sep", Day: "10", Winner: "Abi", Loser: "Takakeisho"})
+	if err != nil || !duplicate {
+		t.Errorf("got %v, %v; want the reversed pairing to be a duplicate", duplicate, err)
+	}
+
+	bout, err := models.Bouts.Get(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale := *bout
+	bout.Kimarite = "oshidashi"
+
+	err = models.Bouts.Update(ctx, bout)
+	if err != nil || bout.Version != 2 {
+		t.Fatalf("got %v, version %d", err, bout.Version)
+	}
+
+	err = models.Bouts.Update(ctx, &stale)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	err = models.Bouts.Delete(ctx, bout.ID, stale.Version)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale delete; want ErrEditConflict", err)
+	}
+
+	err = models.Bouts.Delete(ctx, 99, 1)
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v deleting a missing bout; want ErrRecordNotFound", err)
+	}
+}
+
+func testUsers(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	user := &data.User{Name: "Gyoji", Email: "gyoji@example.com"}
+
+	err := user.Password.Set("pa55word123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = models.Users.Insert(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.ID != 1 || user.Version != 1 || user.CreatedAt.IsZero() {
+		t.Errorf("got %+v after insert", user)
+	}
+
+	duplicate := &data.User{Name: "Gyoji", Email: "GYOJI@example.com"}
+	duplicate.Password.Set("pa55word123")
+
+	err = models.Users.Insert(ctx, duplicate)
+	if !errors.Is(err, data.ErrDuplicateEmail) {
+		t.Errorf("got %v for a duplicate email; want ErrDuplicateEmail", err)
+	}
+
+	got, err := models.Users.GetByEmail(ctx, "Gyoji@Example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := got.Password.Matches("pa55word123")
+	if err != nil || !matches || got.Version != 1 {
+		t.Errorf("got %+v, matches %v, %v", got, matches, err)
+	}
+
+	stale := *got
+	got.Activated = true
+
+	err = models.Users.Update(ctx, got)
+	if err != nil || got.Version != 2 {
+		t.Fatalf("got %v, version %d", err, got.Version)
+	}
+
+	err = models.Users.Update(ctx, &stale)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	_, err = models.Users.GetByEmail(ctx, "yobidashi@example.com")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v for a missing user; want ErrRecordNotFound", err)
+	}
+}
+
+func testSearch(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	mustInsertRikishis(t, models,
+		&data.Rikishi{Shikona: "Hakuho", HighestRank: "Yokozuna", Heya: "Miyagino", ShikonaKanji: "白鵬", ShikonaKana: "はくほう", HeyaKanji: "宮城野", HeyaKana: "みやぎの"},
+		&data.Rikishi{Shikona: "Terunofuji", HighestRank: "Yokozuna", Heya: "Isegahama", ShikonaHistory: []string{"Wakamisho", "Terunofuji"}},
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+	)
+
+	err := models.Bouts.InsertBatch(ctx, []*data.Bout{
+		{Tournament: "2021 Mar", Day: "1", Division: "Makuuchi", Winner: "Hakuho", Loser: "Takakeisho", Kimarite: "oshidashi"},
+		{Tournament: "2021 Mar", Day: "2", Division: "Makuuchi", Winner: "Terunofuji", Loser: "Takakeisho", Kimarite: "yorikiri"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		kinds       []string
+		wantKind    string
+		wantValue   string
+		wantRikishi string
+	}{
+		{"Accents", "Hakuhō", data.SearchKinds, data.SearchKindRikishi, "Hakuho", "Hakuho"},
+		{"Historical shikona", "wakamisho", data.SearchKinds, data.SearchKindRikishi, "Wakamisho", "Terunofuji"},
+		{"Typo", "oshidasi", data.SearchKinds, data.SearchKindKimarite, "oshidashi", ""},
+		{"Partial heya", "isegaha", []string{data.SearchKindHeya}, data.SearchKindHeya, "Isegahama", ""},
+		{"Kanji shikona", "白鵬", data.SearchKinds, data.SearchKindRikishi, "Hakuho", "Hakuho"},
+		{"Katakana shikona", "ハクホウ", data.SearchKinds, data.SearchKindRikishi, "Hakuho", "Hakuho"},
+		{"Kana heya", "みやぎの", data.SearchKinds, data.SearchKindHeya, "Miyagino", ""},
+		{"Kanji kimarite", "寄り切り", data.SearchKinds, data.SearchKindKimarite, "yorikiri", ""},
+		{"Kana kimarite", "よりきり", data.SearchKinds, data.SearchKindKimarite, "yorikiri", ""},
+		{"Kanji heya", "宮城野", []string{data.SearchKindHeya}, data.SearchKindHeya, "Miyagino", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := models.Search.Search(ctx, tt.query, tt.kinds, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(hits) == 0 {
+				t.Fatalf("got no hits for %q", tt.query)
+			}
+
+			hit := hits[0]
+			if hit.Kind != tt.wantKind || hit.Value != tt.wantValue || hit.Rikishi != tt.wantRikishi {
+				t.Errorf("got %+v; want %s %s (%s)", hit, tt.wantKind, tt.wantValue, tt.wantRikishi)
+			}
+
+			if !strings.Contains(hit.Highlight, "<em>") {
+				t.Errorf("got highlight %q; want a marked match", hit.Highlight)
+			}
+
+			for _, hit := range hits {
+				if !slices.Contains(tt.kinds, hit.Kind) {
+					t.Errorf("got hit of kind %q; want one of %v", hit.Kind, tt.kinds)
+				}
+			}
+		})
+	}
+
+	hits, err := models.Search.Search(ctx, "zzzz", data.SearchKinds, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hits) != 0 {
+		t.Errorf("got %d hits for an unrelated query; want 0", len(hits))
+	}
+}
+
+func testWebhooks(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	mustInsertRikishis(t, models,
+		&data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama"},
+		&data.Rikishi{Shikona: "Takayasu", HighestRank: "Ozeki", Heya: "Tagonoura"},
+	)
+
+	// Changes made before a webhook subscribes are not delivered to it.
+	bout := &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takayasu", Kimarite: "oshidashi"}
+
+	err := models.Bouts.Insert(ctx, bout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bouts := &data.Webhook{URL: "https://example.com/bouts", Events: []string{"bout.created", "bout.updated"}, Secret: "0123456789abcdef", Active: true, Owner: "partner"}
+	results := &data.Webhook{URL: "https://example.com/results", Events: []string{"tournament_result.created"}, Secret: "0123456789abcdef", Active: true, Owner: "partner"}
+	other := &data.Webhook{URL: "https://example.org/bouts", Events: []string{"bout.created"}, Secret: "0123456789abcdef", Active: false, Owner: "other"}
+
+	for _, webhook := range []*data.Webhook{bouts, results, other} {
+		err = models.Webhooks.Insert(ctx, webhook)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if bouts.ID != 1 || bouts.Version != 1 || bouts.CreatedAt.IsZero() {
+		t.Errorf("got %+v after insert", bouts)
+	}
+
+	got, err := models.Webhooks.Get(ctx, bouts.ID, "partner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.URL != bouts.URL || !reflect.DeepEqual(got.Events, bouts.Events) || got.Secret != bouts.Secret || !got.Active || got.Owner != "partner" {
+		t.Errorf("got %+v; want %+v", got, bouts)
+	}
+
+	_, err = models.Webhooks.Get(ctx, bouts.ID, "other")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v for another owner's webhook; want ErrRecordNotFound", err)
+	}
+
+	got, err = models.Webhooks.GetForDelivery(ctx, other.ID)
+	if err != nil || got.URL != other.URL {
+		t.Errorf("got %+v, %v; want any owner's webhook for delivery", got, err)
+	}
+
+	hijack := *other
+	hijack.Owner = "partner"
+
+	err = models.Webhooks.Update(ctx, &hijack)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v updating another owner's webhook; want ErrEditConflict", err)
+	}
+
+	got, err = models.Webhooks.Get(ctx, bouts.ID, "partner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale := *got
+	got.Events = append(got.Events, "bout.deleted")
+
+	err = models.Webhooks.Update(ctx, got)
+	if err != nil || got.Version != 2 {
+		t.Fatalf("got %v, version %d", err, got.Version)
+	}
+
+	err = models.Webhooks.Update(ctx, &stale)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Errorf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	webhooks, metadata, err := models.Webhooks.GetAll(ctx, "partner", data.Filters{Page: 1, PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(webhooks) != 1 || webhooks[0].ID != bouts.ID || metadata.TotalRecords != 2 || metadata.LastPage != 2 {
+		t.Errorf("got %d webhooks, %+v", len(webhooks), metadata)
+	}
+
+	// A failed change queues nothing.
+	err = models.Bouts.Delete(ctx, bout.ID, bout.Version+1)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Fatalf("got %v for a stale delete; want ErrEditConflict", err)
+	}
+
+	err = models.Bouts.Delete(ctx, bout.ID, bout.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Add(time.Second)
+
+	claimed, err := models.Webhooks.ClaimDeliveries(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(claimed) != 1 || claimed[0].WebhookID != bouts.ID || claimed[0].Status != data.DeliveryPending || !strings.Contains(string(claimed[0].Payload), "bout.deleted") || !strings.Contains(string(claimed[0].Payload), "oshidashi") {
+		t.Fatalf("got claimed %+v; want the one bout.deleted delivery", claimed)
+	}
+
+	again, err := models.Webhooks.ClaimDeliveries(ctx, now, time.Minute, 10)
+	if err != nil || len(again) != 0 {
+		t.Fatalf("got %d deliveries, %v; want leased deliveries to be skipped", len(again), err)
+	}
+
+	delivery := claimed[0]
+	delivery.Attempts = 1
+	delivery.ResponseStatus = 500
+	delivery.LastError = "unexpected status 500"
+	delivery.NextAttemptAt = now.Add(-time.Second)
+
+	err = models.Webhooks.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err = models.Webhooks.ClaimDeliveries(ctx, now, time.Minute, 10)
+	if err != nil || len(claimed) != 1 || claimed[0].Attempts != 1 || claimed[0].LastError != delivery.LastError {
+		t.Fatalf("got %+v, %v; want the retried delivery", claimed, err)
+	}
+
+	delivery = claimed[0]
+	delivery.Status = data.DeliveryDead
+
+	err = models.Webhooks.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err = models.Webhooks.ClaimDeliveries(ctx, now.Add(time.Hour), time.Minute, 10)
+	if err != nil || len(claimed) != 0 {
+		t.Fatalf("got %d deliveries, %v; want dead deliveries to be skipped", len(claimed), err)
+	}
+
+	err = models.Bouts.Insert(ctx, &data.Bout{Tournament: "2022 Nov", Day: "2", Division: "Makuuchi", Winner: "Takayasu", Loser: "Takakeisho", Kimarite: "yorikiri"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, metadata, err := models.Webhooks.GetDeliveries(ctx, bouts.ID, "partner", filters(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deliveries) != 2 || deliveries[0].Event != "bout.created" || deliveries[1].Status != data.DeliveryDead || metadata.TotalRecords != 2 {
+		t.Errorf("got %d deliveries, %+v; want newest first", len(deliveries), metadata)
+	}
+
+	deliveries, metadata, err = models.Webhooks.GetDeliveries(ctx, bouts.ID, "other", filters(""))
+	if err != nil || len(deliveries) != 0 || metadata.TotalRecords != 0 {
+		t.Errorf("got %d deliveries, %v for another owner; want none", len(deliveries), err)
+	}
+
+	err = models.Webhooks.Delete(ctx, bouts.ID, "other")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v deleting another owner's webhook; want ErrRecordNotFound", err)
+	}
+
+	err = models.Webhooks.Delete(ctx, bouts.ID, "partner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, _, err = models.Webhooks.GetDeliveries(ctx, bouts.ID, "partner", filters(""))
+	if err != nil || len(deliveries) != 0 {
+		t.Errorf("got %d deliveries, %v; want them deleted with the webhook", len(deliveries), err)
+	}
+
+	err = models.Webhooks.Delete(ctx, bouts.ID, "partner")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v for a missing webhook; want ErrRecordNotFound", err)
+	}
+
+	tr := &data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "Ozeki", Wins: 12, Losses: 3}
+
+	err = models.TournamentsResults.InsertBatch(ctx, []*data.TournamentResult{tr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staleResult := *tr
+	staleResult.Version++
+
+	err = models.TournamentsResults.Update(ctx, &staleResult)
+	if !errors.Is(err, data.ErrEditConflict) {
+		t.Fatalf("got %v for a stale update; want ErrEditConflict", err)
+	}
+
+	deliveries, _, err = models.Webhooks.GetDeliveries(ctx, results.ID, "partner", filters(""))
+	if err != nil || len(deliveries) != 1 || deliveries[0].Event != "tournament_result.created" {
+		t.Errorf("got %+v, %v; want the one tournament_result.created delivery", deliveries, err)
+	}
+}
+
+func testRateLimits(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	limit := data.RateLimit{Rate: 1, Burst: 3}
+	now := time.Date(2024, time.January, 14, 12, 0, 0, 0, time.UTC)
+
+	for want := 2; want >= 0; want-- {
+		result, err := models.RateLimits.Allow(ctx, "ip:192.0.2.1", limit, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Allowed || result.Remaining != want || result.Limit != 3 {
+			t.Errorf("got %+v; want allowed with %d remaining of 3", result, want)
+		}
+	}
+
+	result, err := models.RateLimits.Allow(ctx, "ip:192.0.2.1", limit, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := data.RateLimitResult{Limit: 3, Reset: 3 * time.Second, RetryAfter: time.Second}
+	if result != want {
+		t.Errorf("got %+v once the burst is used; want %+v", result, want)
+	}
+
+	result, err = models.RateLimits.Allow(ctx, "ip:192.0.2.2", limit, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Allowed || result.Remaining != 2 {
+		t.Errorf("got %+v for another key; want its own bucket", result)
+	}
+
+	err = models.RateLimits.Refund(ctx, "ip:192.0.2.2", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = models.RateLimits.Allow(ctx, "ip:192.0.2.2", limit, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Allowed || result.Remaining != 2 {
+		t.Errorf("got %+v after a refund; want the request given back", result)
+	}
+
+	result, err = models.RateLimits.Allow(ctx, "ip:192.0.2.1", limit, now.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = data.RateLimitResult{Allowed: true, Limit: 3, Reset: 3 * time.Second}
+	if result != want {
+		t.Errorf("got %+v after a second; want %+v", result, want)
+	}
+
+	later := now.Add(time.Minute)
+
+	err = models.RateLimits.DeleteExpired(ctx, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = models.RateLimits.Allow(ctx, "ip:192.0.2.1", limit, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Allowed || result.Remaining != 2 {
+		t.Errorf("got %+v once the bucket refilled; want 2 remaining", result)
+	}
+}
+
+func testImports(t *testing.T, models data.Models) {
+	ctx := t.Context()
+
+	webhook := &data.Webhook{URL: "https://example.com/bouts", Events: []string{"bout.created", "tournament_result.created"}, Secret: "0123456789abcdef", Active: true, Owner: "partner"}
+
+	err := models.Webhooks.Insert(ctx, webhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rikishi := &data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama", ShikonaHistory: []string{"Takakeisho"}}
+	tr := &data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Takakeisho", Rank: "O1e", Wins: 12, Losses: 3}
+	bout := &data.Bout{Tournament: "2022 Nov", Day: "1", Division: "Makuuchi", Winner: "Takakeisho", Loser: "Takakeisho", Kimarite: "fusensho"}
+
+	err = models.Imports.Import(ctx, func(tx data.ImportTx) error {
+		err := tx.InsertRikishi(ctx, rikishi)
+		if err != nil {
+			return err
+		}
+
+		err = tx.InsertTournamentResult(ctx, tr)
+		if err != nil {
+			return err
+		}
+
+		return tx.InsertBout(ctx, bout)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rikishi.Version != 1 || tr.ID == 0 || tr.Version != 1 || bout.ID == 0 || bout.Version != 1 {
+		t.Errorf("got %+v, %+v and %+v; want IDs and versions set", rikishi, tr, bout)
+	}
+
+	got, err := models.Bouts.Get(ctx, bout.ID)
+	if err != nil || !reflect.DeepEqual(got, bout) {
+		t.Errorf("got %+v, %v; want %+v", got, err, bout)
+	}
+
+	claimed, err := models.Webhooks.ClaimDeliveries(ctx, time.Now().Add(time.Second), time.Minute, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(claimed) != 0 {
+		t.Errorf("got %d deliveries; want imported rows not delivered", len(claimed))
+	}
+
+	// A failed batch leaves nothing behind, including the rows inserted
+	// before the failure.
+	errStop := errors.New("stop")
+
+	err = models.Imports.Import(ctx, func(tx data.ImportTx) error {
+		err := tx.InsertRikishi(ctx, &data.Rikishi{Shikona: "Abi", HighestRank: "Komusubi", Heya: "Shikoroyama", ShikonaHistory: []string{"Abi"}})
+		if err != nil {
+			return err
+		}
+
+		err = tx.InsertTournamentResult(ctx, &data.TournamentResult{Tournament: "2022 Nov", Rikishi: "Abi", Rank: "K1w", Wins: 8, Losses: 7})
+		if err != nil {
+			return err
+		}
+
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("got %v; want the batch's error", err)
+	}
+
+	err = models.Imports.Import(ctx, func(tx data.ImportTx) error {
+		return tx.InsertRikishi(ctx, &data.Rikishi{Shikona: "Takakeisho", HighestRank: "Ozeki", Heya: "Tokiwayama", ShikonaHistory: []string{"Takakeisho"}})
+	})
+	if err == nil {
+		t.Fatal("got no error importing an existing rikishi")
+	}
+
+	_, err = models.Rikishis.Get(ctx, "Abi")
+	if !errors.Is(err, data.ErrRecordNotFound) {
+		t.Errorf("got %v; want the failed batch rolled back", err)
+	}
+
+	_, metadata, err := models.TournamentsResults.GetAll(ctx, "", "", 0, nil, filters("id", "id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metadata.TotalRecords != 1 {
+		t.Errorf("got %d tournament results; want only the committed one", metadata.TotalRecords)
+	}
+}
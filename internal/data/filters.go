@@ -1,25 +1,67 @@
 package data
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
 	"strings"
 
 	"github.com/corsairconstantine/sumodb/internal/validator"
 )
 
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 type Filters struct {
 	Page         int
 	PageSize     int
 	Sort         string
 	SortSafelist []string
+	Cursor       string
+	IncludeTotal bool
+	Unlimited    bool
 }
 
 type Metadata struct {
-	CurrentPage  int `json:"current_page,omitempty"`
-	PageSize     int `json:"page_size,omitempty"`
-	FirstPage    int `json:"first_page,omitempty"`
-	LastPage     int `json:"last_page,omitempty"`
-	TotalRecords int `json:"total_records,omitempty"`
+	CurrentPage  int    `json:"current_page,omitempty"`
+	PageSize     int    `json:"page_size,omitempty"`
+	FirstPage    int    `json:"first_page,omitempty"`
+	LastPage     int    `json:"last_page,omitempty"`
+	TotalRecords int    `json:"total_records,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
+}
+
+type cursor struct {
+	Sort     string    `json:"s"`
+	Values   [2]string `json:"v"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+func encodeCursor(c cursor) string {
+	js, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(js)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+
+	if s == "" {
+		return c, nil
+	}
+
+	js, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+
+	err = json.Unmarshal(js, &c)
+	if err != nil || c.Sort == "" {
+		return c, ErrInvalidCursor
+	}
+
+	return c, nil
 }
 
 func calculateMetadata(totalRecords, page, pageSize int) Metadata {
@@ -36,11 +78,19 @@ func calculateMetadata(totalRecords, page, pageSize int) Metadata {
 	}
 }
 
-func (f Filters) limit() int {
-	return f.PageSize
+func (f Filters) limit() interface{} {
+	if f.Unlimited {
+		return nil
+	}
+
+	return f.PageSize + 1
 }
 
 func (f Filters) offset() int {
+	if f.Cursor != "" || f.Unlimited {
+		return 0
+	}
+
 	return (f.Page - 1) * f.PageSize
 }
 
@@ -62,6 +112,162 @@ func (f Filters) sortDirection() string {
 	return "ASC"
 }
 
+func (f Filters) totalColumn() string {
+	if f.IncludeTotal {
+		return "count(*) OVER()"
+	}
+
+	return "0"
+}
+
+func (f Filters) backward() bool {
+	c, _ := decodeCursor(f.Cursor)
+	return c.Backward
+}
+
+// orderBy reverses the order for a backward page, so that the limit keeps the
+// rows nearest the cursor. Unlimited reads have no limit to apply and keep the
+// page order.
+func (f Filters) orderBy(column, key string) string {
+	sortDirection, keyDirection := f.sortDirection(), "ASC"
+
+	if f.backward() && !f.Unlimited {
+		sortDirection, keyDirection = reverseDirection(sortDirection), reverseDirection(keyDirection)
+	}
+
+	return fmt.Sprintf("%s %s, %s %s", column, sortDirection, key, keyDirection)
+}
+
+func (f Filters) keysetCondition(column, key string, placeholder int) (string, []interface{}) {
+	c, err := decodeCursor(f.Cursor)
+	if err != nil || f.Cursor == "" {
+		return "TRUE", nil
+	}
+
+	sortOp, keyOp := ">", ">"
+	if f.sortDirection() == "DESC" {
+		sortOp = "<"
+	}
+
+	if c.Backward {
+		sortOp, keyOp = reverseOperator(sortOp), reverseOperator(keyOp)
+	}
+
+	condition := fmt.Sprintf("(%[1]s %[2]s $%[5]d OR (%[1]s = $%[5]d AND %[3]s %[4]s $%[6]d))",
+		column, sortOp, key, keyOp, placeholder, placeholder+1)
+
+	return condition, []interface{}{c.Values[0], c.Values[1]}
+}
+
+func reverseDirection(direction string) string {
+	if direction == "ASC" {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+func reverseOperator(op string) string {
+	if op == ">" {
+		return "<"
+	}
+
+	return ">"
+}
+
+func paginate[T any](f Filters, items []T, keys [][2]string, totalRecords int) ([]T, Metadata) {
+	backward := f.backward()
+
+	hasMore := len(items) > f.PageSize
+	if hasMore {
+		items, keys = items[:f.PageSize], keys[:f.PageSize]
+	}
+
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	var metadata Metadata
+
+	switch {
+	case f.Cursor != "":
+		metadata = Metadata{PageSize: f.PageSize}
+		if f.IncludeTotal {
+			metadata.TotalRecords = totalRecords
+		}
+	case f.IncludeTotal:
+		metadata = calculateMetadata(totalRecords, f.Page, f.PageSize)
+	default:
+		metadata = Metadata{CurrentPage: f.Page, PageSize: f.PageSize, FirstPage: 1}
+	}
+
+	if len(items) == 0 {
+		return items, metadata
+	}
+
+	hasNext, hasPrev := hasMore, f.Cursor != "" || f.Page > 1
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		metadata.NextCursor = encodeCursor(cursor{Sort: f.Sort, Values: keys[len(keys)-1]})
+	}
+
+	if hasPrev {
+		metadata.PrevCursor = encodeCursor(cursor{Sort: f.Sort, Values: keys[0], Backward: true})
+	}
+
+	return items, metadata
+}
+
+// pageStreamer passes the rows of a streamed read to fn, stopping after one
+// page unless the read is unlimited. A limited backward page is read in reverse
+// order, so its rows are held back and passed on reversed by flush.
+type pageStreamer[T any] struct {
+	filters  Filters
+	backward bool
+	fn       func(T) error
+	count    int
+	held     []T
+}
+
+func newPageStreamer[T any](filters Filters, fn func(T) error) *pageStreamer[T] {
+	return &pageStreamer[T]{filters: filters, backward: filters.backward(), fn: fn}
+}
+
+func (s *pageStreamer[T]) add(item T) error {
+	if s.filters.Unlimited {
+		return s.fn(item)
+	}
+
+	if s.count == s.filters.PageSize {
+		return nil
+	}
+	s.count++
+
+	if s.backward {
+		s.held = append(s.held, item)
+		return nil
+	}
+
+	return s.fn(item)
+}
+
+func (s *pageStreamer[T]) flush() error {
+	for i := len(s.held) - 1; i >= 0; i-- {
+		err := s.fn(s.held[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func ValidateFilters(v *validator.Validator, f Filters) {
 	v.Check(f.Page > 0, "page", "must be greater than zero")
 	v.Check(f.Page <= 10_000_000, "page", "must be a maximum of 10 million")
@@ -69,4 +275,10 @@ func ValidateFilters(v *validator.Validator, f Filters) {
 	v.Check(f.PageSize <= 100, "page_size", "must be a maximum of 100")
 
 	v.Check(validator.In(f.Sort, f.SortSafelist...), "sort", "invalid sort value")
+
+	if f.Cursor != "" {
+		c, err := decodeCursor(f.Cursor)
+		v.Check(err == nil, "cursor", "invalid cursor value")
+		v.Check(err != nil || c.Sort == f.Sort, "sort", "must match the sort the cursor was issued for")
+	}
 }
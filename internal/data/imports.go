@@ -0,0 +1,34 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ImportModel runs imports against PostgreSQL. A batch can take far longer
+// than a single write, so its only limit is the caller's deadline.
+type ImportModel struct {
+	DB *sql.DB
+}
+
+func (m ImportModel) Import(ctx context.Context, fn func(tx ImportTx) error) error {
+	return withTx(ctx, m.DB, func(tx *sql.Tx) error {
+		return fn(importTx{tx})
+	})
+}
+
+type importTx struct {
+	tx *sql.Tx
+}
+
+func (t importTx) InsertRikishi(ctx context.Context, rikishi *Rikishi) error {
+	return RikishiModel{}.InsertTx(ctx, t.tx, rikishi)
+}
+
+func (t importTx) InsertTournamentResult(ctx context.Context, tr *TournamentResult) error {
+	return TournamentResultModel{}.InsertTx(ctx, t.tx, tr)
+}
+
+func (t importTx) InsertBout(ctx context.Context, bout *Bout) error {
+	return BoutModel{}.InsertTx(ctx, t.tx, bout)
+}
@@ -0,0 +1,444 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryObserver is told how long each model method took, e.g. to record
+// query latency metrics.
+type QueryObserver func(model, method string, d time.Duration)
+
+// InstrumentModels wraps every store in models so that each call gets a
+// span from tp and is timed and reported to observe under the store's table
+// name.
+func InstrumentModels(models Models, tp trace.TracerProvider, observe QueryObserver) Models {
+	tracer := tp.Tracer("github.com/corsairconstantine/sumodb/internal/data")
+
+	return Models{
+		Rikishis:           instrumentedRikishis{models.Rikishis, instrumented{"rikishis", tracer, observe}},
+		TournamentsResults: instrumentedTournamentsResults{models.TournamentsResults, instrumented{"tournaments_results", tracer, observe}},
+		Bouts:              instrumentedBouts{models.Bouts, instrumented{"bouts", tracer, observe}},
+		Users:              instrumentedUsers{models.Users, instrumented{"users", tracer, observe}},
+		Search:             instrumentedSearch{models.Search, instrumented{"search", tracer, observe}},
+		Webhooks:           instrumentedWebhooks{models.Webhooks, instrumented{"webhooks", tracer, observe}},
+		RateLimits:         instrumentedRateLimits{models.RateLimits, instrumented{"rate_limits", tracer, observe}},
+		Imports:            instrumentedImports{models.Imports, instrumented{"imports", tracer, observe}},
+	}
+}
+
+type instrumented struct {
+	model   string
+	tracer  trace.Tracer
+	observe QueryObserver
+}
+
+// start begins a span for method and returns a function which ends it. Missing
+// records and edit conflicts are expected outcomes, so only other errors mark
+// the span as failed.
+func (i instrumented) start(ctx context.Context, method string) (context.Context, func(err *error)) {
+	start := time.Now()
+
+	ctx, span := i.tracer.Start(ctx, i.model+"."+method, trace.WithAttributes(
+		attribute.String("sumodb.model", i.model),
+		attribute.String("sumodb.method", method),
+	))
+
+	return ctx, func(err *error) {
+		if err != nil && *err != nil && !errors.Is(*err, ErrRecordNotFound) && !errors.Is(*err, ErrEditConflict) {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+
+		span.End()
+		i.observe(i.model, method, time.Since(start))
+	}
+}
+
+type instrumentedRikishis struct {
+	next RikishiStore
+	instrumented
+}
+
+func (m instrumentedRikishis) Exists(ctx context.Context, shikona string) (_ bool, err error) {
+	ctx, end := m.start(ctx, "Exists")
+	defer end(&err)
+
+	return m.next.Exists(ctx, shikona)
+}
+
+func (m instrumentedRikishis) Insert(ctx context.Context, rikishi *Rikishi) (err error) {
+	ctx, end := m.start(ctx, "Insert")
+	defer end(&err)
+
+	return m.next.Insert(ctx, rikishi)
+}
+
+func (m instrumentedRikishis) Get(ctx context.Context, shikona string) (_ *Rikishi, err error) {
+	ctx, end := m.start(ctx, "Get")
+	defer end(&err)
+
+	return m.next.Get(ctx, shikona)
+}
+
+func (m instrumentedRikishis) GetAll(ctx context.Context, shikona, highestRank, heya string, filters Filters) (_ []*Rikishi, _ Metadata, err error) {
+	ctx, end := m.start(ctx, "GetAll")
+	defer end(&err)
+
+	return m.next.GetAll(ctx, shikona, highestRank, heya, filters)
+}
+
+func (m instrumentedRikishis) Stream(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(*Rikishi) error) (err error) {
+	ctx, end := m.start(ctx, "Stream")
+	defer end(&err)
+
+	return m.next.Stream(ctx, shikona, highestRank, heya, filters, fn)
+}
+
+func (m instrumentedRikishis) GetByShikonas(ctx context.Context, shikonas []string) (_ map[string]*Rikishi, err error) {
+	ctx, end := m.start(ctx, "GetByShikonas")
+	defer end(&err)
+
+	return m.next.GetByShikonas(ctx, shikonas)
+}
+
+func (m instrumentedRikishis) GetForHeyas(ctx context.Context, heyas []string, limit int) (_ []*Rikishi, err error) {
+	ctx, end := m.start(ctx, "GetForHeyas")
+	defer end(&err)
+
+	return m.next.GetForHeyas(ctx, heyas, limit)
+}
+
+func (m instrumentedRikishis) GetShikonaHistory(ctx context.Context, shikona string) (_ []string, err error) {
+	ctx, end := m.start(ctx, "GetShikonaHistory")
+	defer end(&err)
+
+	return m.next.GetShikonaHistory(ctx, shikona)
+}
+
+func (m instrumentedRikishis) ExistingShikonas(ctx context.Context, shikonas []string) (_ ShikonaSet, err error) {
+	ctx, end := m.start(ctx, "ExistingShikonas")
+	defer end(&err)
+
+	return m.next.ExistingShikonas(ctx, shikonas)
+}
+
+func (m instrumentedRikishis) Aliases(ctx context.Context) (_ map[string]string, err error) {
+	ctx, end := m.start(ctx, "Aliases")
+	defer end(&err)
+
+	return m.next.Aliases(ctx)
+}
+
+func (m instrumentedRikishis) Update(ctx context.Context, rikishi *Rikishi) (err error) {
+	ctx, end := m.start(ctx, "Update")
+	defer end(&err)
+
+	return m.next.Update(ctx, rikishi)
+}
+
+func (m instrumentedRikishis) Delete(ctx context.Context, shikona string, version int32) (err error) {
+	ctx, end := m.start(ctx, "Delete")
+	defer end(&err)
+
+	return m.next.Delete(ctx, shikona, version)
+}
+
+type instrumentedTournamentsResults struct {
+	next TournamentResultStore
+	instrumented
+}
+
+func (m instrumentedTournamentsResults) Insert(ctx context.Context, tr *TournamentResult) (err error) {
+	ctx, end := m.start(ctx, "Insert")
+	defer end(&err)
+
+	return m.next.Insert(ctx, tr)
+}
+
+func (m instrumentedTournamentsResults) InsertBatch(ctx context.Context, trs []*TournamentResult) (err error) {
+	ctx, end := m.start(ctx, "InsertBatch")
+	defer end(&err)
+
+	return m.next.InsertBatch(ctx, trs)
+}
+
+func (m instrumentedTournamentsResults) Get(ctx context.Context, id int64) (_ *TournamentResult, err error) {
+	ctx, end := m.start(ctx, "Get")
+	defer end(&err)
+
+	return m.next.Get(ctx, id)
+}
+
+func (m instrumentedTournamentsResults) GetAll(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters) (_ []*TournamentResult, _ Metadata, err error) {
+	ctx, end := m.start(ctx, "GetAll")
+	defer end(&err)
+
+	return m.next.GetAll(ctx, tournament, rank, wins, shikonas, filters)
+}
+
+func (m instrumentedTournamentsResults) Stream(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(*TournamentResult) error) (err error) {
+	ctx, end := m.start(ctx, "Stream")
+	defer end(&err)
+
+	return m.next.Stream(ctx, tournament, rank, wins, shikonas, filters, fn)
+}
+
+func (m instrumentedTournamentsResults) GetForRikishis(ctx context.Context, tournaments, shikonas []string) (_ []*TournamentResult, err error) {
+	ctx, end := m.start(ctx, "GetForRikishis")
+	defer end(&err)
+
+	return m.next.GetForRikishis(ctx, tournaments, shikonas)
+}
+
+func (m instrumentedTournamentsResults) GetForTournaments(ctx context.Context, tournaments []string, rank string, limit int) (_ []*TournamentResult, err error) {
+	ctx, end := m.start(ctx, "GetForTournaments")
+	defer end(&err)
+
+	return m.next.GetForTournaments(ctx, tournaments, rank, limit)
+}
+
+func (m instrumentedTournamentsResults) IsDuplicate(ctx context.Context, tr *TournamentResult) (_ bool, err error) {
+	ctx, end := m.start(ctx, "IsDuplicate")
+	defer end(&err)
+
+	return m.next.IsDuplicate(ctx, tr)
+}
+
+func (m instrumentedTournamentsResults) Update(ctx context.Context, tr *TournamentResult) (err error) {
+	ctx, end := m.start(ctx, "Update")
+	defer end(&err)
+
+	return m.next.Update(ctx, tr)
+}
+
+func (m instrumentedTournamentsResults) Delete(ctx context.Context, id int64, version int32) (err error) {
+	ctx, end := m.start(ctx, "Delete")
+	defer end(&err)
+
+	return m.next.Delete(ctx, id, version)
+}
+
+type instrumentedBouts struct {
+	next BoutStore
+	instrumented
+}
+
+func (m instrumentedBouts) Insert(ctx context.Context, bout *Bout) (err error) {
+	ctx, end := m.start(ctx, "Insert")
+	defer end(&err)
+
+	return m.next.Insert(ctx, bout)
+}
+
+func (m instrumentedBouts) InsertBatch(ctx context.Context, bouts []*Bout) (err error) {
+	ctx, end := m.start(ctx, "InsertBatch")
+	defer end(&err)
+
+	return m.next.InsertBatch(ctx, bouts)
+}
+
+func (m instrumentedBouts) Get(ctx context.Context, id int64) (_ *Bout, err error) {
+	ctx, end := m.start(ctx, "Get")
+	defer end(&err)
+
+	return m.next.Get(ctx, id)
+}
+
+func (m instrumentedBouts) GetAll(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) (_ []*Bout, _ Metadata, err error) {
+	ctx, end := m.start(ctx, "GetAll")
+	defer end(&err)
+
+	return m.next.GetAll(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters)
+}
+
+func (m instrumentedBouts) Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(*Bout) error) (err error) {
+	ctx, end := m.start(ctx, "Stream")
+	defer end(&err)
+
+	return m.next.Stream(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, fn)
+}
+
+func (m instrumentedBouts) GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) (_ []*Bout, err error) {
+	ctx, end := m.start(ctx, "GetForTournaments")
+	defer end(&err)
+
+	return m.next.GetForTournaments(ctx, tournaments, day, division, limit)
+}
+
+func (m instrumentedBouts) GetForRikishis(ctx context.Context, groups []BoutGroup, limit int) (_ [][]*Bout, err error) {
+	ctx, end := m.start(ctx, "GetForRikishis")
+	defer end(&err)
+
+	return m.next.GetForRikishis(ctx, groups, limit)
+}
+
+func (m instrumentedBouts) IsDuplicate(ctx context.Context, bout *Bout) (_ bool, err error) {
+	ctx, end := m.start(ctx, "IsDuplicate")
+	defer end(&err)
+
+	return m.next.IsDuplicate(ctx, bout)
+}
+
+func (m instrumentedBouts) Update(ctx context.Context, bout *Bout) (err error) {
+	ctx, end := m.start(ctx, "Update")
+	defer end(&err)
+
+	return m.next.Update(ctx, bout)
+}
+
+func (m instrumentedBouts) Delete(ctx context.Context, id int64, version int32) (err error) {
+	ctx, end := m.start(ctx, "Delete")
+	defer end(&err)
+
+	return m.next.Delete(ctx, id, version)
+}
+
+type instrumentedUsers struct {
+	next UserStore
+	instrumented
+}
+
+func (m instrumentedUsers) Insert(ctx context.Context, user *User) (err error) {
+	ctx, end := m.start(ctx, "Insert")
+	defer end(&err)
+
+	return m.next.Insert(ctx, user)
+}
+
+func (m instrumentedUsers) GetByEmail(ctx context.Context, email string) (_ *User, err error) {
+	ctx, end := m.start(ctx, "GetByEmail")
+	defer end(&err)
+
+	return m.next.GetByEmail(ctx, email)
+}
+
+func (m instrumentedUsers) Update(ctx context.Context, user *User) (err error) {
+	ctx, end := m.start(ctx, "Update")
+	defer end(&err)
+
+	return m.next.Update(ctx, user)
+}
+
+type instrumentedSearch struct {
+	next SearchStore
+	instrumented
+}
+
+func (m instrumentedSearch) Search(ctx context.Context, query string, kinds []string, limit int) (_ []*SearchHit, err error) {
+	ctx, end := m.start(ctx, "Search")
+	defer end(&err)
+
+	return m.next.Search(ctx, query, kinds, limit)
+}
+
+type instrumentedWebhooks struct {
+	next WebhookStore
+	instrumented
+}
+
+func (m instrumentedWebhooks) Insert(ctx context.Context, webhook *Webhook) (err error) {
+	ctx, end := m.start(ctx, "Insert")
+	defer end(&err)
+
+	return m.next.Insert(ctx, webhook)
+}
+
+func (m instrumentedWebhooks) Get(ctx context.Context, id int64, owner string) (_ *Webhook, err error) {
+	ctx, end := m.start(ctx, "Get")
+	defer end(&err)
+
+	return m.next.Get(ctx, id, owner)
+}
+
+func (m instrumentedWebhooks) GetForDelivery(ctx context.Context, id int64) (_ *Webhook, err error) {
+	ctx, end := m.start(ctx, "GetForDelivery")
+	defer end(&err)
+
+	return m.next.GetForDelivery(ctx, id)
+}
+
+func (m instrumentedWebhooks) GetAll(ctx context.Context, owner string, filters Filters) (_ []*Webhook, _ Metadata, err error) {
+	ctx, end := m.start(ctx, "GetAll")
+	defer end(&err)
+
+	return m.next.GetAll(ctx, owner, filters)
+}
+
+func (m instrumentedWebhooks) Update(ctx context.Context, webhook *Webhook) (err error) {
+	ctx, end := m.start(ctx, "Update")
+	defer end(&err)
+
+	return m.next.Update(ctx, webhook)
+}
+
+func (m instrumentedWebhooks) Delete(ctx context.Context, id int64, owner string) (err error) {
+	ctx, end := m.start(ctx, "Delete")
+	defer end(&err)
+
+	return m.next.Delete(ctx, id, owner)
+}
+
+func (m instrumentedWebhooks) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) (_ []*WebhookDelivery, err error) {
+	ctx, end := m.start(ctx, "ClaimDeliveries")
+	defer end(&err)
+
+	return m.next.ClaimDeliveries(ctx, now, lease, limit)
+}
+
+func (m instrumentedWebhooks) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) (err error) {
+	ctx, end := m.start(ctx, "UpdateDelivery")
+	defer end(&err)
+
+	return m.next.UpdateDelivery(ctx, delivery)
+}
+
+func (m instrumentedWebhooks) GetDeliveries(ctx context.Context, webhookID int64, owner string, filters Filters) (_ []*WebhookDelivery, _ Metadata, err error) {
+	ctx, end := m.start(ctx, "GetDeliveries")
+	defer end(&err)
+
+	return m.next.GetDeliveries(ctx, webhookID, owner, filters)
+}
+
+type instrumentedRateLimits struct {
+	next RateLimitStore
+	instrumented
+}
+
+func (m instrumentedRateLimits) Allow(ctx context.Context, key string, limit RateLimit, now time.Time) (_ RateLimitResult, err error) {
+	ctx, end := m.start(ctx, "Allow")
+	defer end(&err)
+
+	return m.next.Allow(ctx, key, limit, now)
+}
+
+func (m instrumentedRateLimits) Refund(ctx context.Context, key string, limit RateLimit) (err error) {
+	ctx, end := m.start(ctx, "Refund")
+	defer end(&err)
+
+	return m.next.Refund(ctx, key, limit)
+}
+
+func (m instrumentedRateLimits) DeleteExpired(ctx context.Context, now time.Time) (err error) {
+	ctx, end := m.start(ctx, "DeleteExpired")
+	defer end(&err)
+
+	return m.next.DeleteExpired(ctx, now)
+}
+
+type instrumentedImports struct {
+	next ImportStore
+	instrumented
+}
+
+func (m instrumentedImports) Import(ctx context.Context, fn func(tx ImportTx) error) (err error) {
+	ctx, end := m.start(ctx, "Import")
+	defer end(&err)
+
+	return m.next.Import(ctx, fn)
+}
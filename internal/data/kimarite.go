@@ -0,0 +1,114 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type Reading struct {
+	Kanji string `json:"kanji"`
+	Kana  string `json:"kana"`
+}
+
+var KimariteReadings = map[string]Reading{
+	"abisetaoshi":      {"浴びせ倒し", "あびせたおし"},
+	"amiuchi":          {"網打ち", "あみうち"},
+	"ashitori":         {"足取り", "あしとり"},
+	"chongake":         {"ちょん掛け", "ちょんがけ"},
+	"fumidashi":        {"踏み出し", "ふみだし"},
+	"fusen":            {"不戦", "ふせん"},
+	"gasshohineri":     {"合掌捻り", "がっしょうひねり"},
+	"harimanage":       {"波離間投げ", "はりまなげ"},
+	"hatakikomi":       {"叩き込み", "はたきこみ"},
+	"hikiotoshi":       {"引き落とし", "ひきおとし"},
+	"hikkake":          {"引っ掛け", "ひっかけ"},
+	"ipponzeoi":        {"一本背負い", "いっぽんぜおい"},
+	"isamiashi":        {"勇み足", "いさみあし"},
+	"izori":            {"居反り", "いぞり"},
+	"kainahineri":      {"腕捻り", "かいなひねり"},
+	"kakenage":         {"掛け投げ", "かけなげ"},
+	"kakezori":         {"掛け反り", "かけぞり"},
+	"katasukashi":      {"肩透かし", "かたすかし"},
+	"kawazugake":       {"河津掛け", "かわづがけ"},
+	"kekaeshi":         {"蹴返し", "けかえし"},
+	"ketaguri":         {"蹴手繰り", "けたぐり"},
+	"kimedashi":        {"極め出し", "きめだし"},
+	"kimetaoshi":       {"極め倒し", "きめたおし"},
+	"kirikaeshi":       {"切り返し", "きりかえし"},
+	"komatasukui":      {"小股掬い", "こまたすくい"},
+	"koshikudake":      {"腰砕け", "こしくだけ"},
+	"koshinage":        {"腰投げ", "こしなげ"},
+	"kotehineri":       {"小手捻り", "こてひねり"},
+	"kotenage":         {"小手投げ", "こてなげ"},
+	"kozumatori":       {"小褄取り", "こづまとり"},
+	"kubihineri":       {"首捻り", "くびひねり"},
+	"kubinage":         {"首投げ", "くびなげ"},
+	"makiotoshi":       {"巻き落とし", "まきおとし"},
+	"mitokorozeme":     {"三所攻め", "みところぜめ"},
+	"nichonage":        {"二丁投げ", "にちょうなげ"},
+	"nimaigeri":        {"二枚蹴り", "にまいげり"},
+	"okuridashi":       {"送り出し", "おくりだし"},
+	"okurigake":        {"送り掛け", "おくりがけ"},
+	"okurihikiotoshi":  {"送り引き落とし", "おくりひきおとし"},
+	"okurinage":        {"送り投げ", "おくりなげ"},
+	"okuritaoshi":      {"送り倒し", "おくりたおし"},
+	"okuritsuridashi":  {"送り吊り出し", "おくりつりだし"},
+	"okuritsuriotoshi": {"送り吊り落とし", "おくりつりおとし"},
+	"omata":            {"大股", "おおまた"},
+	"osakate":          {"大逆手", "おおさかて"},
+	"oshidashi":        {"押し出し", "おしだし"},
+	"oshitaoshi":       {"押し倒し", "おしたおし"},
+	"sabaori":          {"鯖折り", "さばおり"},
+	"sakatottari":      {"逆とったり", "さかとったり"},
+	"shitatedashinage": {"下手出し投げ", "したてだしなげ"},
+	"shitatehineri":    {"下手捻り", "したてひねり"},
+	"shitatenage":      {"下手投げ", "したてなげ"},
+	"shumokuzori":      {"撞木反り", "しゅもくぞり"},
+	"sokubiotoshi":     {"素首落とし", "そくびおとし"},
+	"sotogake":         {"外掛け", "そとがけ"},
+	"sotokomata":       {"外小股", "そとこまた"},
+	"sotomuso":         {"外無双", "そとむそう"},
+	"sototasukizori":   {"外たすき反り", "そとたすきぞり"},
+	"sukuinage":        {"掬い投げ", "すくいなげ"},
+	"susoharai":        {"裾払い", "すそはらい"},
+	"susotori":         {"裾取り", "すそとり"},
+	"tasukizori":       {"たすき反り", "たすきぞり"},
+	"tokkurinage":      {"徳利投げ", "とっくりなげ"},
+	"tottari":          {"とったり", "とったり"},
+	"tsukaminage":      {"つかみ投げ", "つかみなげ"},
+	"tsukidashi":       {"突き出し", "つきだし"},
+	"tsukihiza":        {"つき膝", "つきひざ"},
+	"tsukiotoshi":      {"突き落とし", "つきおとし"},
+	"tsukitaoshi":      {"突き倒し", "つきたおし"},
+	"tsukite":          {"つき手", "つきて"},
+	"tsumatori":        {"褄取り", "つまとり"},
+	"tsuridashi":       {"吊り出し", "つりだし"},
+	"tsuriotoshi":      {"吊り落とし", "つりおとし"},
+	"tsutaezori":       {"伝え反り", "つたえぞり"},
+	"uchigake":         {"内掛け", "うちがけ"},
+	"uchimuso":         {"内無双", "うちむそう"},
+	"ushiromotare":     {"後ろもたれ", "うしろもたれ"},
+	"utchari":          {"うっちゃり", "うっちゃり"},
+	"uwatedashinage":   {"上手出し投げ", "うわてだしなげ"},
+	"uwatehineri":      {"上手捻り", "うわてひねり"},
+	"uwatenage":        {"上手投げ", "うわてなげ"},
+	"waridashi":        {"割り出し", "わりだし"},
+	"watashikomi":      {"渡し込み", "わたしこみ"},
+	"yaguranage":       {"櫓投げ", "やぐらなげ"},
+	"yobimodoshi":      {"呼び戻し", "よびもどし"},
+	"yorikiri":         {"寄り切り", "よりきり"},
+	"yoritaoshi":       {"寄り倒し", "よりたおし"},
+	"zubuneri":         {"ずぶねり", "ずぶねり"},
+}
+
+func (b Bout) MarshalJSON() ([]byte, error) {
+	type bout Bout
+
+	reading := KimariteReadings[strings.ToLower(b.Kimarite)]
+
+	return json.Marshal(struct {
+		bout
+		KimariteKanji string `json:"kimarite_kanji"`
+		KimariteKana  string `json:"kimarite_kana"`
+	}{bout(b), reading.Kanji, reading.Kana})
+}
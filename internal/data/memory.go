@@ -0,0 +1,1282 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	errMemoryDuplicateKey = errors.New("duplicate key value violates unique constraint")
+	errMemoryForeignKey   = errors.New("update or delete violates foreign key constraint")
+)
+
+type memoryStore struct {
+	mu                 sync.RWMutex
+	rikishis           map[string]Rikishi
+	tournamentsResults map[int64]TournamentResult
+	bouts              map[int64]Bout
+	users              map[int64]User
+	webhooks           map[int64]Webhook
+	deliveries         map[int64]WebhookDelivery
+	sequences          map[string]int64
+}
+
+func NewMemoryModels() Models {
+	s := &memoryStore{
+		rikishis:           make(map[string]Rikishi),
+		tournamentsResults: make(map[int64]TournamentResult),
+		bouts:              make(map[int64]Bout),
+		users:              make(map[int64]User),
+		webhooks:           make(map[int64]Webhook),
+		deliveries:         make(map[int64]WebhookDelivery),
+		sequences:          make(map[string]int64),
+	}
+
+	return Models{
+		Rikishis:           memoryRikishiModel{s},
+		TournamentsResults: memoryTournamentResultModel{s},
+		Bouts:              memoryBoutModel{s},
+		Users:              memoryUserModel{s},
+		Search:             memorySearchModel{s},
+		Webhooks:           memoryWebhookModel{s},
+		RateLimits:         NewMemoryRateLimitStore(),
+		Imports:            memoryImportModel{s},
+	}
+}
+
+func (s *memoryStore) nextID(table string) int64 {
+	s.sequences[table]++
+	return s.sequences[table]
+}
+
+func (s *memoryStore) referenced(shikona string) bool {
+	for _, tr := range s.tournamentsResults {
+		if tr.Rikishi == shikona {
+			return true
+		}
+	}
+
+	for _, bout := range s.bouts {
+		if bout.Winner == shikona || bout.Loser == shikona {
+			return true
+		}
+	}
+
+	return false
+}
+
+type memoryRikishiModel struct {
+	s *memoryStore
+}
+
+func (r memoryRikishiModel) Insert(ctx context.Context, rikishi *Rikishi) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.insert(rikishi)
+}
+
+func (r memoryRikishiModel) insert(rikishi *Rikishi) error {
+	if _, ok := r.s.rikishis[rikishi.Shikona]; ok {
+		return errMemoryDuplicateKey
+	}
+
+	rikishi.Version = 1
+	r.s.rikishis[rikishi.Shikona] = copyRikishi(*rikishi)
+
+	return nil
+}
+
+func (r memoryRikishiModel) Get(ctx context.Context, shikona string) (*Rikishi, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rikishi, ok := r.s.rikishis[shikona]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	rikishi = copyRikishi(rikishi)
+
+	return &rikishi, nil
+}
+
+func (r memoryRikishiModel) GetAll(ctx context.Context, shikona, highestRank, heya string, filters Filters) ([]*Rikishi, Metadata, error) {
+	rikishis, keys, total := r.scan(shikona, highestRank, heya, filters)
+
+	rikishis, metadata := paginate(filters, rikishis, keys, total)
+
+	return rikishis, metadata, nil
+}
+
+func (r memoryRikishiModel) Stream(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(*Rikishi) error) error {
+	rikishis, _, _ := r.scan(shikona, highestRank, heya, filters)
+
+	return memoryStream(ctx, filters, rikishis, fn)
+}
+
+func (r memoryRikishiModel) scan(shikona, highestRank, heya string, filters Filters) ([]*Rikishi, [][2]string, int) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rikishis := []*Rikishi{}
+
+	for _, rikishi := range r.s.rikishis {
+		if shikona != "" && !matchesWords(strings.Join(rikishi.ShikonaHistory, ","), shikona) {
+			continue
+		}
+
+		if highestRank != "" && !strings.EqualFold(rikishi.HighestRank, highestRank) {
+			continue
+		}
+
+		if heya != "" && !strings.EqualFold(rikishi.Heya, heya) {
+			continue
+		}
+
+		rikishi := copyRikishi(rikishi)
+		rikishis = append(rikishis, &rikishi)
+	}
+
+	return memoryScan(filters, rikishis, func(rikishi *Rikishi, column string) interface{} {
+		switch column {
+		case "highest_rank":
+			return rikishi.HighestRank
+		case "heya":
+			return rikishi.Heya
+		default:
+			return rikishi.Shikona
+		}
+	}, func(rikishi *Rikishi) interface{} {
+		return rikishi.Shikona
+	})
+}
+
+func (r memoryRikishiModel) GetByShikonas(ctx context.Context, shikonas []string) (map[string]*Rikishi, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rikishis := make(map[string]*Rikishi)
+
+	for _, rikishi := range r.s.rikishis {
+		if !overlaps(rikishi.ShikonaHistory, shikonas) {
+			continue
+		}
+
+		rikishi := copyRikishi(rikishi)
+		for _, shikona := range rikishi.ShikonaHistory {
+			rikishis[shikona] = &rikishi
+		}
+	}
+
+	return rikishis, nil
+}
+
+func (r memoryRikishiModel) GetForHeyas(ctx context.Context, heyas []string, limit int) ([]*Rikishi, error) {
+	rikishis := []*Rikishi{}
+
+	for _, heya := range uniqueFold(heyas) {
+		page, _, _ := r.scan("", "", heya, memoryLimitFilters("shikona", limit))
+		rikishis = append(rikishis, page[:min(limit, len(page))]...)
+	}
+
+	sort.Slice(rikishis, func(i, j int) bool {
+		return rikishis[i].Shikona < rikishis[j].Shikona
+	})
+
+	return rikishis, nil
+}
+
+func (r memoryRikishiModel) GetShikonaHistory(ctx context.Context, shikona string) ([]string, error) {
+	if shikona == "" {
+		return []string{}, nil
+	}
+
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var shikonas []string
+
+	for _, rikishi := range r.s.rikishis {
+		if matchesWords(strings.Join(rikishi.ShikonaHistory, ","), shikona) {
+			shikonas = append(shikonas, rikishi.ShikonaHistory...)
+		}
+	}
+
+	return shikonas, nil
+}
+
+func (r memoryRikishiModel) ExistingShikonas(ctx context.Context, shikonas []string) (ShikonaSet, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	set := make(ShikonaSet)
+
+	for _, shikona := range shikonas {
+		if _, ok := r.s.rikishis[shikona]; ok {
+			set[shikona] = true
+		}
+	}
+
+	return set, nil
+}
+
+func (r memoryRikishiModel) Aliases(ctx context.Context) (map[string]string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	aliases := make(map[string]string)
+
+	for shikona, rikishi := range r.s.rikishis {
+		for _, alias := range rikishi.ShikonaHistory {
+			aliases[alias] = shikona
+		}
+		aliases[shikona] = shikona
+	}
+
+	return aliases, nil
+}
+
+func (r memoryRikishiModel) Update(ctx context.Context, rikishi *Rikishi) error {
+	oldShikona := rikishi.ShikonaHistory[len(rikishi.ShikonaHistory)-1]
+	if rikishi.Shikona != oldShikona {
+		rikishi.ShikonaHistory = append(rikishi.ShikonaHistory, rikishi.Shikona)
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	current, ok := r.s.rikishis[oldShikona]
+	if !ok || current.Version != rikishi.Version {
+		return ErrEditConflict
+	}
+
+	if rikishi.Shikona != oldShikona {
+		if _, ok := r.s.rikishis[rikishi.Shikona]; ok {
+			return errMemoryDuplicateKey
+		}
+
+		if r.s.referenced(oldShikona) {
+			return errMemoryForeignKey
+		}
+
+		delete(r.s.rikishis, oldShikona)
+	}
+
+	rikishi.Version++
+	r.s.rikishis[rikishi.Shikona] = copyRikishi(*rikishi)
+
+	return nil
+}
+
+func (r memoryRikishiModel) Delete(ctx context.Context, shikona string, version int32) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	current, ok := r.s.rikishis[shikona]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	if current.Version != version {
+		return ErrEditConflict
+	}
+
+	if r.s.referenced(shikona) {
+		return errMemoryForeignKey
+	}
+
+	delete(r.s.rikishis, shikona)
+
+	return nil
+}
+
+func (r memoryRikishiModel) Exists(ctx context.Context, shikona string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, ok := r.s.rikishis[shikona]
+
+	return ok, nil
+}
+
+type memoryTournamentResultModel struct {
+	s *memoryStore
+}
+
+func (t memoryTournamentResultModel) Insert(ctx context.Context, tr *TournamentResult) error {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	t.insert(tr)
+
+	return t.s.enqueueWebhooks(EventTournamentResultCreated, tr)
+}
+
+func (t memoryTournamentResultModel) InsertBatch(ctx context.Context, trs []*TournamentResult) error {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	for _, tr := range trs {
+		t.insert(tr)
+
+		err := t.s.enqueueWebhooks(EventTournamentResultCreated, tr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t memoryTournamentResultModel) insert(tr *TournamentResult) {
+	tr.ID = t.s.nextID("tournaments_results")
+	tr.Version = 1
+	t.s.tournamentsResults[tr.ID] = *tr
+}
+
+func (t memoryTournamentResultModel) Get(ctx context.Context, id int64) (*TournamentResult, error) {
+	t.s.mu.RLock()
+	defer t.s.mu.RUnlock()
+
+	tr, ok := t.s.tournamentsResults[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	return &tr, nil
+}
+
+func (t memoryTournamentResultModel) GetAll(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, Metadata, error) {
+	trs, keys, total := t.scan(tournament, rank, wins, shikonas, filters)
+
+	trs, metadata := paginate(filters, trs, keys, total)
+
+	return trs, metadata, nil
+}
+
+func (t memoryTournamentResultModel) Stream(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(*TournamentResult) error) error {
+	trs, _, _ := t.scan(tournament, rank, wins, shikonas, filters)
+
+	return memoryStream(ctx, filters, trs, fn)
+}
+
+func (t memoryTournamentResultModel) scan(tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, [][2]string, int) {
+	t.s.mu.RLock()
+	defer t.s.mu.RUnlock()
+
+	trs := []*TournamentResult{}
+
+	for _, tr := range t.s.tournamentsResults {
+		if tournament != "" && !strings.EqualFold(tr.Tournament, tournament) {
+			continue
+		}
+
+		if rank != "" && !strings.EqualFold(tr.Rank, rank) {
+			continue
+		}
+
+		if len(shikonas) > 0 && !contains(shikonas, tr.Rikishi) {
+			continue
+		}
+
+		if int(tr.Wins) < wins {
+			continue
+		}
+
+		tr := tr
+		trs = append(trs, &tr)
+	}
+
+	return memoryScan(filters, trs, func(tr *TournamentResult, column string) interface{} {
+		switch column {
+		case "tournament":
+			return tr.Tournament
+		case "rikishi":
+			return tr.Rikishi
+		case "rank":
+			return tr.Rank
+		case "wins":
+			return int64(tr.Wins)
+		default:
+			return tr.ID
+		}
+	}, func(tr *TournamentResult) interface{} {
+		return tr.ID
+	})
+}
+
+func (t memoryTournamentResultModel) GetForRikishis(ctx context.Context, tournaments, shikonas []string) ([]*TournamentResult, error) {
+	t.s.mu.RLock()
+	defer t.s.mu.RUnlock()
+
+	trs := []*TournamentResult{}
+
+	for _, tr := range t.s.tournamentsResults {
+		if len(tournaments) > 0 && !contains(tournaments, tr.Tournament) {
+			continue
+		}
+
+		if !contains(shikonas, tr.Rikishi) {
+			continue
+		}
+
+		tr := tr
+		trs = append(trs, &tr)
+	}
+
+	sort.Slice(trs, func(i, j int) bool {
+		return trs[i].ID < trs[j].ID
+	})
+
+	return trs, nil
+}
+
+func (t memoryTournamentResultModel) GetForTournaments(ctx context.Context, tournaments []string, rank string, limit int) ([]*TournamentResult, error) {
+	trs := []*TournamentResult{}
+
+	for _, tournament := range uniqueFold(tournaments) {
+		page, _, _ := t.scan(tournament, rank, 0, nil, memoryLimitFilters("id", limit))
+		trs = append(trs, page[:min(limit, len(page))]...)
+	}
+
+	sort.Slice(trs, func(i, j int) bool {
+		return trs[i].ID < trs[j].ID
+	})
+
+	return trs, nil
+}
+
+func (t memoryTournamentResultModel) IsDuplicate(ctx context.Context, tr *TournamentResult) (bool, error) {
+	t.s.mu.RLock()
+	defer t.s.mu.RUnlock()
+
+	for id, existing := range t.s.tournamentsResults {
+		if id != tr.ID && existing.Tournament == tr.Tournament && existing.Rikishi == tr.Rikishi {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (t memoryTournamentResultModel) Update(ctx context.Context, tr *TournamentResult) error {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	current, ok := t.s.tournamentsResults[tr.ID]
+	if !ok || current.Version != tr.Version {
+		return ErrEditConflict
+	}
+
+	tr.Version++
+	t.s.tournamentsResults[tr.ID] = *tr
+
+	return t.s.enqueueWebhooks(EventTournamentResultUpdated, tr)
+}
+
+func (t memoryTournamentResultModel) Delete(ctx context.Context, id int64, version int32) error {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	current, ok := t.s.tournamentsResults[id]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	if current.Version != version {
+		return ErrEditConflict
+	}
+
+	delete(t.s.tournamentsResults, id)
+
+	return t.s.enqueueWebhooks(EventTournamentResultDeleted, &current)
+}
+
+type memoryBoutModel struct {
+	s *memoryStore
+}
+
+func (b memoryBoutModel) Insert(ctx context.Context, bout *Bout) error {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+
+	b.insert(bout)
+
+	return b.s.enqueueWebhooks(EventBoutCreated, bout)
+}
+
+func (b memoryBoutModel) InsertBatch(ctx context.Context, bouts []*Bout) error {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+
+	for _, bout := range bouts {
+		b.insert(bout)
+
+		err := b.s.enqueueWebhooks(EventBoutCreated, bout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b memoryBoutModel) insert(bout *Bout) {
+	bout.ID = b.s.nextID("bouts")
+	bout.Version = 1
+	b.s.bouts[bout.ID] = *bout
+}
+
+func (b memoryBoutModel) Get(ctx context.Context, id int64) (*Bout, error) {
+	b.s.mu.RLock()
+	defer b.s.mu.RUnlock()
+
+	bout, ok := b.s.bouts[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	return &bout, nil
+}
+
+func (b memoryBoutModel) GetAll(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) ([]*Bout, Metadata, error) {
+	bouts, keys, total := b.scan(tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters)
+
+	bouts, metadata := paginate(filters, bouts, keys, total)
+
+	return bouts, metadata, nil
+}
+
+func (b memoryBoutModel) Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(*Bout) error) error {
+	bouts, _, _ := b.scan(tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters)
+
+	return memoryStream(ctx, filters, bouts, fn)
+}
+
+func (b memoryBoutModel) scan(tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) ([]*Bout, [][2]string, int) {
+	b.s.mu.RLock()
+	defer b.s.mu.RUnlock()
+
+	fromDate, _ := tournamentDate(from).(time.Time)
+	toDate, _ := tournamentDate(to).(time.Time)
+
+	bouts := []*Bout{}
+
+	for _, bout := range b.s.bouts {
+		date, _ := tournamentDate(bout.Tournament).(time.Time)
+
+		switch {
+		case tournament != "" && !strings.EqualFold(bout.Tournament, tournament),
+			day != "" && bout.Day != day,
+			division != "" && !strings.EqualFold(bout.Division, division),
+			kimarite != "" && bout.Kimarite != kimarite,
+			len(rikishi1) > 0 && !contains(rikishi1, bout.Winner) && !contains(rikishi1, bout.Loser),
+			len(rikishi2) > 0 && !contains(rikishi2, bout.Winner) && !contains(rikishi2, bout.Loser),
+			len(winner) > 0 && !contains(winner, bout.Winner),
+			len(loser) > 0 && !contains(loser, bout.Loser),
+			!fromDate.IsZero() && date.Before(fromDate),
+			!toDate.IsZero() && date.After(toDate):
+			continue
+		}
+
+		bout := bout
+		bouts = append(bouts, &bout)
+	}
+
+	return memoryScan(filters, bouts, func(bout *Bout, column string) interface{} {
+		switch column {
+		case "tournament":
+			date, _ := tournamentDate(bout.Tournament).(time.Time)
+			return date
+		case "day":
+			if bout.Day == "Playoff" {
+				return int64(16)
+			}
+			day, _ := strconv.ParseInt(bout.Day, 10, 64)
+			return day
+		case "winner":
+			return bout.Winner
+		case "loser":
+			return bout.Loser
+		case "kimarite":
+			return bout.Kimarite
+		default:
+			return bout.ID
+		}
+	}, func(bout *Bout) interface{} {
+		return bout.ID
+	})
+}
+
+func (b memoryBoutModel) GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) ([]*Bout, error) {
+	bouts := []*Bout{}
+
+	for _, tournament := range uniqueFold(tournaments) {
+		page, _, _ := b.scan(tournament, day, division, "", nil, nil, nil, nil, "", "", memoryLimitFilters("id", limit))
+		bouts = append(bouts, page[:min(limit, len(page))]...)
+	}
+
+	sort.Slice(bouts, func(i, j int) bool {
+		return bouts[i].ID < bouts[j].ID
+	})
+
+	return bouts, nil
+}
+
+func (b memoryBoutModel) GetForRikishis(ctx context.Context, groups []BoutGroup, limit int) ([][]*Bout, error) {
+	bouts := make([][]*Bout, len(groups))
+
+	for i, group := range groups {
+		if len(group.Shikonas) == 0 {
+			continue
+		}
+
+		page, _, _ := b.scan(group.Tournament, "", "", "", group.Shikonas, nil, nil, nil, "", "", memoryLimitFilters("-tournament", limit))
+		bouts[i] = page[:min(limit, len(page))]
+	}
+
+	return bouts, nil
+}
+
+func (b memoryBoutModel) IsDuplicate(ctx context.Context, bout *Bout) (bool, error) {
+	b.s.mu.RLock()
+	defer b.s.mu.RUnlock()
+
+	for id, existing := range b.s.bouts {
+		if id == bout.ID || existing.Tournament != bout.Tournament || existing.Day != bout.Day {
+			continue
+		}
+
+		if (existing.Winner == bout.Winner && existing.Loser == bout.Loser) || (existing.Winner == bout.Loser && existing.Loser == bout.Winner) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (b memoryBoutModel) Update(ctx context.Context, bout *Bout) error {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+
+	current, ok := b.s.bouts[bout.ID]
+	if !ok || current.Version != bout.Version {
+		return ErrEditConflict
+	}
+
+	bout.Version++
+	b.s.bouts[bout.ID] = *bout
+
+	return b.s.enqueueWebhooks(EventBoutUpdated, bout)
+}
+
+func (b memoryBoutModel) Delete(ctx context.Context, id int64, version int32) error {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+
+	current, ok := b.s.bouts[id]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	if current.Version != version {
+		return ErrEditConflict
+	}
+
+	delete(b.s.bouts, id)
+
+	return b.s.enqueueWebhooks(EventBoutDeleted, &current)
+}
+
+type memoryUserModel struct {
+	s *memoryStore
+}
+
+func (m memoryUserModel) Insert(ctx context.Context, user *User) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	for _, existing := range m.s.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return ErrDuplicateEmail
+		}
+	}
+
+	user.ID = m.s.nextID("users")
+	user.CreatedAt = time.Now().Truncate(time.Second)
+	user.Version = 1
+	m.s.users[user.ID] = *user
+
+	return nil
+}
+
+func (m memoryUserModel) GetByEmail(ctx context.Context, email string) (*User, error) {
+	m.s.mu.RLock()
+	defer m.s.mu.RUnlock()
+
+	for _, user := range m.s.users {
+		if strings.EqualFold(user.Email, email) {
+			user.Password.plainText = nil
+			return &user, nil
+		}
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+func (m memoryUserModel) Update(ctx context.Context, user *User) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	for id, existing := range m.s.users {
+		if id != user.ID && strings.EqualFold(existing.Email, user.Email) {
+			return ErrDuplicateEmail
+		}
+	}
+
+	current, ok := m.s.users[user.ID]
+	if !ok || current.Version != user.Version {
+		return ErrEditConflict
+	}
+
+	user.Version++
+	m.s.users[user.ID] = *user
+
+	return nil
+}
+
+type memorySearchModel struct {
+	s *memoryStore
+}
+
+func (m memorySearchModel) Search(ctx context.Context, query string, kinds []string, limit int) ([]*SearchHit, error) {
+	m.s.mu.RLock()
+	defer m.s.mu.RUnlock()
+
+	terms := []searchTerm{}
+
+	for _, rikishi := range m.s.rikishis {
+		for _, name := range rikishi.ShikonaHistory {
+			terms = append(terms, searchTerm{SearchKindRikishi, name, rikishi.Shikona, name})
+		}
+
+		terms = append(terms,
+			searchTerm{SearchKindRikishi, rikishi.Shikona, rikishi.Shikona, rikishi.Shikona},
+			searchTerm{SearchKindRikishi, rikishi.Shikona, rikishi.Shikona, rikishi.ShikonaKanji},
+			searchTerm{SearchKindRikishi, rikishi.Shikona, rikishi.Shikona, rikishi.ShikonaKana},
+			searchTerm{SearchKindHeya, rikishi.Heya, "", rikishi.Heya},
+			searchTerm{SearchKindHeya, rikishi.Heya, "", rikishi.HeyaKanji},
+			searchTerm{SearchKindHeya, rikishi.Heya, "", rikishi.HeyaKana},
+		)
+	}
+
+	for _, bout := range m.s.bouts {
+		terms = append(terms, kimariteSearchTerms(bout.Kimarite)...)
+	}
+
+	return rankSearchTerms(terms, query, kinds, limit), nil
+}
+
+type memoryWebhookModel struct {
+	s *memoryStore
+}
+
+func (m memoryWebhookModel) Insert(ctx context.Context, webhook *Webhook) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	webhook.ID = m.s.nextID("webhooks")
+	webhook.CreatedAt = time.Now().Truncate(time.Second)
+	webhook.Version = 1
+	m.s.webhooks[webhook.ID] = copyWebhook(*webhook)
+
+	return nil
+}
+
+func (m memoryWebhookModel) Get(ctx context.Context, id int64, owner string) (*Webhook, error) {
+	webhook, err := m.GetForDelivery(ctx, id)
+	if err != nil || webhook.Owner != owner {
+		return nil, ErrRecordNotFound
+	}
+
+	return webhook, nil
+}
+
+func (m memoryWebhookModel) GetForDelivery(ctx context.Context, id int64) (*Webhook, error) {
+	m.s.mu.RLock()
+	defer m.s.mu.RUnlock()
+
+	webhook, ok := m.s.webhooks[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	webhook = copyWebhook(webhook)
+
+	return &webhook, nil
+}
+
+func (m memoryWebhookModel) GetAll(ctx context.Context, owner string, filters Filters) ([]*Webhook, Metadata, error) {
+	m.s.mu.RLock()
+	defer m.s.mu.RUnlock()
+
+	webhooks := []*Webhook{}
+
+	for _, webhook := range m.s.webhooks {
+		if webhook.Owner != owner {
+			continue
+		}
+
+		webhook = copyWebhook(webhook)
+		webhooks = append(webhooks, &webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+
+	webhooks, metadata := memoryPage(filters, webhooks)
+
+	return webhooks, metadata, nil
+}
+
+func (m memoryWebhookModel) Update(ctx context.Context, webhook *Webhook) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	current, ok := m.s.webhooks[webhook.ID]
+	if !ok || current.Owner != webhook.Owner || current.Version != webhook.Version {
+		return ErrEditConflict
+	}
+
+	webhook.Version++
+	m.s.webhooks[webhook.ID] = copyWebhook(*webhook)
+
+	return nil
+}
+
+func (m memoryWebhookModel) Delete(ctx context.Context, id int64, owner string) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	if webhook, ok := m.s.webhooks[id]; !ok || webhook.Owner != owner {
+		return ErrRecordNotFound
+	}
+
+	delete(m.s.webhooks, id)
+
+	for deliveryID, delivery := range m.s.deliveries {
+		if delivery.WebhookID == id {
+			delete(m.s.deliveries, deliveryID)
+		}
+	}
+
+	return nil
+}
+
+// enqueueWebhooks queues a delivery of record for every active webhook
+// subscribed to event. Callers hold the write lock, so the deliveries are
+// queued together with the change.
+func (s *memoryStore) enqueueWebhooks(event string, record interface{}) error {
+	payload, err := webhookPayload(event, record)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	for _, webhook := range s.webhooks {
+		if !webhook.Active || !contains(webhook.Events, event) {
+			continue
+		}
+
+		id := s.nextID("webhook_deliveries")
+		s.deliveries[id] = WebhookDelivery{
+			ID:            id,
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+	}
+
+	return nil
+}
+
+func (m memoryWebhookModel) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	deliveries := []*WebhookDelivery{}
+
+	for _, delivery := range m.s.deliveries {
+		if delivery.Status == DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			delivery := delivery
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].NextAttemptAt.Equal(deliveries[j].NextAttemptAt) {
+			return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	for _, delivery := range deliveries {
+		delivery.NextAttemptAt = now.Add(lease).Truncate(time.Second)
+		m.s.deliveries[delivery.ID] = *delivery
+	}
+
+	return deliveries, nil
+}
+
+func (m memoryWebhookModel) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	if _, ok := m.s.deliveries[delivery.ID]; !ok {
+		return ErrRecordNotFound
+	}
+
+	delivery.NextAttemptAt = delivery.NextAttemptAt.Truncate(time.Second)
+	delivery.UpdatedAt = time.Now().Truncate(time.Second)
+	m.s.deliveries[delivery.ID] = *delivery
+
+	return nil
+}
+
+func (m memoryWebhookModel) GetDeliveries(ctx context.Context, webhookID int64, owner string, filters Filters) ([]*WebhookDelivery, Metadata, error) {
+	m.s.mu.RLock()
+	defer m.s.mu.RUnlock()
+
+	deliveries := []*WebhookDelivery{}
+
+	if webhook, ok := m.s.webhooks[webhookID]; !ok || webhook.Owner != owner {
+		return deliveries, calculateMetadata(0, filters.Page, filters.PageSize), nil
+	}
+
+	for _, delivery := range m.s.deliveries {
+		if delivery.WebhookID == webhookID {
+			delivery := delivery
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+
+	deliveries, metadata := memoryPage(filters, deliveries)
+
+	return deliveries, metadata, nil
+}
+
+// memoryPage returns the page of sorted items selected by filters.Page.
+type memoryImportModel struct {
+	s *memoryStore
+}
+
+// Import holds the lock for the whole batch and restores the tables it writes
+// to if fn fails.
+func (m memoryImportModel) Import(ctx context.Context, fn func(tx ImportTx) error) error {
+	m.s.mu.Lock()
+	defer m.s.mu.Unlock()
+
+	rikishis := maps.Clone(m.s.rikishis)
+	tournamentsResults := maps.Clone(m.s.tournamentsResults)
+	bouts := maps.Clone(m.s.bouts)
+	sequences := maps.Clone(m.s.sequences)
+
+	err := fn(memoryImportTx{m.s})
+	if err != nil {
+		m.s.rikishis = rikishis
+		m.s.tournamentsResults = tournamentsResults
+		m.s.bouts = bouts
+		m.s.sequences = sequences
+	}
+
+	return err
+}
+
+type memoryImportTx struct {
+	s *memoryStore
+}
+
+func (t memoryImportTx) InsertRikishi(ctx context.Context, rikishi *Rikishi) error {
+	return memoryRikishiModel{t.s}.insert(rikishi)
+}
+
+func (t memoryImportTx) InsertTournamentResult(ctx context.Context, tr *TournamentResult) error {
+	memoryTournamentResultModel{t.s}.insert(tr)
+	return nil
+}
+
+func (t memoryImportTx) InsertBout(ctx context.Context, bout *Bout) error {
+	memoryBoutModel{t.s}.insert(bout)
+	return nil
+}
+
+type memoryRateLimitModel struct {
+	mu   sync.Mutex
+	tats map[string]int64
+}
+
+// NewMemoryRateLimitStore keeps buckets in process, for deployments with a
+// single replica.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitModel{tats: make(map[string]int64)}
+}
+
+func (m *memoryRateLimitModel) Allow(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tat, result := limit.allow(m.tats[key], now)
+	m.tats[key] = tat
+
+	return result, nil
+}
+
+func (m *memoryRateLimitModel) Refund(ctx context.Context, key string, limit RateLimit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tats[key]; ok {
+		m.tats[key] -= limit.interval()
+	}
+
+	return nil
+}
+
+func (m *memoryRateLimitModel) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, tat := range m.tats {
+		if tat < now.UnixNano() {
+			delete(m.tats, key)
+		}
+	}
+
+	return nil
+}
+
+func memoryPage[T any](f Filters, items []T) ([]T, Metadata) {
+	metadata := calculateMetadata(len(items), f.Page, f.PageSize)
+
+	start := min(f.offset(), len(items))
+	end := min(start+f.PageSize, len(items))
+
+	return items[start:end], metadata
+}
+
+func memoryScan[T any](f Filters, items []T, sortValue func(T, string) interface{}, keyValue func(T) interface{}) ([]T, [][2]string, int) {
+	column := f.SortColumn()
+	descending := f.sortDirection() == "DESC"
+
+	c, _ := decodeCursor(f.Cursor)
+	if c.Backward {
+		descending = !descending
+	}
+
+	less := func(sortA, keyA, sortB, keyB interface{}) bool {
+		if cmp := compareValues(sortA, sortB); cmp != 0 {
+			return (cmp < 0) != descending
+		}
+
+		if c.Backward {
+			return compareValues(keyA, keyB) > 0
+		}
+
+		return compareValues(keyA, keyB) < 0
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return less(sortValue(items[i], column), keyValue(items[i]), sortValue(items[j], column), keyValue(items[j]))
+	})
+
+	total := len(items)
+
+	if f.Cursor != "" {
+		var after []T
+
+		for _, item := range items {
+			sv, kv := sortValue(item, column), keyValue(item)
+			if less(parseLike(sv, c.Values[0]), parseLike(kv, c.Values[1]), sv, kv) {
+				after = append(after, item)
+			}
+		}
+
+		items = after
+	}
+
+	if offset := f.offset(); offset < len(items) {
+		items = items[offset:]
+	} else {
+		items = nil
+	}
+
+	if !f.Unlimited && len(items) > f.PageSize+1 {
+		items = items[:f.PageSize+1]
+	}
+
+	page := make([]T, len(items))
+	keys := make([][2]string, len(items))
+
+	for i, item := range items {
+		page[i] = item
+		keys[i] = [2]string{formatValue(sortValue(item, column)), formatValue(keyValue(item))}
+	}
+
+	return page, keys, total
+}
+
+// memoryLimitFilters reads the first limit records in sort order, for the
+// batched lookups which apply a limit per parent.
+func memoryLimitFilters(sort string, limit int) Filters {
+	return Filters{Page: 1, PageSize: limit, Sort: sort, SortSafelist: []string{sort}}
+}
+
+func uniqueFold(values []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+
+	for _, value := range values {
+		if key := strings.ToLower(value); !seen[key] {
+			seen[key] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
+
+func memoryStream[T any](ctx context.Context, f Filters, items []T, fn func(T) error) error {
+	// memoryScan sorts a backward read in reverse. The database stores only do
+	// that for limited pages, so unlimited reads are put back in order here.
+	if f.Unlimited && f.backward() {
+		slices.Reverse(items)
+	}
+
+	streamer := newPageStreamer(f, fn)
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := streamer.add(item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return streamer.flush()
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b, _ := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		b, _ := b.(time.Time)
+		return a.Compare(b)
+	default:
+		as, _ := a.(string)
+		bs, _ := b.(string)
+		return strings.Compare(as, bs)
+	}
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		s, _ := v.(string)
+		return s
+	}
+}
+
+func parseLike(sample interface{}, s string) interface{} {
+	switch sample.(type) {
+	case int64:
+		n, _ := strconv.ParseInt(s, 10, 64)
+		return n
+	case time.Time:
+		t, _ := time.Parse("2006-01-02", s)
+		return t
+	default:
+		return s
+	}
+}
+
+func matchesWords(text, query string) bool {
+	split := func(s string) []string {
+		return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+	}
+
+	words := split(text)
+
+	for _, term := range split(query) {
+		if !contains(words, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func overlaps(a, b []string) bool {
+	for _, v := range a {
+		if contains(b, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func copyRikishi(rikishi Rikishi) Rikishi {
+	rikishi.ShikonaHistory = append([]string(nil), rikishi.ShikonaHistory...)
+	return rikishi
+}
+
+func copyWebhook(webhook Webhook) Webhook {
+	webhook.Events = append([]string(nil), webhook.Events...)
+	return webhook
+}
@@ -1,8 +1,16 @@
 package data
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 var (
@@ -10,18 +18,217 @@ var (
 	ErrEditConflict   = errors.New("edit conflict")
 )
 
+// Timeouts bounds the queries run by the database models, on top of any
+// deadline already set on the caller's context. Batch covers batch inserts and
+// full-table reads. A zero duration leaves the caller's deadline as the only
+// limit.
+type Timeouts struct {
+	Read   time.Duration
+	Write  time.Duration
+	Batch  time.Duration
+	Search time.Duration
+}
+
+var DefaultTimeouts = Timeouts{
+	Read:   3 * time.Second,
+	Write:  3 * time.Second,
+	Batch:  10 * time.Second,
+	Search: 3 * time.Second,
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// withTx runs fn in a transaction, which is committed if fn succeeds and rolled
+// back otherwise.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// missedDelete explains why a delete matching on both key and version found no
+// row: ErrRecordNotFound if the row is gone, and ErrEditConflict if it has a
+// different version.
+func missedDelete(ctx context.Context, q rowQuerier, table, column string, key interface{}) error {
+	var exists bool
+
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s = $1)`, table, column)
+
+	err := q.QueryRowContext(ctx, query, key).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ErrRecordNotFound
+	}
+
+	return ErrEditConflict
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+
+	return lowered
+}
+
+// IsCanceled reports whether err means a query was stopped because its
+// context was canceled or its deadline passed. The drivers report this with
+// their own errors rather than the context's.
+func IsCanceled(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Name() == "query_canceled"
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_INTERRUPT
+	}
+
+	return false
+}
+
+type ShikonaChecker interface {
+	Exists(ctx context.Context, shikona string) (bool, error)
+}
+
+type ShikonaSet map[string]bool
+
+func (s ShikonaSet) Exists(ctx context.Context, shikona string) (bool, error) {
+	return s[shikona], nil
+}
+
+type RikishiStore interface {
+	ShikonaChecker
+	Insert(ctx context.Context, rikishi *Rikishi) error
+	Get(ctx context.Context, shikona string) (*Rikishi, error)
+	GetAll(ctx context.Context, shikona, highestRank, heya string, filters Filters) ([]*Rikishi, Metadata, error)
+	Stream(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(*Rikishi) error) error
+	GetByShikonas(ctx context.Context, shikonas []string) (map[string]*Rikishi, error)
+	GetForHeyas(ctx context.Context, heyas []string, limit int) ([]*Rikishi, error)
+	GetShikonaHistory(ctx context.Context, shikona string) ([]string, error)
+	ExistingShikonas(ctx context.Context, shikonas []string) (ShikonaSet, error)
+	Aliases(ctx context.Context) (map[string]string, error)
+	Update(ctx context.Context, rikishi *Rikishi) error
+	Delete(ctx context.Context, shikona string, version int32) error
+}
+
+type TournamentResultStore interface {
+	Insert(ctx context.Context, tr *TournamentResult) error
+	InsertBatch(ctx context.Context, trs []*TournamentResult) error
+	Get(ctx context.Context, id int64) (*TournamentResult, error)
+	GetAll(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, Metadata, error)
+	Stream(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(*TournamentResult) error) error
+	GetForRikishis(ctx context.Context, tournaments, shikonas []string) ([]*TournamentResult, error)
+	GetForTournaments(ctx context.Context, tournaments []string, rank string, limit int) ([]*TournamentResult, error)
+	IsDuplicate(ctx context.Context, tr *TournamentResult) (bool, error)
+	Update(ctx context.Context, tr *TournamentResult) error
+	Delete(ctx context.Context, id int64, version int32) error
+}
+
+type BoutStore interface {
+	Insert(ctx context.Context, bout *Bout) error
+	InsertBatch(ctx context.Context, bouts []*Bout) error
+	Get(ctx context.Context, id int64) (*Bout, error)
+	GetAll(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) ([]*Bout, Metadata, error)
+	Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(*Bout) error) error
+	GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) ([]*Bout, error)
+	GetForRikishis(ctx context.Context, groups []BoutGroup, limit int) ([][]*Bout, error)
+	IsDuplicate(ctx context.Context, bout *Bout) (bool, error)
+	Update(ctx context.Context, bout *Bout) error
+	Delete(ctx context.Context, id int64, version int32) error
+}
+
+type UserStore interface {
+	Insert(ctx context.Context, user *User) error
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	Update(ctx context.Context, user *User) error
+}
+
+// WebhookStore holds webhook subscriptions and their deliveries. Webhooks
+// belong to the API key that created them, and every method taking an owner
+// only sees that key's webhooks.
+type WebhookStore interface {
+	Insert(ctx context.Context, webhook *Webhook) error
+	Get(ctx context.Context, id int64, owner string) (*Webhook, error)
+	GetForDelivery(ctx context.Context, id int64) (*Webhook, error)
+	GetAll(ctx context.Context, owner string, filters Filters) ([]*Webhook, Metadata, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id int64, owner string) error
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookID int64, owner string, filters Filters) ([]*WebhookDelivery, Metadata, error)
+}
+
+// ImportTx inserts rows as part of an import. Unlike the stores' inserts, it
+// does not queue webhook deliveries.
+type ImportTx interface {
+	InsertRikishi(ctx context.Context, rikishi *Rikishi) error
+	InsertTournamentResult(ctx context.Context, tr *TournamentResult) error
+	InsertBout(ctx context.Context, bout *Bout) error
+}
+
+// ImportStore runs fn in a transaction, which is committed if fn succeeds and
+// rolled back otherwise.
+type ImportStore interface {
+	Import(ctx context.Context, fn func(tx ImportTx) error) error
+}
+
+// RateLimitStore holds the rate limiter's buckets. A store shared through the
+// database applies one quota across every replica.
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+	Refund(ctx context.Context, key string, limit RateLimit) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
 type Models struct {
-	Rikishis           RikishiModel
-	TournamentsResults TournamentResultModel
-	Bouts              BoutModel
-	Users              UserModel
+	Rikishis           RikishiStore
+	TournamentsResults TournamentResultStore
+	Bouts              BoutStore
+	Users              UserStore
+	Search             SearchStore
+	Webhooks           WebhookStore
+	RateLimits         RateLimitStore
+	Imports            ImportStore
 }
 
-func NewModels(db *sql.DB) Models {
+func NewModels(db *sql.DB, timeouts Timeouts) Models {
 	return Models{
-		Rikishis:           RikishiModel{DB: db},
-		TournamentsResults: TournamentResultModel{DB: db},
-		Bouts:              BoutModel{DB: db},
-		Users:              UserModel{DB: db},
+		Rikishis:           RikishiModel{DB: db, Timeouts: timeouts},
+		TournamentsResults: TournamentResultModel{DB: db, Timeouts: timeouts},
+		Bouts:              BoutModel{DB: db, Timeouts: timeouts},
+		Users:              UserModel{DB: db, Timeouts: timeouts},
+		Search:             SearchModel{DB: db, Timeouts: timeouts},
+		Webhooks:           WebhookModel{DB: db, Timeouts: timeouts},
+		RateLimits:         RateLimitModel{DB: db, Timeouts: timeouts},
+		Imports:            ImportModel{DB: db},
 	}
 }
@@ -0,0 +1,133 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// RateLimit allows Burst requests at once, refilled at Rate per second.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Buckets are kept as the theoretical arrival time (TAT) of the generic cell
+// rate algorithm: the time at which the bucket would be full again. A single
+// timestamp per key is what lets the database stores update a bucket in one
+// statement.
+func (l RateLimit) interval() int64 {
+	return int64(float64(time.Second) / l.Rate)
+}
+
+func (l RateLimit) capacity() int64 {
+	return l.interval() * int64(l.Burst)
+}
+
+// allow applies one request at now to a bucket with the given TAT, returning
+// the bucket's new TAT.
+func (l RateLimit) allow(tat int64, now time.Time) (int64, RateLimitResult) {
+	n := now.UnixNano()
+	tat = max(tat, n)
+
+	allowAt := tat + l.interval() - l.capacity()
+	if allowAt > n {
+		return tat, RateLimitResult{
+			Limit:      l.Burst,
+			Reset:      time.Duration(tat - n),
+			RetryAfter: time.Duration(allowAt - n),
+		}
+	}
+
+	return tat + l.interval(), l.allowed(tat+l.interval(), now)
+}
+
+func (l RateLimit) allowed(tat int64, now time.Time) RateLimitResult {
+	n := now.UnixNano()
+
+	return RateLimitResult{
+		Allowed:   true,
+		Limit:     l.Burst,
+		Remaining: int((n - (tat - l.capacity())) / l.interval()),
+		Reset:     time.Duration(tat - n),
+	}
+}
+
+type RateLimitModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+// Allow takes a request from the bucket for key. The update only happens when
+// the request is allowed, so a rejected request reads the bucket afterwards to
+// say when to retry.
+func (m RateLimitModel) Allow(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	query := `
+		INSERT INTO rate_limits AS l (key, tat)
+		VALUES ($1, $2::bigint + $3)
+		ON CONFLICT (key) DO UPDATE
+		SET tat = GREATEST(l.tat, $2) + $3
+		WHERE GREATEST(l.tat, $2) + $3 - $4 <= $2
+		RETURNING tat`
+
+	return allowRateLimit(ctx, m.DB, m.Timeouts, query, key, limit, now)
+}
+
+func (m RateLimitModel) Refund(ctx context.Context, key string, limit RateLimit) error {
+	return refundRateLimit(ctx, m.DB, m.Timeouts, key, limit)
+}
+
+func (m RateLimitModel) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM rate_limits WHERE tat < $1`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Batch)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, query, now.UnixNano())
+	return err
+}
+
+func allowRateLimit(ctx context.Context, db *sql.DB, timeouts Timeouts, query, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	ctx, cancel := withTimeout(ctx, timeouts.Write)
+	defer cancel()
+
+	var tat int64
+
+	err := db.QueryRowContext(ctx, query, key, now.UnixNano(), limit.interval(), limit.capacity()).Scan(&tat)
+	if err == nil {
+		return limit.allowed(tat, now), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return RateLimitResult{}, err
+	}
+
+	err = db.QueryRowContext(ctx, `SELECT tat FROM rate_limits WHERE key = $1`, key).Scan(&tat)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	_, result := limit.allow(tat, now)
+
+	return result, nil
+}
+
+// refundRateLimit gives back a request taken by Allow, for when a later bucket
+// rejects it. The query is the same in both databases.
+func refundRateLimit(ctx context.Context, db *sql.DB, timeouts Timeouts, key string, limit RateLimit) error {
+	query := `UPDATE rate_limits SET tat = tat - $2 WHERE key = $1`
+
+	ctx, cancel := withTimeout(ctx, timeouts.Write)
+	defer cancel()
+
+	_, err := db.ExecContext(ctx, query, key, limit.interval())
+	return err
+}
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/corsairconstantine/sumodb/internal/validator"
 	"github.com/lib/pq"
@@ -16,40 +15,51 @@ type Rikishi struct {
 	HighestRank    string   `json:"highest_rank"`
 	Heya           string   `json:"heya"`
 	ShikonaHistory []string `json:"shikona_history"`
+	ShikonaKanji   string   `json:"shikona_kanji"`
+	ShikonaKana    string   `json:"shikona_kana"`
+	HeyaKanji      string   `json:"heya_kanji"`
+	HeyaKana       string   `json:"heya_kana"`
 	Version        int32    `json:"version"`
 }
 
 type RikishiModel struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts Timeouts
 }
 
-func (r RikishiModel) Insert(rikishi *Rikishi) error {
-	query := `
-		INSERT INTO rikishis (shikona, highest_rank, heya, shikona_history)
-		VALUES ($1, $2, $3, $4)
-		RETURNING version`
+const insertRikishiQuery = `
+	INSERT INTO rikishis (shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING version`
 
-	args := []interface{}{rikishi.Shikona, rikishi.HighestRank, rikishi.Heya, pq.Array(rikishi.ShikonaHistory)}
+func (r RikishiModel) Insert(ctx context.Context, rikishi *Rikishi) error {
+	args := []interface{}{rikishi.Shikona, rikishi.HighestRank, rikishi.Heya, pq.Array(rikishi.ShikonaHistory), rikishi.ShikonaKanji, rikishi.ShikonaKana, rikishi.HeyaKanji, rikishi.HeyaKana}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
 	defer cancel()
 
-	return r.DB.QueryRowContext(ctx, query, args...).Scan(&rikishi.Version)
+	return r.DB.QueryRowContext(ctx, insertRikishiQuery, args...).Scan(&rikishi.Version)
+}
+
+func (r RikishiModel) InsertTx(ctx context.Context, tx *sql.Tx, rikishi *Rikishi) error {
+	args := []interface{}{rikishi.Shikona, rikishi.HighestRank, rikishi.Heya, pq.Array(rikishi.ShikonaHistory), rikishi.ShikonaKanji, rikishi.ShikonaKana, rikishi.HeyaKanji, rikishi.HeyaKana}
+
+	return tx.QueryRowContext(ctx, insertRikishiQuery, args...).Scan(&rikishi.Version)
 }
 
-func (r RikishiModel) Get(shikona string) (*Rikishi, error) {
+func (r RikishiModel) Get(ctx context.Context, shikona string) (*Rikishi, error) {
 	if shikona == "" {
 		return nil, ErrRecordNotFound
 	}
 
 	query := `
-		SELECT shikona, highest_rank, heya, shikona_history, version
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
 		FROM rikishis
 		WHERE shikona = $1`
 
 	var rikishi Rikishi
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
 	defer cancel()
 
 	err := r.DB.QueryRowContext(ctx, query, shikona).Scan(
@@ -57,6 +67,10 @@ func (r RikishiModel) Get(shikona string) (*Rikishi, error) {
 		&rikishi.HighestRank,
 		&rikishi.Heya,
 		pq.Array(&rikishi.ShikonaHistory),
+		&rikishi.ShikonaKanji,
+		&rikishi.ShikonaKana,
+		&rikishi.HeyaKanji,
+		&rikishi.HeyaKana,
 		&rikishi.Version,
 	)
 
@@ -72,63 +86,250 @@ func (r RikishiModel) Get(shikona string) (*Rikishi, error) {
 	return &rikishi, nil
 }
 
-func (r RikishiModel) GetAll(shikona, highestRank, heya string, filters Filters) ([]*Rikishi, Metadata, error) {
+func (r RikishiModel) GetAll(ctx context.Context, shikona, highestRank, heya string, filters Filters) ([]*Rikishi, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	totalRecords := 0
+	rikishis := []*Rikishi{}
+	keys := [][2]string{}
+
+	err := r.scan(ctx, shikona, highestRank, heya, filters, func(total int, sortKey string, rikishi *Rikishi) error {
+		totalRecords = total
+		rikishis = append(rikishis, rikishi)
+		keys = append(keys, [2]string{sortKey, rikishi.Shikona})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	rikishis, metadata := paginate(filters, rikishis, keys, totalRecords)
+
+	return rikishis, metadata, nil
+}
+
+func (r RikishiModel) Stream(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(*Rikishi) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := r.scan(ctx, shikona, highestRank, heya, filters, func(_ int, _ string, rikishi *Rikishi) error {
+		return streamer.add(rikishi)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (r RikishiModel) scan(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(totalRecords int, sortKey string, rikishi *Rikishi) error) error {
+	keyset, keysetArgs := filters.keysetCondition(filters.SortColumn(), "shikona", 6)
+
 	query := fmt.Sprintf(`
-		SELECT count(*) OVER(), shikona, highest_rank, heya, shikona_history, version
-		FROM rikishis
-		WHERE (array_to_string(shikona_history, ',') @@ plainto_tsquery('simple', $1) OR $1 = '')
-		AND (LOWER(highest_rank) = LOWER($2) OR $2 = '')
-		AND (LOWER(heya) = LOWER($3) OR $3 = '')
-		ORDER BY %s %s, shikona ASC
-		LIMIT $4 OFFSET $5`, filters.SortColumn(), filters.sortDirection())
+		SELECT total, sort_key, shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM (
+			SELECT %s AS total, %s::text AS sort_key, shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+			FROM rikishis
+			WHERE (array_to_string(shikona_history, ',') @@ plainto_tsquery('simple', $1) OR $1 = '')
+			AND (LOWER(highest_rank) = LOWER($2) OR $2 = '')
+			AND (LOWER(heya) = LOWER($3) OR $3 = '')
+		) AS rikishis
+		WHERE %s
+		ORDER BY %s
+		LIMIT $4 OFFSET $5`, filters.totalColumn(), filters.SortColumn(), keyset, filters.orderBy(filters.SortColumn(), "shikona"))
 
 	//need a custom sort by rank: yokozuna->ozeki->sekiwake->komusubi->maegashira->
 	//juryo->makushita->sandanme->jonidan->jonokuchi->mae-zumo
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
 	args := []interface{}{shikona, highestRank, heya, filters.limit(), filters.offset()}
+	args = append(args, keysetArgs...)
 
 	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, Metadata{}, err
+		return err
 	}
 
 	defer rows.Close()
 
-	totalRecords := 0
-	rikishis := []*Rikishi{}
-
 	for rows.Next() {
 		var rikishi Rikishi
+		var totalRecords int
+		var sortKey string
 
 		err := rows.Scan(
 			&totalRecords,
+			&sortKey,
 			&rikishi.Shikona,
 			&rikishi.HighestRank,
 			&rikishi.Heya,
 			pq.Array(&rikishi.ShikonaHistory),
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
 			&rikishi.Version,
 		)
 
 		if err != nil {
-			return nil, Metadata{}, err
+			return err
 		}
 
-		rikishis = append(rikishis, &rikishi)
+		err = fn(totalRecords, sortKey, &rikishi)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r RikishiModel) GetByShikonas(ctx context.Context, shikonas []string) (map[string]*Rikishi, error) {
+	rikishis := make(map[string]*Rikishi)
+
+	if len(shikonas) == 0 {
+		return rikishis, nil
+	}
+
+	query := `
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM rikishis
+		WHERE shikona_history && $1`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(shikonas))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rikishi Rikishi
+
+		err := rows.Scan(
+			&rikishi.Shikona,
+			&rikishi.HighestRank,
+			&rikishi.Heya,
+			pq.Array(&rikishi.ShikonaHistory),
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
+			&rikishi.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, shikona := range rikishi.ShikonaHistory {
+			rikishis[shikona] = &rikishi
+		}
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, Metadata{}, err
+		return nil, err
 	}
 
-	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+	return rikishis, nil
+}
 
-	return rikishis, metadata, nil
+func (r RikishiModel) Aliases(ctx context.Context) (map[string]string, error) {
+	query := `
+		SELECT shikona, shikona_history
+		FROM rikishis`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Batch)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make(map[string]string)
+
+	for rows.Next() {
+		var shikona string
+		var history []string
+
+		err := rows.Scan(&shikona, pq.Array(&history))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alias := range history {
+			aliases[alias] = shikona
+		}
+		aliases[shikona] = shikona
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// GetForHeyas returns up to limit rikishis of each heya, matched case
+// insensitively and ordered by shikona, in a single query.
+func (r RikishiModel) GetForHeyas(ctx context.Context, heyas []string, limit int) ([]*Rikishi, error) {
+	if len(heyas) == 0 {
+		return []*Rikishi{}, nil
+	}
+
+	query := `
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM (
+			SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version,
+				row_number() OVER (PARTITION BY LOWER(heya) ORDER BY shikona) AS n
+			FROM rikishis
+			WHERE LOWER(heya) = ANY($1)
+		) AS rikishis
+		WHERE n <= $2
+		ORDER BY shikona`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(lowerAll(heyas)), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rikishis := []*Rikishi{}
+
+	for rows.Next() {
+		var rikishi Rikishi
+
+		err := rows.Scan(
+			&rikishi.Shikona,
+			&rikishi.HighestRank,
+			&rikishi.Heya,
+			pq.Array(&rikishi.ShikonaHistory),
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
+			&rikishi.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rikishis = append(rikishis, &rikishi)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rikishis, nil
 }
 
-func (r RikishiModel) GetShikonaHistory(shikona string) ([]string, error) {
+func (r RikishiModel) GetShikonaHistory(ctx context.Context, shikona string) ([]string, error) {
 	if shikona == "" {
 		return []string{}, nil
 	}
@@ -138,7 +339,10 @@ func (r RikishiModel) GetShikonaHistory(shikona string) ([]string, error) {
 		FROM rikishis
 		WHERE array_to_string(shikona_history, ',') @@ plainto_tsquery('simple', $1)`
 
-	rows, err := r.DB.Query(query, shikona)
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, shikona)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -167,15 +371,16 @@ func (r RikishiModel) GetShikonaHistory(shikona string) ([]string, error) {
 	return shikonas, nil
 }
 
-func (r RikishiModel) Update(rikishi *Rikishi) error {
+func (r RikishiModel) Update(ctx context.Context, rikishi *Rikishi) error {
 	var oldShikona string = rikishi.ShikonaHistory[len(rikishi.ShikonaHistory)-1]
 	if rikishi.Shikona != oldShikona {
 		rikishi.ShikonaHistory = append(rikishi.ShikonaHistory, rikishi.Shikona)
 	}
 	query := `
 		UPDATE rikishis
-		SET shikona = $1, highest_rank = $2, heya = $3, shikona_history = $4, version = version + 1
-		WHERE shikona = $5 AND version = $6
+		SET shikona = $1, highest_rank = $2, heya = $3, shikona_history = $4,
+			shikona_kanji = $5, shikona_kana = $6, heya_kanji = $7, heya_kana = $8, version = version + 1
+		WHERE shikona = $9 AND version = $10
 		RETURNING version`
 
 	args := []interface{}{
@@ -183,11 +388,15 @@ func (r RikishiModel) Update(rikishi *Rikishi) error {
 		rikishi.HighestRank,
 		rikishi.Heya,
 		pq.Array(rikishi.ShikonaHistory),
+		rikishi.ShikonaKanji,
+		rikishi.ShikonaKana,
+		rikishi.HeyaKanji,
+		rikishi.HeyaKana,
 		oldShikona,
 		rikishi.Version,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
 	defer cancel()
 
 	err := r.DB.QueryRowContext(ctx, query, args...).Scan(&rikishi.Version)
@@ -203,19 +412,19 @@ func (r RikishiModel) Update(rikishi *Rikishi) error {
 	return nil
 }
 
-func (r RikishiModel) Delete(shikona string) error {
+func (r RikishiModel) Delete(ctx context.Context, shikona string, version int32) error {
 	if shikona == "" {
 		return ErrRecordNotFound
 	}
 
 	query := `
 		DELETE FROM rikishis
-		WHERE shikona = $1`
+		WHERE shikona = $1 AND version = $2`
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
 	defer cancel()
 
-	result, err := r.DB.ExecContext(ctx, query, shikona)
+	result, err := r.DB.ExecContext(ctx, query, shikona, version)
 	if err != nil {
 		return err
 	}
@@ -226,18 +435,61 @@ func (r RikishiModel) Delete(shikona string) error {
 	}
 
 	if rowsAffected == 0 {
-		return ErrRecordNotFound
+		return missedDelete(ctx, r.DB, "rikishis", "shikona", shikona)
 	}
 
 	return nil
 }
 
-func (r RikishiModel) Exists(shikona string) bool {
+func (r RikishiModel) ExistingShikonas(ctx context.Context, shikonas []string) (ShikonaSet, error) {
+	set := make(ShikonaSet)
+
+	if len(shikonas) == 0 {
+		return set, nil
+	}
+
+	query := `
+		SELECT shikona
+		FROM rikishis
+		WHERE shikona = ANY($1)`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(shikonas))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shikona string
+
+		err := rows.Scan(&shikona)
+		if err != nil {
+			return nil, err
+		}
+
+		set[shikona] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (r RikishiModel) Exists(ctx context.Context, shikona string) (bool, error) {
 	var exists bool
 	query := `SELECT exists (SELECT true FROM rikishis WHERE shikona = $1)`
-	r.DB.QueryRow(query, shikona).Scan(&exists)
 
-	return exists
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	err := r.DB.QueryRowContext(ctx, query, shikona).Scan(&exists)
+
+	return exists, err
 }
 
 func ValidateRikishi(v *validator.Validator, rikishi *Rikishi) {
@@ -253,4 +505,12 @@ func ValidateRikishi(v *validator.Validator, rikishi *Rikishi) {
 	v.Check(rikishi.ShikonaHistory != nil, "shikona history", "must be provided")
 	v.Check(len(rikishi.ShikonaHistory) >= 1, "shikona history", "must contain at least 1 shikona")
 	v.Check(validator.Unique(rikishi.ShikonaHistory), "shikona history", "must not contain duplicate values")
+
+	v.Check(len(rikishi.ShikonaKanji) <= 500, "shikona_kanji", "must not be more than 500 bytes long")
+	v.Check(len(rikishi.ShikonaKana) <= 500, "shikona_kana", "must not be more than 500 bytes long")
+	v.Check(validator.Hiragana(rikishi.ShikonaKana), "shikona_kana", "must only contain hiragana")
+
+	v.Check(len(rikishi.HeyaKanji) <= 500, "heya_kanji", "must not be more than 500 bytes long")
+	v.Check(len(rikishi.HeyaKana) <= 500, "heya_kana", "must not be more than 500 bytes long")
+	v.Check(validator.Hiragana(rikishi.HeyaKana), "heya_kana", "must only contain hiragana")
 }
@@ -0,0 +1,383 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/lib/pq"
+)
+
+const (
+	SearchKindRikishi  = "rikishi"
+	SearchKindHeya     = "heya"
+	SearchKindKimarite = "kimarite"
+
+	// searchThreshold and wordSearchThreshold are pg_trgm's defaults for the
+	// % and <% operators.
+	searchThreshold     = 0.3
+	wordSearchThreshold = 0.6
+)
+
+var SearchKinds = []string{SearchKindRikishi, SearchKindHeya, SearchKindKimarite}
+
+type SearchHit struct {
+	Kind      string  `json:"kind"`
+	Value     string  `json:"value"`
+	Rikishi   string  `json:"rikishi,omitempty"`
+	Score     float64 `json:"score"`
+	Highlight string  `json:"highlight"`
+}
+
+type SearchStore interface {
+	Search(ctx context.Context, query string, kinds []string, limit int) ([]*SearchHit, error)
+}
+
+type SearchModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+func (m SearchModel) Search(ctx context.Context, query string, kinds []string, limit int) ([]*SearchHit, error) {
+	// Each branch filters on the trigram operators over an expression with a
+	// GIN index, so the indexes narrow the rows down before anything is
+	// scored. Past shikonas are found through the index on the whole history
+	// and then matched one by one, so a past shikona only similar to a much
+	// longer query can be missed. Kimarite readings are matched in Go and
+	// looked up through the index on lower(f_unaccent(kimarite)).
+	stmt := fmt.Sprintf(`
+		WITH terms AS (
+			SELECT 'rikishi' AS kind, shikona AS value, shikona AS rikishi, shikona AS text
+			FROM rikishis
+			WHERE 'rikishi' = ANY($2) AND %s
+			UNION ALL
+			SELECT 'rikishi', name, shikona, name
+			FROM rikishis, unnest(shikona_history) AS name
+			WHERE 'rikishi' = ANY($2) AND %s AND %s
+			UNION ALL
+			SELECT 'rikishi', shikona, shikona, shikona_kanji
+			FROM rikishis
+			WHERE 'rikishi' = ANY($2) AND %s
+			UNION ALL
+			SELECT 'rikishi', shikona, shikona, shikona_kana
+			FROM rikishis
+			WHERE 'rikishi' = ANY($2) AND %s
+			UNION ALL
+			SELECT DISTINCT 'heya', heya, '', heya
+			FROM rikishis
+			WHERE 'heya' = ANY($2) AND %s
+			UNION ALL
+			SELECT DISTINCT 'heya', heya, '', heya_kanji
+			FROM rikishis
+			WHERE 'heya' = ANY($2) AND %s
+			UNION ALL
+			SELECT DISTINCT 'heya', heya, '', heya_kana
+			FROM rikishis
+			WHERE 'heya' = ANY($2) AND %s
+			UNION ALL
+			SELECT DISTINCT 'kimarite', kimarite, '', kimarite
+			FROM bouts
+			WHERE 'kimarite' = ANY($2) AND %s
+			UNION ALL
+			SELECT 'kimarite', bouts.kimarite, '', readings.text
+			FROM unnest($4::text[], $5::text[]) AS readings(romaji, text)
+			CROSS JOIN LATERAL (
+				SELECT kimarite FROM bouts WHERE lower(f_unaccent(kimarite)) = readings.romaji LIMIT 1
+			) AS bouts
+			WHERE 'kimarite' = ANY($2)
+		), scored AS (
+			SELECT DISTINCT ON (kind, value, rikishi) kind, value, rikishi, text,
+				greatest(similarity(f_unaccent(text), q), word_similarity(q, f_unaccent(text))) AS score
+			FROM terms, f_unaccent($1) AS q
+			WHERE value <> '' AND text <> ''
+			ORDER BY kind, value, rikishi, score DESC
+		)
+		SELECT kind, value, rikishi, text, score
+		FROM scored
+		ORDER BY score DESC, kind, value
+		LIMIT $3`,
+		trigramMatch("shikona"), trigramMatch("f_array_to_string(shikona_history)"), trigramMatch("name"),
+		trigramMatch("shikona_kanji"), trigramMatch("shikona_kana"),
+		trigramMatch("heya"), trigramMatch("heya_kanji"), trigramMatch("heya_kana"),
+		trigramMatch("kimarite"))
+
+	romaji, texts := matchingReadings(query)
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Search)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, stmt, query, pq.Array(kinds), limit, pq.Array(romaji), pq.Array(texts))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []*SearchHit{}
+
+	for rows.Next() {
+		var hit SearchHit
+		var text string
+
+		err := rows.Scan(&hit.Kind, &hit.Value, &hit.Rikishi, &text, &hit.Score)
+		if err != nil {
+			return nil, err
+		}
+
+		hit.Highlight = Highlight(text, query)
+		hits = append(hits, &hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// trigramMatch matches column against the search query ($1) in the form the
+// pg_trgm GIN indexes support.
+func trigramMatch(column string) string {
+	return fmt.Sprintf("(f_unaccent(%[1]s) %% f_unaccent($1) OR f_unaccent($1) <%% f_unaccent(%[1]s))", column)
+}
+
+// matchingReadings returns the kimarite readings that trigramMatch would
+// match against query, with the kimarite they belong to. There are only a few
+// hundred, so they are matched here rather than by the database.
+func matchingReadings(query string) (romaji, texts []string) {
+	romaji, texts = []string{}, []string{}
+	queryTrigrams := trigrams(query)
+
+	for name, reading := range KimariteReadings {
+		for _, text := range []string{reading.Kanji, reading.Kana} {
+			textTrigrams := trigrams(text)
+
+			if similarity(queryTrigrams, textTrigrams) >= searchThreshold || wordSimilarity(queryTrigrams, textTrigrams) >= wordSearchThreshold {
+				romaji = append(romaji, name)
+				texts = append(texts, text)
+			}
+		}
+	}
+
+	return romaji, texts
+}
+
+var accentFolds = map[rune]rune{
+	'ā': 'a', 'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'ē': 'e', 'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'ī': 'i', 'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ō': 'o', 'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o',
+	'ū': 'u', 'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+func foldAccents(s string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if folded, ok := accentFolds[r]; ok {
+			return folded
+		}
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+func searchWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+
+	for _, word := range searchWords(foldAccents(s)) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+
+	return set
+}
+
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range a {
+		if b[trigram] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func wordSimilarity(query, value map[string]bool) float64 {
+	if len(query) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range query {
+		if value[trigram] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(query))
+}
+
+type searchTerm struct {
+	kind    string
+	value   string
+	rikishi string
+	text    string
+}
+
+func kimariteSearchTerms(kimarite string) []searchTerm {
+	reading := KimariteReadings[strings.ToLower(kimarite)]
+
+	return []searchTerm{
+		{SearchKindKimarite, kimarite, "", kimarite},
+		{SearchKindKimarite, kimarite, "", reading.Kanji},
+		{SearchKindKimarite, kimarite, "", reading.Kana},
+	}
+}
+
+func rankSearchTerms(terms []searchTerm, query string, kinds []string, limit int) []*SearchHit {
+	queryTrigrams := trigrams(query)
+
+	best := make(map[[3]string]*SearchHit)
+	texts := make(map[*SearchHit]string)
+
+	for _, term := range terms {
+		if term.value == "" || term.text == "" || !contains(kinds, term.kind) {
+			continue
+		}
+
+		textTrigrams := trigrams(term.text)
+
+		score := similarity(queryTrigrams, textTrigrams)
+		if ws := wordSimilarity(queryTrigrams, textTrigrams); ws > score {
+			score = ws
+		}
+
+		if score < searchThreshold {
+			continue
+		}
+
+		key := [3]string{term.kind, term.value, term.rikishi}
+		if hit, ok := best[key]; ok && hit.Score >= score {
+			continue
+		}
+
+		hit := &SearchHit{Kind: term.kind, Value: term.value, Rikishi: term.rikishi, Score: score}
+		best[key] = hit
+		texts[hit] = term.text
+	}
+
+	hits := make([]*SearchHit, 0, len(best))
+	for _, hit := range best {
+		hits = append(hits, hit)
+	}
+
+	sortSearchHits(hits)
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	for _, hit := range hits {
+		hit.Highlight = Highlight(texts[hit], query)
+	}
+
+	return hits
+}
+
+func sortSearchHits(hits []*SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind < hits[j].Kind
+		}
+		return hits[i].Value < hits[j].Value
+	})
+}
+
+// Highlight wraps the parts of value that match the words of query in <em>
+// tags. Everything else is HTML escaped, so the result is safe to render as
+// markup.
+func Highlight(value, query string) string {
+	original := []rune(value)
+	folded := []rune(foldAccents(value))
+
+	marked := make([]bool, len(original))
+
+	for _, word := range searchWords(foldAccents(query)) {
+		needle := []rune(word)
+
+		for n := len(needle); n >= 3 || n == len(needle); n-- {
+			if n <= 0 {
+				break
+			}
+
+			if i := runeIndex(folded, needle[:n]); i >= 0 {
+				for j := i; j < i+n; j++ {
+					marked[j] = true
+				}
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(original); {
+		j := i
+		for j < len(original) && marked[j] == marked[i] {
+			j++
+		}
+
+		segment := html.EscapeString(string(original[i:j]))
+		if marked[i] {
+			segment = "<em>" + segment + "</em>"
+		}
+
+		b.WriteString(segment)
+		i = j
+	}
+
+	return b.String()
+}
+
+func runeIndex(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func ValidateSearch(v *validator.Validator, query string, limit int) {
+	v.Check(strings.TrimSpace(query) != "", "q", "must be provided")
+	v.Check(len(query) <= 100, "q", "must not be more than 100 bytes long")
+	v.Check(limit > 0, "limit", "must be greater than zero")
+	v.Check(limit <= 100, "limit", "must be a maximum of 100")
+}
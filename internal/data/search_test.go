@@ -0,0 +1,30 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		query string
+		want  string
+	}{
+		{"Whole word", "Hakuho", "hakuho", "<em>Hakuho</em>"},
+		{"Accents", "Hakuhō Shō", "hakuho", "<em>Hakuhō</em> Shō"},
+		{"Partial match", "Kisenosato", "kisenosat", "<em>Kisenosat</em>o"},
+		{"Escaped text", `<img src=x onerror="alert(1)">Enho`, "enho", "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;<em>Enho</em>"},
+		{"Escaped match", "Tom & Jerry", "tom & jerry", "<em>Tom</em> &amp; <em>Jerry</em>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := data.Highlight(tt.value, tt.query); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
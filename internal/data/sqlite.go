@@ -0,0 +1,123 @@
+package data
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	sqliteTournamentDateExpression = `printf('%s-%02d', substr(tournament, 1, 4), (instr('JanFebMarAprMayJunJulAugSepOctNovDec', substr(tournament, -3)) + 2) / 3)`
+	sqliteDayNumberExpression      = `CASE WHEN day = 'Playoff' THEN 16 ELSE CAST(day AS integer) END`
+)
+
+func NewSQLiteModels(db *sql.DB, timeouts Timeouts) Models {
+	return Models{
+		Rikishis:           SQLiteRikishiModel{DB: db, Timeouts: timeouts},
+		TournamentsResults: SQLiteTournamentResultModel{DB: db, Timeouts: timeouts},
+		Bouts:              SQLiteBoutModel{DB: db, Timeouts: timeouts},
+		Users:              SQLiteUserModel{DB: db, Timeouts: timeouts},
+		Search:             SQLiteSearchModel{DB: db, Timeouts: timeouts},
+		Webhooks:           SQLiteWebhookModel{DB: db, Timeouts: timeouts},
+		RateLimits:         SQLiteRateLimitModel{DB: db, Timeouts: timeouts},
+		Imports:            SQLiteImportModel{DB: db},
+	}
+}
+
+func SQLiteDSN(dsn string) string {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+
+	return dsn + separator + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+type sqliteArray struct {
+	values *[]string
+}
+
+func (a sqliteArray) Value() (driver.Value, error) {
+	values := *a.values
+	if values == nil {
+		values = []string{}
+	}
+
+	js, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(js), nil
+}
+
+func (a sqliteArray) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), a.values)
+	case []byte:
+		return json.Unmarshal(src, a.values)
+	case nil:
+		*a.values = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into a string array", src)
+	}
+}
+
+func sqliteMatchQuery(s string) string {
+	terms := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, term := range terms {
+		terms[i] = `"` + term + `"`
+	}
+
+	if len(terms) == 0 {
+		return `""`
+	}
+
+	return strings.Join(terms, " ")
+}
+
+func sqliteLimit(f Filters) int {
+	if f.Unlimited {
+		return -1
+	}
+
+	return f.PageSize + 1
+}
+
+func sqliteKeysetArgs(args []interface{}, numericSort, numericKey bool) []interface{} {
+	for i, numeric := range []bool{numericSort, numericKey} {
+		if !numeric || i >= len(args) {
+			continue
+		}
+
+		n, err := strconv.ParseInt(args[i].(string), 10, 64)
+		if err == nil {
+			args[i] = n
+		}
+	}
+
+	return args
+}
+
+func sqliteTournamentDate(tournament string) interface{} {
+	date, ok := tournamentDate(tournament).(time.Time)
+	if !ok {
+		return nil
+	}
+
+	return date.Format("2006-01")
+}
+
+func isSQLiteUniqueViolation(err error, column string) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed: "+column)
+}
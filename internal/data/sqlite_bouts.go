@@ -0,0 +1,433 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type SQLiteBoutModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+const sqliteInsertBoutQuery = `
+	INSERT INTO bouts (tournament, day, division, winner, loser, kimarite)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, version`
+
+func (b SQLiteBoutModel) Insert(ctx context.Context, bout *Bout) error {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		return b.insert(ctx, tx, bout)
+	})
+}
+
+func (b SQLiteBoutModel) InsertBatch(ctx context.Context, bouts []*Bout) error {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Batch)
+	defer cancel()
+
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		for _, bout := range bouts {
+			err := b.insert(ctx, tx, bout)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b SQLiteBoutModel) insert(ctx context.Context, tx *sql.Tx, bout *Bout) error {
+	args := []interface{}{bout.Tournament, bout.Day, bout.Division, bout.Winner, bout.Loser, bout.Kimarite}
+
+	err := tx.QueryRowContext(ctx, sqliteInsertBoutQuery, args...).Scan(&bout.ID, &bout.Version)
+	if err != nil {
+		return err
+	}
+
+	return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventBoutCreated, bout)
+}
+
+func (b SQLiteBoutModel) Get(ctx context.Context, id int64) (*Bout, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, tournament, day, division, winner, loser, kimarite, version
+		FROM bouts
+		WHERE id = $1`
+
+	var bout Bout
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	err := b.DB.QueryRowContext(ctx, query, id).Scan(
+		&bout.ID,
+		&bout.Tournament,
+		&bout.Day,
+		&bout.Division,
+		&bout.Winner,
+		&bout.Loser,
+		&bout.Kimarite,
+		&bout.Version,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &bout, nil
+}
+
+func (b SQLiteBoutModel) GetAll(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters) ([]*Bout, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	totalRecords := 0
+	bouts := []*Bout{}
+	keys := [][2]string{}
+
+	err := b.scan(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, func(total int, sortKey string, bout *Bout) error {
+		totalRecords = total
+		bouts = append(bouts, bout)
+		keys = append(keys, [2]string{sortKey, strconv.FormatInt(bout.ID, 10)})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	bouts, metadata := paginate(filters, bouts, keys, totalRecords)
+
+	return bouts, metadata, nil
+}
+
+func (b SQLiteBoutModel) Stream(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(*Bout) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := b.scan(ctx, tournament, day, division, kimarite, rikishi1, rikishi2, winner, loser, from, to, filters, func(_ int, _ string, bout *Bout) error {
+		return streamer.add(bout)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (b SQLiteBoutModel) scan(ctx context.Context, tournament, day, division, kimarite string, rikishi1, rikishi2, winner, loser []string, from, to string, filters Filters, fn func(totalRecords int, sortKey string, bout *Bout) error) error {
+	sortColumn := filters.SortColumn()
+
+	sortExpression := sortColumn
+	switch sortColumn {
+	case "tournament":
+		sortExpression = sqliteTournamentDateExpression
+	case "day":
+		sortExpression = sqliteDayNumberExpression
+	}
+
+	keyset, keysetArgs := filters.keysetCondition(sortExpression, "id", 13)
+
+	query := fmt.Sprintf(`
+		SELECT total, sort_key, id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT %s AS total, CAST(%s AS text) AS sort_key, id, tournament, day, division, winner, loser, kimarite, version
+			FROM bouts
+			WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
+			AND (day = $2 OR $2 = '')
+			AND (LOWER(division) = LOWER($3) OR $3 = '')
+			AND (kimarite = $4 OR $4 = '')
+			AND (winner IN (SELECT value FROM json_each($5)) OR loser IN (SELECT value FROM json_each($5)) OR $5 = '[]')
+			AND (winner IN (SELECT value FROM json_each($6)) OR loser IN (SELECT value FROM json_each($6)) OR $6 = '[]')
+			AND (winner IN (SELECT value FROM json_each($7)) OR $7 = '[]')
+			AND (loser IN (SELECT value FROM json_each($8)) OR $8 = '[]')
+			AND ($9 IS NULL OR %s >= $9)
+			AND ($10 IS NULL OR %s <= $10)
+		) AS bouts
+		WHERE %s
+		ORDER BY %s
+		LIMIT $11 OFFSET $12`,
+		filters.totalColumn(), sortExpression, sqliteTournamentDateExpression, sqliteTournamentDateExpression, keyset, filters.orderBy(sortExpression, "id"))
+
+	args := []interface{}{
+		tournament,
+		day,
+		division,
+		kimarite,
+		sqliteArray{&rikishi1},
+		sqliteArray{&rikishi2},
+		sqliteArray{&winner},
+		sqliteArray{&loser},
+		sqliteTournamentDate(from),
+		sqliteTournamentDate(to),
+		sqliteLimit(filters),
+		filters.offset(),
+	}
+	args = append(args, sqliteKeysetArgs(keysetArgs, sortColumn == "id" || sortColumn == "day", true)...)
+
+	rows, err := b.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bout Bout
+		var totalRecords int
+		var sortKey string
+
+		err := rows.Scan(
+			&totalRecords,
+			&sortKey,
+			&bout.ID,
+			&bout.Tournament,
+			&bout.Day,
+			&bout.Division,
+			&bout.Winner,
+			&bout.Loser,
+			&bout.Kimarite,
+			&bout.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(totalRecords, sortKey, &bout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (b SQLiteBoutModel) GetForTournaments(ctx context.Context, tournaments []string, day, division string, limit int) ([]*Bout, error) {
+	if len(tournaments) == 0 {
+		return []*Bout{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT id, tournament, day, division, winner, loser, kimarite, version,
+				row_number() OVER (PARTITION BY LOWER(tournament) ORDER BY id) AS n
+			FROM bouts
+			WHERE LOWER(tournament) IN (SELECT value FROM json_each($1))
+			AND (day = $2 OR $2 = '')
+			AND (LOWER(division) = LOWER($3) OR $3 = '')
+		) AS bouts
+		WHERE n <= $4
+		ORDER BY id`
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	lowered := lowerAll(tournaments)
+
+	rows, err := b.DB.QueryContext(ctx, query, sqliteArray{&lowered}, day, division, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bouts := []*Bout{}
+
+	for rows.Next() {
+		var bout Bout
+
+		err := rows.Scan(
+			&bout.ID,
+			&bout.Tournament,
+			&bout.Day,
+			&bout.Division,
+			&bout.Winner,
+			&bout.Loser,
+			&bout.Kimarite,
+			&bout.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		bouts = append(bouts, &bout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bouts, nil
+}
+
+func (b SQLiteBoutModel) GetForRikishis(ctx context.Context, groups []BoutGroup, limit int) ([][]*Bout, error) {
+	indexes, shikonas, tournaments := boutGroupRows(groups)
+
+	groupKeys := make([]string, len(indexes))
+	for i, index := range indexes {
+		groupKeys[i] = strconv.FormatInt(index, 10)
+	}
+
+	// The three arrays are parallel, so their elements are joined by position.
+	query := fmt.Sprintf(`
+		SELECT grp, id, tournament, day, division, winner, loser, kimarite, version
+		FROM (
+			SELECT grp, id, tournament, day, division, winner, loser, kimarite, version,
+				row_number() OVER (PARTITION BY grp ORDER BY %s DESC, id) AS n
+			FROM (
+				SELECT DISTINCT CAST(g.value AS integer) AS grp, b.id, b.tournament, b.day, b.division, b.winner, b.loser, b.kimarite, b.version
+				FROM json_each($1) AS g
+				JOIN json_each($2) AS s ON s.key = g.key
+				JOIN json_each($3) AS t ON t.key = g.key
+				JOIN bouts AS b ON (b.winner = s.value OR b.loser = s.value)
+				AND (LOWER(b.tournament) = LOWER(t.value) OR t.value = '')
+			) AS matches
+		) AS bouts
+		WHERE n <= $4
+		ORDER BY grp, n`, sqliteTournamentDateExpression)
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	rows, err := b.DB.QueryContext(ctx, query, sqliteArray{&groupKeys}, sqliteArray{&shikonas}, sqliteArray{&tournaments}, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bouts := make([][]*Bout, len(groups))
+
+	for rows.Next() {
+		var bout Bout
+		var group int
+
+		err := rows.Scan(
+			&group,
+			&bout.ID,
+			&bout.Tournament,
+			&bout.Day,
+			&bout.Division,
+			&bout.Winner,
+			&bout.Loser,
+			&bout.Kimarite,
+			&bout.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		bouts[group] = append(bouts[group], &bout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bouts, nil
+}
+
+func (b SQLiteBoutModel) IsDuplicate(ctx context.Context, bout *Bout) (bool, error) {
+	query := `
+		SELECT exists (
+			SELECT true FROM bouts
+			WHERE tournament = $1 AND day = $2
+			AND ((winner = $3 AND loser = $4) OR (winner = $4 AND loser = $3))
+			AND id <> $5
+		)`
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Read)
+	defer cancel()
+
+	var exists bool
+
+	err := b.DB.QueryRowContext(ctx, query, bout.Tournament, bout.Day, bout.Winner, bout.Loser, bout.ID).Scan(&exists)
+
+	return exists, err
+}
+
+func (b SQLiteBoutModel) Update(ctx context.Context, bout *Bout) error {
+	query := `
+		UPDATE bouts
+		SET tournament = $1, day = $2, division = $3, winner = $4, loser = $5, kimarite = $6, version = version + 1
+		WHERE id = $7 AND version = $8
+		RETURNING version`
+
+	args := []interface{}{
+		bout.Tournament,
+		bout.Day,
+		bout.Division,
+		bout.Winner,
+		bout.Loser,
+		bout.Kimarite,
+		bout.ID,
+		bout.Version,
+	}
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, args...).Scan(&bout.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrEditConflict
+			default:
+				return err
+			}
+		}
+
+		return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventBoutUpdated, bout)
+	})
+}
+
+func (b SQLiteBoutModel) Delete(ctx context.Context, id int64, version int32) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `
+		DELETE FROM bouts
+		WHERE id = $1 AND version = $2
+		RETURNING id, tournament, day, division, winner, loser, kimarite, version`
+
+	ctx, cancel := withTimeout(ctx, b.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, b.DB, func(tx *sql.Tx) error {
+		var deleted Bout
+
+		err := tx.QueryRowContext(ctx, query, id, version).Scan(
+			&deleted.ID,
+			&deleted.Tournament,
+			&deleted.Day,
+			&deleted.Division,
+			&deleted.Winner,
+			&deleted.Loser,
+			&deleted.Kimarite,
+			&deleted.Version,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return missedDelete(ctx, tx, "bouts", "id", id)
+			default:
+				return err
+			}
+		}
+
+		return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventBoutDeleted, &deleted)
+	})
+}
@@ -0,0 +1,38 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+)
+
+type SQLiteImportModel struct {
+	DB *sql.DB
+}
+
+func (m SQLiteImportModel) Import(ctx context.Context, fn func(tx ImportTx) error) error {
+	return withTx(ctx, m.DB, func(tx *sql.Tx) error {
+		return fn(sqliteImportTx{tx})
+	})
+}
+
+type sqliteImportTx struct {
+	tx *sql.Tx
+}
+
+func (t sqliteImportTx) InsertRikishi(ctx context.Context, rikishi *Rikishi) error {
+	args := []interface{}{rikishi.Shikona, rikishi.HighestRank, rikishi.Heya, sqliteArray{&rikishi.ShikonaHistory}, rikishi.ShikonaKanji, rikishi.ShikonaKana, rikishi.HeyaKanji, rikishi.HeyaKana}
+
+	return t.tx.QueryRowContext(ctx, sqliteInsertRikishiQuery, args...).Scan(&rikishi.Version)
+}
+
+func (t sqliteImportTx) InsertTournamentResult(ctx context.Context, tr *TournamentResult) error {
+	args := []interface{}{tr.Tournament, tr.Rikishi, tr.Rank, tr.Wins, tr.Losses, tr.Absent}
+
+	return t.tx.QueryRowContext(ctx, sqliteInsertTournamentResultQuery, args...).Scan(&tr.ID, &tr.Version)
+}
+
+func (t sqliteImportTx) InsertBout(ctx context.Context, bout *Bout) error {
+	args := []interface{}{bout.Tournament, bout.Day, bout.Division, bout.Winner, bout.Loser, bout.Kimarite}
+
+	return t.tx.QueryRowContext(ctx, sqliteInsertBoutQuery, args...).Scan(&bout.ID, &bout.Version)
+}
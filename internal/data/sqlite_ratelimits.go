@@ -0,0 +1,38 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type SQLiteRateLimitModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+func (m SQLiteRateLimitModel) Allow(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	query := `
+		INSERT INTO rate_limits AS l (key, tat)
+		VALUES ($1, $2 + $3)
+		ON CONFLICT (key) DO UPDATE
+		SET tat = MAX(l.tat, $2) + $3
+		WHERE MAX(l.tat, $2) + $3 - $4 <= $2
+		RETURNING tat`
+
+	return allowRateLimit(ctx, m.DB, m.Timeouts, query, key, limit, now)
+}
+
+func (m SQLiteRateLimitModel) Refund(ctx context.Context, key string, limit RateLimit) error {
+	return refundRateLimit(ctx, m.DB, m.Timeouts, key, limit)
+}
+
+func (m SQLiteRateLimitModel) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM rate_limits WHERE tat < $1`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Batch)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, query, now.UnixNano())
+	return err
+}
@@ -0,0 +1,467 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type SQLiteRikishiModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+const sqliteInsertRikishiQuery = `
+	INSERT INTO rikishis (shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING version`
+
+func (r SQLiteRikishiModel) Insert(ctx context.Context, rikishi *Rikishi) error {
+	args := []interface{}{rikishi.Shikona, rikishi.HighestRank, rikishi.Heya, sqliteArray{&rikishi.ShikonaHistory}, rikishi.ShikonaKanji, rikishi.ShikonaKana, rikishi.HeyaKanji, rikishi.HeyaKana}
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	return r.DB.QueryRowContext(ctx, sqliteInsertRikishiQuery, args...).Scan(&rikishi.Version)
+}
+
+func (r SQLiteRikishiModel) Get(ctx context.Context, shikona string) (*Rikishi, error) {
+	if shikona == "" {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM rikishis
+		WHERE shikona = $1`
+
+	var rikishi Rikishi
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	err := r.DB.QueryRowContext(ctx, query, shikona).Scan(
+		&rikishi.Shikona,
+		&rikishi.HighestRank,
+		&rikishi.Heya,
+		sqliteArray{&rikishi.ShikonaHistory},
+		&rikishi.ShikonaKanji,
+		&rikishi.ShikonaKana,
+		&rikishi.HeyaKanji,
+		&rikishi.HeyaKana,
+		&rikishi.Version,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &rikishi, nil
+}
+
+func (r SQLiteRikishiModel) GetAll(ctx context.Context, shikona, highestRank, heya string, filters Filters) ([]*Rikishi, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	totalRecords := 0
+	rikishis := []*Rikishi{}
+	keys := [][2]string{}
+
+	err := r.scan(ctx, shikona, highestRank, heya, filters, func(total int, sortKey string, rikishi *Rikishi) error {
+		totalRecords = total
+		rikishis = append(rikishis, rikishi)
+		keys = append(keys, [2]string{sortKey, rikishi.Shikona})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	rikishis, metadata := paginate(filters, rikishis, keys, totalRecords)
+
+	return rikishis, metadata, nil
+}
+
+func (r SQLiteRikishiModel) Stream(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(*Rikishi) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := r.scan(ctx, shikona, highestRank, heya, filters, func(_ int, _ string, rikishi *Rikishi) error {
+		return streamer.add(rikishi)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (r SQLiteRikishiModel) scan(ctx context.Context, shikona, highestRank, heya string, filters Filters, fn func(totalRecords int, sortKey string, rikishi *Rikishi) error) error {
+	keyset, keysetArgs := filters.keysetCondition(filters.SortColumn(), "shikona", 7)
+
+	query := fmt.Sprintf(`
+		SELECT total, sort_key, shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM (
+			SELECT %s AS total, CAST(%s AS text) AS sort_key, shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+			FROM rikishis
+			WHERE ($1 = '' OR shikona IN (SELECT shikona FROM rikishis_search WHERE rikishis_search MATCH $2))
+			AND (LOWER(highest_rank) = LOWER($3) OR $3 = '')
+			AND (LOWER(heya) = LOWER($4) OR $4 = '')
+		) AS rikishis
+		WHERE %s
+		ORDER BY %s
+		LIMIT $5 OFFSET $6`, filters.totalColumn(), filters.SortColumn(), keyset, filters.orderBy(filters.SortColumn(), "shikona"))
+
+	args := []interface{}{shikona, sqliteMatchQuery(shikona), highestRank, heya, sqliteLimit(filters), filters.offset()}
+	args = append(args, keysetArgs...)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rikishi Rikishi
+		var totalRecords int
+		var sortKey string
+
+		err := rows.Scan(
+			&totalRecords,
+			&sortKey,
+			&rikishi.Shikona,
+			&rikishi.HighestRank,
+			&rikishi.Heya,
+			sqliteArray{&rikishi.ShikonaHistory},
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
+			&rikishi.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(totalRecords, sortKey, &rikishi)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r SQLiteRikishiModel) GetByShikonas(ctx context.Context, shikonas []string) (map[string]*Rikishi, error) {
+	rikishis := make(map[string]*Rikishi)
+
+	if len(shikonas) == 0 {
+		return rikishis, nil
+	}
+
+	query := `
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM rikishis
+		WHERE EXISTS (
+			SELECT true FROM json_each(shikona_history)
+			WHERE value IN (SELECT value FROM json_each($1))
+		)`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, sqliteArray{&shikonas})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rikishi Rikishi
+
+		err := rows.Scan(
+			&rikishi.Shikona,
+			&rikishi.HighestRank,
+			&rikishi.Heya,
+			sqliteArray{&rikishi.ShikonaHistory},
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
+			&rikishi.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, shikona := range rikishi.ShikonaHistory {
+			rikishis[shikona] = &rikishi
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rikishis, nil
+}
+
+func (r SQLiteRikishiModel) GetForHeyas(ctx context.Context, heyas []string, limit int) ([]*Rikishi, error) {
+	if len(heyas) == 0 {
+		return []*Rikishi{}, nil
+	}
+
+	query := `
+		SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version
+		FROM (
+			SELECT shikona, highest_rank, heya, shikona_history, shikona_kanji, shikona_kana, heya_kanji, heya_kana, version,
+				row_number() OVER (PARTITION BY LOWER(heya) ORDER BY shikona) AS n
+			FROM rikishis
+			WHERE LOWER(heya) IN (SELECT value FROM json_each($1))
+		) AS rikishis
+		WHERE n <= $2
+		ORDER BY shikona`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	lowered := lowerAll(heyas)
+
+	rows, err := r.DB.QueryContext(ctx, query, sqliteArray{&lowered}, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rikishis := []*Rikishi{}
+
+	for rows.Next() {
+		var rikishi Rikishi
+
+		err := rows.Scan(
+			&rikishi.Shikona,
+			&rikishi.HighestRank,
+			&rikishi.Heya,
+			sqliteArray{&rikishi.ShikonaHistory},
+			&rikishi.ShikonaKanji,
+			&rikishi.ShikonaKana,
+			&rikishi.HeyaKanji,
+			&rikishi.HeyaKana,
+			&rikishi.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rikishis = append(rikishis, &rikishi)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rikishis, nil
+}
+
+func (r SQLiteRikishiModel) GetShikonaHistory(ctx context.Context, shikona string) ([]string, error) {
+	if shikona == "" {
+		return []string{}, nil
+	}
+
+	query := `
+		SELECT shikona_history
+		FROM rikishis
+		WHERE shikona IN (SELECT shikona FROM rikishis_search WHERE rikishis_search MATCH $1)`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, sqliteMatchQuery(shikona))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shikonas []string
+
+	for rows.Next() {
+		var history []string
+
+		err := rows.Scan(sqliteArray{&history})
+		if err != nil {
+			return nil, err
+		}
+		shikonas = append(shikonas, history...)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shikonas, nil
+}
+
+func (r SQLiteRikishiModel) ExistingShikonas(ctx context.Context, shikonas []string) (ShikonaSet, error) {
+	set := make(ShikonaSet)
+
+	if len(shikonas) == 0 {
+		return set, nil
+	}
+
+	query := `
+		SELECT shikona
+		FROM rikishis
+		WHERE shikona IN (SELECT value FROM json_each($1))`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query, sqliteArray{&shikonas})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shikona string
+
+		err := rows.Scan(&shikona)
+		if err != nil {
+			return nil, err
+		}
+
+		set[shikona] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (r SQLiteRikishiModel) Aliases(ctx context.Context) (map[string]string, error) {
+	query := `
+		SELECT shikona, shikona_history
+		FROM rikishis`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Batch)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make(map[string]string)
+
+	for rows.Next() {
+		var shikona string
+		var history []string
+
+		err := rows.Scan(&shikona, sqliteArray{&history})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alias := range history {
+			aliases[alias] = shikona
+		}
+		aliases[shikona] = shikona
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+func (r SQLiteRikishiModel) Update(ctx context.Context, rikishi *Rikishi) error {
+	oldShikona := rikishi.ShikonaHistory[len(rikishi.ShikonaHistory)-1]
+	if rikishi.Shikona != oldShikona {
+		rikishi.ShikonaHistory = append(rikishi.ShikonaHistory, rikishi.Shikona)
+	}
+
+	query := `
+		UPDATE rikishis
+		SET shikona = $1, highest_rank = $2, heya = $3, shikona_history = $4,
+			shikona_kanji = $5, shikona_kana = $6, heya_kanji = $7, heya_kana = $8, version = version + 1
+		WHERE shikona = $9 AND version = $10
+		RETURNING version`
+
+	args := []interface{}{
+		rikishi.Shikona,
+		rikishi.HighestRank,
+		rikishi.Heya,
+		sqliteArray{&rikishi.ShikonaHistory},
+		rikishi.ShikonaKanji,
+		rikishi.ShikonaKana,
+		rikishi.HeyaKanji,
+		rikishi.HeyaKana,
+		oldShikona,
+		rikishi.Version,
+	}
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	err := r.DB.QueryRowContext(ctx, query, args...).Scan(&rikishi.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r SQLiteRikishiModel) Delete(ctx context.Context, shikona string, version int32) error {
+	if shikona == "" {
+		return ErrRecordNotFound
+	}
+
+	query := `
+		DELETE FROM rikishis
+		WHERE shikona = $1 AND version = $2`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, query, shikona, version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return missedDelete(ctx, r.DB, "rikishis", "shikona", shikona)
+	}
+
+	return nil
+}
+
+func (r SQLiteRikishiModel) Exists(ctx context.Context, shikona string) (bool, error) {
+	var exists bool
+	query := `SELECT exists (SELECT true FROM rikishis WHERE shikona = $1)`
+
+	ctx, cancel := withTimeout(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	err := r.DB.QueryRowContext(ctx, query, shikona).Scan(&exists)
+
+	return exists, err
+}
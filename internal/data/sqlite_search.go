@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+)
+
+type SQLiteSearchModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+func (m SQLiteSearchModel) Search(ctx context.Context, query string, kinds []string, limit int) ([]*SearchHit, error) {
+	stmt := `
+		SELECT 'rikishi', shikona, shikona, shikona FROM rikishis
+		UNION
+		SELECT 'rikishi', history.value, rikishis.shikona, history.value FROM rikishis, json_each(rikishis.shikona_history) AS history
+		UNION
+		SELECT 'rikishi', shikona, shikona, shikona_kanji FROM rikishis
+		UNION
+		SELECT 'rikishi', shikona, shikona, shikona_kana FROM rikishis
+		UNION
+		SELECT 'heya', heya, '', heya FROM rikishis
+		UNION
+		SELECT 'heya', heya, '', heya_kanji FROM rikishis
+		UNION
+		SELECT 'heya', heya, '', heya_kana FROM rikishis
+		UNION
+		SELECT 'kimarite', kimarite, '', '' FROM bouts`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Search)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := []searchTerm{}
+
+	for rows.Next() {
+		var term searchTerm
+
+		err := rows.Scan(&term.kind, &term.value, &term.rikishi, &term.text)
+		if err != nil {
+			return nil, err
+		}
+
+		if term.kind == SearchKindKimarite {
+			terms = append(terms, kimariteSearchTerms(term.value)...)
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rankSearchTerms(terms, query, kinds, limit), nil
+}
@@ -0,0 +1,384 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type SQLiteTournamentResultModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+const sqliteInsertTournamentResultQuery = `
+	INSERT INTO tournaments_results (tournament, rikishi, rank, wins, losses, absent)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, version`
+
+func (t SQLiteTournamentResultModel) Insert(ctx context.Context, tr *TournamentResult) error {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		return t.insert(ctx, tx, tr)
+	})
+}
+
+func (t SQLiteTournamentResultModel) InsertBatch(ctx context.Context, trs []*TournamentResult) error {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Batch)
+	defer cancel()
+
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		for _, tr := range trs {
+			err := t.insert(ctx, tx, tr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (t SQLiteTournamentResultModel) insert(ctx context.Context, tx *sql.Tx, tr *TournamentResult) error {
+	args := []interface{}{tr.Tournament, tr.Rikishi, tr.Rank, tr.Wins, tr.Losses, tr.Absent}
+
+	err := tx.QueryRowContext(ctx, sqliteInsertTournamentResultQuery, args...).Scan(&tr.ID, &tr.Version)
+	if err != nil {
+		return err
+	}
+
+	return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventTournamentResultCreated, tr)
+}
+
+func (t SQLiteTournamentResultModel) Get(ctx context.Context, id int64) (*TournamentResult, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM tournaments_results
+		WHERE id = $1`
+
+	var tr TournamentResult
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	err := t.DB.QueryRowContext(ctx, query, id).Scan(
+		&tr.ID,
+		&tr.Tournament,
+		&tr.Rikishi,
+		&tr.Rank,
+		&tr.Wins,
+		&tr.Losses,
+		&tr.Absent,
+		&tr.Version,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &tr, nil
+}
+
+func (t SQLiteTournamentResultModel) GetAll(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	totalRecords := 0
+	tournamentsResults := []*TournamentResult{}
+	keys := [][2]string{}
+
+	err := t.scan(ctx, tournament, rank, wins, shikonas, filters, func(total int, sortKey string, tournamentResult *TournamentResult) error {
+		totalRecords = total
+		tournamentsResults = append(tournamentsResults, tournamentResult)
+		keys = append(keys, [2]string{sortKey, strconv.FormatInt(tournamentResult.ID, 10)})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	tournamentsResults, metadata := paginate(filters, tournamentsResults, keys, totalRecords)
+
+	return tournamentsResults, metadata, nil
+}
+
+func (t SQLiteTournamentResultModel) Stream(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(*TournamentResult) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := t.scan(ctx, tournament, rank, wins, shikonas, filters, func(_ int, _ string, tournamentResult *TournamentResult) error {
+		return streamer.add(tournamentResult)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (t SQLiteTournamentResultModel) scan(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(totalRecords int, sortKey string, tournamentResult *TournamentResult) error) error {
+	keyset, keysetArgs := filters.keysetCondition(filters.SortColumn(), "id", 7)
+
+	query := fmt.Sprintf(`
+		SELECT total, sort_key, id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM (
+			SELECT %s AS total, CAST(%s AS text) AS sort_key, id, tournament, rikishi, rank, wins, losses, absent, version
+			FROM tournaments_results
+			WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
+			AND (LOWER(rank) = LOWER($2) OR $2 = '')
+			AND (rikishi IN (SELECT value FROM json_each($3)) OR $3 = '[]')
+			AND wins >= $4
+		) AS tournaments_results
+		WHERE %s
+		ORDER BY %s
+		LIMIT $5 OFFSET $6`, filters.totalColumn(), filters.SortColumn(), keyset, filters.orderBy(filters.SortColumn(), "id"))
+
+	sortColumn := filters.SortColumn()
+
+	args := []interface{}{tournament, rank, sqliteArray{&shikonas}, wins, sqliteLimit(filters), filters.offset()}
+	args = append(args, sqliteKeysetArgs(keysetArgs, sortColumn == "id" || sortColumn == "wins", true)...)
+
+	rows, err := t.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tournamentResult TournamentResult
+		var totalRecords int
+		var sortKey string
+
+		err := rows.Scan(
+			&totalRecords,
+			&sortKey,
+			&tournamentResult.ID,
+			&tournamentResult.Tournament,
+			&tournamentResult.Rikishi,
+			&tournamentResult.Rank,
+			&tournamentResult.Wins,
+			&tournamentResult.Losses,
+			&tournamentResult.Absent,
+			&tournamentResult.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(totalRecords, sortKey, &tournamentResult)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (t SQLiteTournamentResultModel) GetForRikishis(ctx context.Context, tournaments, shikonas []string) ([]*TournamentResult, error) {
+	if len(shikonas) == 0 {
+		return []*TournamentResult{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM tournaments_results
+		WHERE (tournament IN (SELECT value FROM json_each($1)) OR $1 = '[]')
+		AND rikishi IN (SELECT value FROM json_each($2))
+		ORDER BY id ASC`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	rows, err := t.DB.QueryContext(ctx, query, sqliteArray{&tournaments}, sqliteArray{&shikonas})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournamentsResults := []*TournamentResult{}
+
+	for rows.Next() {
+		var tournamentResult TournamentResult
+
+		err := rows.Scan(
+			&tournamentResult.ID,
+			&tournamentResult.Tournament,
+			&tournamentResult.Rikishi,
+			&tournamentResult.Rank,
+			&tournamentResult.Wins,
+			&tournamentResult.Losses,
+			&tournamentResult.Absent,
+			&tournamentResult.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tournamentsResults = append(tournamentsResults, &tournamentResult)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tournamentsResults, nil
+}
+
+func (t SQLiteTournamentResultModel) GetForTournaments(ctx context.Context, tournaments []string, rank string, limit int) ([]*TournamentResult, error) {
+	if len(tournaments) == 0 {
+		return []*TournamentResult{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM (
+			SELECT id, tournament, rikishi, rank, wins, losses, absent, version,
+				row_number() OVER (PARTITION BY LOWER(tournament) ORDER BY id) AS n
+			FROM tournaments_results
+			WHERE LOWER(tournament) IN (SELECT value FROM json_each($1))
+			AND (LOWER(rank) = LOWER($2) OR $2 = '')
+		) AS tournaments_results
+		WHERE n <= $3
+		ORDER BY id`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	lowered := lowerAll(tournaments)
+
+	rows, err := t.DB.QueryContext(ctx, query, sqliteArray{&lowered}, rank, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournamentsResults := []*TournamentResult{}
+
+	for rows.Next() {
+		var tournamentResult TournamentResult
+
+		err := rows.Scan(
+			&tournamentResult.ID,
+			&tournamentResult.Tournament,
+			&tournamentResult.Rikishi,
+			&tournamentResult.Rank,
+			&tournamentResult.Wins,
+			&tournamentResult.Losses,
+			&tournamentResult.Absent,
+			&tournamentResult.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tournamentsResults = append(tournamentsResults, &tournamentResult)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tournamentsResults, nil
+}
+
+func (t SQLiteTournamentResultModel) IsDuplicate(ctx context.Context, tr *TournamentResult) (bool, error) {
+	query := `
+		SELECT exists (
+			SELECT true FROM tournaments_results
+			WHERE tournament = $1 AND rikishi = $2 AND id <> $3
+		)`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	var exists bool
+
+	err := t.DB.QueryRowContext(ctx, query, tr.Tournament, tr.Rikishi, tr.ID).Scan(&exists)
+
+	return exists, err
+}
+
+func (t SQLiteTournamentResultModel) Update(ctx context.Context, tr *TournamentResult) error {
+	query := `
+		UPDATE tournaments_results
+		SET tournament = $1, rikishi = $2, rank = $3, wins = $4, losses = $5, absent = $6, version = version + 1
+		WHERE id = $7 AND version = $8
+		RETURNING version`
+
+	args := []interface{}{
+		tr.Tournament,
+		tr.Rikishi,
+		tr.Rank,
+		tr.Wins,
+		tr.Losses,
+		tr.Absent,
+		tr.ID,
+		tr.Version,
+	}
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, args...).Scan(&tr.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrEditConflict
+			default:
+				return err
+			}
+		}
+
+		return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventTournamentResultUpdated, tr)
+	})
+}
+
+func (t SQLiteTournamentResultModel) Delete(ctx context.Context, id int64, version int32) error {
+	if id < 1 {
+		return ErrRecordNotFound
+	}
+
+	query := `
+		DELETE FROM tournaments_results
+		WHERE id = $1 AND version = $2
+		RETURNING id, tournament, rikishi, rank, wins, losses, absent, version`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		var deleted TournamentResult
+
+		err := tx.QueryRowContext(ctx, query, id, version).Scan(
+			&deleted.ID,
+			&deleted.Tournament,
+			&deleted.Rikishi,
+			&deleted.Rank,
+			&deleted.Wins,
+			&deleted.Losses,
+			&deleted.Absent,
+			&deleted.Version,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return missedDelete(ctx, tx, "tournaments_results", "id", id)
+			default:
+				return err
+			}
+		}
+
+		return enqueueWebhooks(ctx, tx, sqliteEnqueueWebhooksQuery, EventTournamentResultDeleted, &deleted)
+	})
+}
@@ -0,0 +1,278 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type SQLiteWebhookModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+// sqliteTime formats t the way CURRENT_TIMESTAMP does, so stored times
+// compare correctly as text.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+const sqliteEnqueueWebhooksQuery = `
+	INSERT INTO webhook_deliveries (webhook_id, event, payload)
+	SELECT id, $1, $2
+	FROM webhooks
+	WHERE active AND EXISTS (SELECT 1 FROM json_each(webhooks.events) WHERE value = $1)`
+
+func (m SQLiteWebhookModel) Insert(ctx context.Context, webhook *Webhook) error {
+	query := `
+		INSERT INTO webhooks (url, events, secret, active, owner)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, version`
+
+	args := []interface{}{webhook.URL, sqliteArray{&webhook.Events}, webhook.Secret, webhook.Active, webhook.Owner}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, query, args...).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.Version)
+}
+
+// Get returns the webhook with id if it belongs to owner.
+func (m SQLiteWebhookModel) Get(ctx context.Context, id int64, owner string) (*Webhook, error) {
+	query := `
+		SELECT id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE id = $1 AND owner = $2`
+
+	return m.get(ctx, query, id, owner)
+}
+
+// GetForDelivery returns the webhook with id whoever owns it, for the
+// dispatcher.
+func (m SQLiteWebhookModel) GetForDelivery(ctx context.Context, id int64) (*Webhook, error) {
+	query := `
+		SELECT id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE id = $1`
+
+	return m.get(ctx, query, id)
+}
+
+func (m SQLiteWebhookModel) get(ctx context.Context, query string, args ...interface{}) (*Webhook, error) {
+	var webhook Webhook
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(
+		&webhook.ID,
+		&webhook.CreatedAt,
+		&webhook.URL,
+		sqliteArray{&webhook.Events},
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.Owner,
+		&webhook.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &webhook, nil
+}
+
+func (m SQLiteWebhookModel) GetAll(ctx context.Context, owner string, filters Filters) ([]*Webhook, Metadata, error) {
+	query := `
+		SELECT count(*) OVER(), id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE owner = $1
+		ORDER BY id
+		LIMIT $2 OFFSET $3`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, owner, filters.PageSize, filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	webhooks := []*Webhook{}
+
+	for rows.Next() {
+		var webhook Webhook
+
+		err := rows.Scan(
+			&totalRecords,
+			&webhook.ID,
+			&webhook.CreatedAt,
+			&webhook.URL,
+			sqliteArray{&webhook.Events},
+			&webhook.Secret,
+			&webhook.Active,
+			&webhook.Owner,
+			&webhook.Version,
+		)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+
+		webhooks = append(webhooks, &webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return webhooks, calculateMetadata(totalRecords, filters.Page, filters.PageSize), nil
+}
+
+func (m SQLiteWebhookModel) Update(ctx context.Context, webhook *Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $1, events = $2, secret = $3, active = $4, version = version + 1
+		WHERE id = $5 AND owner = $6 AND version = $7
+		RETURNING version`
+
+	args := []interface{}{
+		webhook.URL,
+		sqliteArray{&webhook.Events},
+		webhook.Secret,
+		webhook.Active,
+		webhook.ID,
+		webhook.Owner,
+		webhook.Version,
+	}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&webhook.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m SQLiteWebhookModel) Delete(ctx context.Context, id int64, owner string) error {
+	query := `
+		DELETE FROM webhooks
+		WHERE id = $1 AND owner = $2`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id, owner)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (m SQLiteWebhookModel) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $3)
+		RETURNING id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, updated_at`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, sqliteTime(now), sqliteTime(now.Add(lease)), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows, nil)
+}
+
+func (m SQLiteWebhookModel) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, response_status = $4, last_error = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING updated_at`
+
+	args := []interface{}{
+		delivery.Status,
+		delivery.Attempts,
+		sqliteTime(delivery.NextAttemptAt),
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.ID,
+	}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&delivery.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m SQLiteWebhookModel) GetDeliveries(ctx context.Context, webhookID int64, owner string, filters Filters) ([]*WebhookDelivery, Metadata, error) {
+	query := `
+		SELECT count(*) OVER(), d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at, d.response_status, d.last_error, d.created_at, d.updated_at
+		FROM webhook_deliveries AS d
+		JOIN webhooks AS w ON w.id = d.webhook_id
+		WHERE d.webhook_id = $1 AND w.owner = $2
+		ORDER BY d.id DESC
+		LIMIT $3 OFFSET $4`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, webhookID, owner, filters.PageSize, filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+
+	deliveries, err := scanDeliveries(rows, &totalRecords)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return deliveries, calculateMetadata(totalRecords, filters.Page, filters.PageSize), nil
+}
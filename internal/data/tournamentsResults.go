@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/corsairconstantine/sumodb/internal/validator"
 	"github.com/lib/pq"
@@ -18,29 +18,62 @@ type TournamentResult struct {
 	Rank       string `json:"rank"`
 	Wins       int32  `json:"wins"`
 	Losses     int32  `json:"losses"`
-	Absent     int32  `json:"Absent"`
+	Absent     int32  `json:"absent"`
 	Version    int32  `json:"version"`
 }
 
 type TournamentResultModel struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts Timeouts
 }
 
-func (t TournamentResultModel) Insert(tr *TournamentResult) error {
-	query := `
-		INSERT INTO tournaments_results (tournament, rikishi, rank, wins, losses, absent)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, version`
+const insertTournamentResultQuery = `
+	INSERT INTO tournaments_results (tournament, rikishi, rank, wins, losses, absent)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, version`
+
+func (t TournamentResultModel) Insert(ctx context.Context, tr *TournamentResult) error {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
+	defer cancel()
+
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		return t.insert(ctx, tx, tr)
+	})
+}
 
+func (t TournamentResultModel) InsertTx(ctx context.Context, tx *sql.Tx, tr *TournamentResult) error {
 	args := []interface{}{tr.Tournament, tr.Rikishi, tr.Rank, tr.Wins, tr.Losses, tr.Absent}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	return tx.QueryRowContext(ctx, insertTournamentResultQuery, args...).Scan(&tr.ID, &tr.Version)
+}
+
+func (t TournamentResultModel) InsertBatch(ctx context.Context, trs []*TournamentResult) error {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Batch)
 	defer cancel()
 
-	return t.DB.QueryRowContext(ctx, query, args...).Scan(&tr.ID, &tr.Version)
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		for _, tr := range trs {
+			err := t.insert(ctx, tx, tr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
-func (t TournamentResultModel) Get(id int64) (*TournamentResult, error) {
+// insert adds tr and queues its webhook deliveries in tx.
+func (t TournamentResultModel) insert(ctx context.Context, tx *sql.Tx, tr *TournamentResult) error {
+	err := t.InsertTx(ctx, tx, tr)
+	if err != nil {
+		return err
+	}
+
+	return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventTournamentResultCreated, tr)
+}
+
+func (t TournamentResultModel) Get(ctx context.Context, id int64) (*TournamentResult, error) {
 	if id < 1 {
 		return nil, ErrRecordNotFound
 	}
@@ -52,7 +85,7 @@ func (t TournamentResultModel) Get(id int64) (*TournamentResult, error) {
 
 	var tr TournamentResult
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
 	defer cancel()
 
 	err := t.DB.QueryRowContext(ctx, query, id).Scan(
@@ -78,36 +111,124 @@ func (t TournamentResultModel) Get(id int64) (*TournamentResult, error) {
 	return &tr, nil
 }
 
-func (t TournamentResultModel) GetAll(tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, Metadata, error) {
-	query := fmt.Sprintf(`
-		SELECT count(*) OVER(), id, tournament, rikishi, rank, wins, losses, absent, version
-		FROM tournaments_results
-		WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
-		AND (LOWER(rank) = LOWER($2) OR $2 = '')
-		AND (rikishi = ANY($3) OR $3 = '{}')
-		AND wins >= $4
-		ORDER BY %s %s, id ASC
-		LIMIT $5 OFFSET $6`, filters.SortColumn(), filters.sortDirection())
-
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (t TournamentResultModel) GetAll(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters) ([]*TournamentResult, Metadata, error) {
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
 	defer cancel()
 
+	totalRecords := 0
+	tournamentsResults := []*TournamentResult{}
+	keys := [][2]string{}
+
+	err := t.scan(ctx, tournament, rank, wins, shikonas, filters, func(total int, sortKey string, tournamentResult *TournamentResult) error {
+		totalRecords = total
+		tournamentsResults = append(tournamentsResults, tournamentResult)
+		keys = append(keys, [2]string{sortKey, strconv.FormatInt(tournamentResult.ID, 10)})
+		return nil
+	})
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	tournamentsResults, metadata := paginate(filters, tournamentsResults, keys, totalRecords)
+
+	return tournamentsResults, metadata, nil
+}
+
+func (t TournamentResultModel) Stream(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(*TournamentResult) error) error {
+	streamer := newPageStreamer(filters, fn)
+
+	err := t.scan(ctx, tournament, rank, wins, shikonas, filters, func(_ int, _ string, tournamentResult *TournamentResult) error {
+		return streamer.add(tournamentResult)
+	})
+	if err != nil {
+		return err
+	}
+
+	return streamer.flush()
+}
+
+func (t TournamentResultModel) scan(ctx context.Context, tournament string, rank string, wins int, shikonas []string, filters Filters, fn func(totalRecords int, sortKey string, tournamentResult *TournamentResult) error) error {
+	keyset, keysetArgs := filters.keysetCondition(filters.SortColumn(), "id", 7)
+
+	query := fmt.Sprintf(`
+		SELECT total, sort_key, id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM (
+			SELECT %s AS total, %s::text AS sort_key, id, tournament, rikishi, rank, wins, losses, absent, version
+			FROM tournaments_results
+			WHERE (LOWER(tournament) = LOWER($1) OR $1 = '')
+			AND (LOWER(rank) = LOWER($2) OR $2 = '')
+			AND (rikishi = ANY($3) OR $3 = '{}')
+			AND wins >= $4
+		) AS tournaments_results
+		WHERE %s
+		ORDER BY %s
+		LIMIT $5 OFFSET $6`, filters.totalColumn(), filters.SortColumn(), keyset, filters.orderBy(filters.SortColumn(), "id"))
+
 	args := []interface{}{tournament, rank, pq.Array(shikonas), wins, filters.limit(), filters.offset()}
+	args = append(args, keysetArgs...)
 
 	rows, err := t.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, Metadata{}, err
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tournamentResult TournamentResult
+		var totalRecords int
+		var sortKey string
+
+		err := rows.Scan(
+			&totalRecords,
+			&sortKey,
+			&tournamentResult.ID,
+			&tournamentResult.Tournament,
+			&tournamentResult.Rikishi,
+			&tournamentResult.Rank,
+			&tournamentResult.Wins,
+			&tournamentResult.Losses,
+			&tournamentResult.Absent,
+			&tournamentResult.Version,
+		)
+		if err != nil {
+			return err
+		}
+		err = fn(totalRecords, sortKey, &tournamentResult)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (t TournamentResultModel) GetForRikishis(ctx context.Context, tournaments, shikonas []string) ([]*TournamentResult, error) {
+	if len(shikonas) == 0 {
+		return []*TournamentResult{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM tournaments_results
+		WHERE (tournament = ANY($1) OR $1 = '{}')
+		AND rikishi = ANY($2)
+		ORDER BY id ASC`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	rows, err := t.DB.QueryContext(ctx, query, pq.Array(tournaments), pq.Array(shikonas))
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
-	totalRecords := 0
 	tournamentsResults := []*TournamentResult{}
 
 	for rows.Next() {
 		var tournamentResult TournamentResult
 
 		err := rows.Scan(
-			&totalRecords,
 			&tournamentResult.ID,
 			&tournamentResult.Tournament,
 			&tournamentResult.Rikishi,
@@ -118,20 +239,92 @@ func (t TournamentResultModel) GetAll(tournament string, rank string, wins int,
 			&tournamentResult.Version,
 		)
 		if err != nil {
-			return nil, Metadata{}, err
+			return nil, err
 		}
 		tournamentsResults = append(tournamentsResults, &tournamentResult)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, Metadata{}, err
+		return nil, err
 	}
 
-	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
-	return tournamentsResults, metadata, nil
+	return tournamentsResults, nil
+}
+
+// GetForTournaments returns up to limit results of each tournament, ordered
+// by id, in a single query.
+func (t TournamentResultModel) GetForTournaments(ctx context.Context, tournaments []string, rank string, limit int) ([]*TournamentResult, error) {
+	if len(tournaments) == 0 {
+		return []*TournamentResult{}, nil
+	}
+
+	query := `
+		SELECT id, tournament, rikishi, rank, wins, losses, absent, version
+		FROM (
+			SELECT id, tournament, rikishi, rank, wins, losses, absent, version,
+				row_number() OVER (PARTITION BY LOWER(tournament) ORDER BY id) AS n
+			FROM tournaments_results
+			WHERE LOWER(tournament) = ANY($1)
+			AND (LOWER(rank) = LOWER($2) OR $2 = '')
+		) AS tournaments_results
+		WHERE n <= $3
+		ORDER BY id`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	rows, err := t.DB.QueryContext(ctx, query, pq.Array(lowerAll(tournaments)), rank, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournamentsResults := []*TournamentResult{}
+
+	for rows.Next() {
+		var tournamentResult TournamentResult
+
+		err := rows.Scan(
+			&tournamentResult.ID,
+			&tournamentResult.Tournament,
+			&tournamentResult.Rikishi,
+			&tournamentResult.Rank,
+			&tournamentResult.Wins,
+			&tournamentResult.Losses,
+			&tournamentResult.Absent,
+			&tournamentResult.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tournamentsResults = append(tournamentsResults, &tournamentResult)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tournamentsResults, nil
+}
+
+func (t TournamentResultModel) IsDuplicate(ctx context.Context, tr *TournamentResult) (bool, error) {
+	query := `
+		SELECT exists (
+			SELECT true FROM tournaments_results
+			WHERE tournament = $1 AND rikishi = $2 AND id <> $3
+		)`
+
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Read)
+	defer cancel()
+
+	var exists bool
+
+	err := t.DB.QueryRowContext(ctx, query, tr.Tournament, tr.Rikishi, tr.ID).Scan(&exists)
+
+	return exists, err
 }
 
-func (t TournamentResultModel) Update(tr *TournamentResult) error {
+func (t TournamentResultModel) Update(ctx context.Context, tr *TournamentResult) error {
 	query := `
 		UPDATE tournaments_results
 		SET tournament = $1, rikishi = $2, rank = $3, wins = $4, losses = $5, absent = $6, version = version + 1
@@ -149,54 +342,76 @@ func (t TournamentResultModel) Update(tr *TournamentResult) error {
 		tr.Version,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
 	defer cancel()
 
-	err := t.DB.QueryRowContext(ctx, query, args...).Scan(&tr.Version)
-	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return ErrEditConflict
-		default:
-			return err
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query, args...).Scan(&tr.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrEditConflict
+			default:
+				return err
+			}
 		}
-	}
 
-	return nil
+		return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventTournamentResultUpdated, tr)
+	})
 }
 
-func (t TournamentResultModel) Delete(id int64) error {
+func (t TournamentResultModel) Delete(ctx context.Context, id int64, version int32) error {
 	if id < 1 {
 		return ErrRecordNotFound
 	}
 
-	query := `DELETE FROM tournaments_results WHERE id = $1`
+	query := `
+		DELETE FROM tournaments_results
+		WHERE id = $1 AND version = $2
+		RETURNING id, tournament, rikishi, rank, wins, losses, absent, version`
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := withTimeout(ctx, t.Timeouts.Write)
 	defer cancel()
 
-	result, err := t.DB.ExecContext(ctx, query, id)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		var deleted TournamentResult
+
+		err := tx.QueryRowContext(ctx, query, id, version).Scan(
+			&deleted.ID,
+			&deleted.Tournament,
+			&deleted.Rikishi,
+			&deleted.Rank,
+			&deleted.Wins,
+			&deleted.Losses,
+			&deleted.Absent,
+			&deleted.Version,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return missedDelete(ctx, tx, "tournaments_results", "id", id)
+			default:
+				return err
+			}
+		}
 
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
-	}
-	return nil
+		return enqueueWebhooks(ctx, tx, enqueueWebhooksQuery, EventTournamentResultDeleted, &deleted)
+	})
 }
 
-func ValidateTournamentResult(v *validator.Validator, tr *TournamentResult, rm RikishiModel) {
+// ValidateTournamentResult records the problems with tr in v. It returns an
+// error only when rm fails to look up the rikishi.
+func ValidateTournamentResult(ctx context.Context, v *validator.Validator, tr *TournamentResult, rm ShikonaChecker) error {
 	v.Check(validator.ValidTournament(tr.Tournament), "tournament", "year must be between 1900 and 2050. Month must be 3 letters. Example: 2022 Nov")
 
 	v.Check(tr.Rikishi != "", "rikishi", "must be provided")
 	v.Check(len(tr.Rikishi) <= 500, "rikishi", "must not be more than 500 bytes long")
-	v.Check(rm.Exists(tr.Rikishi), "rikishi", "must exist in the database")
+
+	exists, err := rm.Exists(ctx, tr.Rikishi)
+	if err != nil {
+		return err
+	}
+	v.Check(exists, "rikishi", "must exist in the database")
 
 	v.Check(tr.Rank != "", "rank", "must be provided")
 	v.Check(len(tr.Rank) <= 500, "rank", "must not be more than 500 bytes long")
@@ -204,4 +419,6 @@ func ValidateTournamentResult(v *validator.Validator, tr *TournamentResult, rm R
 	v.Check(tr.Wins >= 0 && tr.Wins <= 15, "wins", "must be between 0 and 15")
 	v.Check(tr.Losses >= 0 && tr.Losses <= 15, "losses", "must be between 0 and 15")
 	v.Check(tr.Absent >= 0 && tr.Absent <= 15, "absent", "must be between 0 and 15")
+
+	return nil
 }
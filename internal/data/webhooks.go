@@ -0,0 +1,451 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/validator"
+	"github.com/lib/pq"
+)
+
+const (
+	EventBoutCreated             = "bout.created"
+	EventBoutUpdated             = "bout.updated"
+	EventBoutDeleted             = "bout.deleted"
+	EventTournamentResultCreated = "tournament_result.created"
+	EventTournamentResultUpdated = "tournament_result.updated"
+	EventTournamentResultDeleted = "tournament_result.deleted"
+)
+
+var WebhookEvents = []string{
+	EventBoutCreated,
+	EventBoutUpdated,
+	EventBoutDeleted,
+	EventTournamentResultCreated,
+	EventTournamentResultUpdated,
+	EventTournamentResultDeleted,
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+type Webhook struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"-"`
+	Owner     string    `json:"-"`
+	Active    bool      `json:"active"`
+	Version   int32     `json:"version"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type WebhookModel struct {
+	DB       *sql.DB
+	Timeouts Timeouts
+}
+
+func (m WebhookModel) Insert(ctx context.Context, webhook *Webhook) error {
+	query := `
+		INSERT INTO webhooks (url, events, secret, active, owner)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, version`
+
+	args := []interface{}{webhook.URL, pq.Array(webhook.Events), webhook.Secret, webhook.Active, webhook.Owner}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, query, args...).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.Version)
+}
+
+// Get returns the webhook with id if it belongs to owner.
+func (m WebhookModel) Get(ctx context.Context, id int64, owner string) (*Webhook, error) {
+	query := `
+		SELECT id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE id = $1 AND owner = $2`
+
+	return m.get(ctx, query, id, owner)
+}
+
+// GetForDelivery returns the webhook with id whoever owns it, for the
+// dispatcher.
+func (m WebhookModel) GetForDelivery(ctx context.Context, id int64) (*Webhook, error) {
+	query := `
+		SELECT id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE id = $1`
+
+	return m.get(ctx, query, id)
+}
+
+func (m WebhookModel) get(ctx context.Context, query string, args ...interface{}) (*Webhook, error) {
+	var webhook Webhook
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(
+		&webhook.ID,
+		&webhook.CreatedAt,
+		&webhook.URL,
+		pq.Array(&webhook.Events),
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.Owner,
+		&webhook.Version,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &webhook, nil
+}
+
+func (m WebhookModel) GetAll(ctx context.Context, owner string, filters Filters) ([]*Webhook, Metadata, error) {
+	query := `
+		SELECT count(*) OVER(), id, created_at, url, events, secret, active, owner, version
+		FROM webhooks
+		WHERE owner = $1
+		ORDER BY id
+		LIMIT $2 OFFSET $3`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, owner, filters.PageSize, filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	webhooks := []*Webhook{}
+
+	for rows.Next() {
+		var webhook Webhook
+
+		err := rows.Scan(
+			&totalRecords,
+			&webhook.ID,
+			&webhook.CreatedAt,
+			&webhook.URL,
+			pq.Array(&webhook.Events),
+			&webhook.Secret,
+			&webhook.Active,
+			&webhook.Owner,
+			&webhook.Version,
+		)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+
+		webhooks = append(webhooks, &webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return webhooks, calculateMetadata(totalRecords, filters.Page, filters.PageSize), nil
+}
+
+func (m WebhookModel) Update(ctx context.Context, webhook *Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $1, events = $2, secret = $3, active = $4, version = version + 1
+		WHERE id = $5 AND owner = $6 AND version = $7
+		RETURNING version`
+
+	args := []interface{}{
+		webhook.URL,
+		pq.Array(webhook.Events),
+		webhook.Secret,
+		webhook.Active,
+		webhook.ID,
+		webhook.Owner,
+		webhook.Version,
+	}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&webhook.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m WebhookModel) Delete(ctx context.Context, id int64, owner string) error {
+	query := `
+		DELETE FROM webhooks
+		WHERE id = $1 AND owner = $2`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id, owner)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (m WebhookModel) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, last_error, created_at, updated_at`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows, nil)
+}
+
+func (m WebhookModel) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, response_status = $4, last_error = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING updated_at`
+
+	args := []interface{}{
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.ID,
+	}
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Write)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&delivery.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m WebhookModel) GetDeliveries(ctx context.Context, webhookID int64, owner string, filters Filters) ([]*WebhookDelivery, Metadata, error) {
+	query := `
+		SELECT count(*) OVER(), d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at, d.response_status, d.last_error, d.created_at, d.updated_at
+		FROM webhook_deliveries AS d
+		JOIN webhooks AS w ON w.id = d.webhook_id
+		WHERE d.webhook_id = $1 AND w.owner = $2
+		ORDER BY d.id DESC
+		LIMIT $3 OFFSET $4`
+
+	ctx, cancel := withTimeout(ctx, m.Timeouts.Read)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, webhookID, owner, filters.PageSize, filters.offset())
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+
+	deliveries, err := scanDeliveries(rows, &totalRecords)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return deliveries, calculateMetadata(totalRecords, filters.Page, filters.PageSize), nil
+}
+
+// scanDeliveries reads delivery rows, with a leading total count column
+// when totalRecords is not nil.
+func scanDeliveries(rows *sql.Rows, totalRecords *int) ([]*WebhookDelivery, error) {
+	deliveries := []*WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var payload []byte
+
+		dest := []interface{}{
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.Event,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		}
+
+		if totalRecords != nil {
+			dest = append([]interface{}{totalRecords}, dest...)
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Payload = json.RawMessage(payload)
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// enqueueWebhooksQuery queues a delivery for every active subscriber to $1.
+const enqueueWebhooksQuery = `
+	INSERT INTO webhook_deliveries (webhook_id, event, payload)
+	SELECT id, $1::text, $2::jsonb
+	FROM webhooks
+	WHERE active AND $1 = ANY(events)`
+
+// enqueueWebhooks queues a delivery of record for every active webhook
+// subscribed to event. It runs in the transaction making the change, so the
+// deliveries are queued if and only if the change is committed.
+func enqueueWebhooks(ctx context.Context, tx *sql.Tx, query, event string, record interface{}) error {
+	payload, err := webhookPayload(event, record)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, event, string(payload))
+	return err
+}
+
+func webhookPayload(event string, record interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"event":      event,
+		"created_at": time.Now().UTC().Truncate(time.Second),
+		"data":       record,
+	})
+}
+
+// nonPublicPrefixes are the reserved ranges not covered by the netip.Addr
+// predicates in PublicAddr.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// PublicAddr reports whether addr is a public unicast address. Loopback,
+// private, link-local and multicast addresses are reachable from the server
+// but not from the internet, so webhooks must not be delivered to them.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateWebhook checks webhook. Unless allowPrivateHosts is set, URLs naming
+// a loopback or private address are rejected. Host names resolving to such
+// addresses can only be caught when the dispatcher connects.
+func ValidateWebhook(v *validator.Validator, webhook *Webhook, allowPrivateHosts bool) {
+	v.Check(webhook.URL != "", "url", "must be provided")
+	v.Check(len(webhook.URL) <= 2048, "url", "must not be more than 2048 bytes long")
+
+	if webhook.URL != "" {
+		u, err := url.Parse(webhook.URL)
+		v.Check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "url", "must be an absolute http or https URL")
+
+		if err == nil && !allowPrivateHosts {
+			host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+			addr, err := netip.ParseAddr(host)
+
+			v.Check(host != "localhost" && !strings.HasSuffix(host, ".localhost") && (err != nil || PublicAddr(addr)), "url", "must not point to a loopback or private address")
+		}
+	}
+
+	v.Check(len(webhook.Events) > 0, "events", "must contain at least 1 event")
+	v.Check(validator.Unique(webhook.Events), "events", "must not contain duplicate values")
+
+	for _, event := range webhook.Events {
+		v.Check(validator.In(event, WebhookEvents...), "events", "must only contain values from: "+strings.Join(WebhookEvents, ", "))
+	}
+
+	v.Check(len(webhook.Secret) >= 16, "secret", "must be at least 16 bytes long")
+	v.Check(len(webhook.Secret) <= 256, "secret", "must not be more than 256 bytes long")
+}
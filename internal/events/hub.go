@@ -0,0 +1,189 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+const (
+	BoutCreated = "bout.created"
+	BoutUpdated = "bout.updated"
+	BoutDeleted = "bout.deleted"
+)
+
+type Event struct {
+	ID   int64
+	Type string
+	Bout data.Bout
+}
+
+// Hub fans bout changes out to subscribers. Subscribers that fall behind by
+// more than their buffer are dropped rather than blocking publishers. The
+// most recent events are kept in a log so clients can resume after a
+// reconnect.
+//
+// Event IDs only count up within one hub, so each hub has a random epoch
+// which clients send back with the last ID they saw. A restarted process or
+// another replica has a different epoch and cannot mistake their IDs for its
+// own.
+type Hub struct {
+	mu      sync.Mutex
+	epoch   string
+	nextID  int64
+	log     []Event
+	logSize int
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+}
+
+func NewHub(logSize int) *Hub {
+	b := make([]byte, 8)
+	rand.Read(b)
+
+	return &Hub{epoch: hex.EncodeToString(b), logSize: logSize, subs: make(map[*Subscription]struct{})}
+}
+
+// Epoch identifies the hub that issued an event ID.
+func (h *Hub) Epoch() string {
+	return h.epoch
+}
+
+func (h *Hub) Publish(eventType string, bout *data.Bout) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.nextID++
+	event := Event{ID: h.nextID, Type: eventType, Bout: *bout}
+
+	if h.logSize > 0 {
+		h.log = append(h.log, event)
+		if len(h.log) > h.logSize {
+			h.log = h.log[1:]
+		}
+	}
+
+	for sub := range h.subs {
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(buffer int) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.subscribe(buffer)
+}
+
+// Resume subscribes and returns the logged events published after lastID.
+// complete is false when events after lastID have already left the log, or
+// lastID was issued by a hub with another epoch, so the client may have
+// missed some. All logged events are returned for another epoch.
+func (h *Hub) Resume(epoch string, lastID int64, buffer int) (sub *Subscription, missed []Event, complete bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if epoch != h.epoch {
+		lastID = 0
+	}
+
+	complete = epoch == h.epoch && lastID <= h.nextID && (lastID == h.nextID || len(h.log) > 0 && h.log[0].ID <= lastID+1)
+
+	for _, event := range h.log {
+		if event.ID > lastID {
+			missed = append(missed, event)
+		}
+	}
+
+	return h.subscribe(buffer), missed, complete
+}
+
+func (h *Hub) subscribe(buffer int) *Subscription {
+	sub := &Subscription{hub: h, events: make(chan Event, buffer)}
+
+	if h.closed {
+		close(sub.events)
+		return sub
+	}
+
+	h.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Close ends every subscription and makes later publishes a no-op.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+
+	for sub := range h.subs {
+		h.remove(sub)
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Events is closed when the subscription is closed, dropped for falling
+// behind or the hub shuts down.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
+
+type BoutFilter struct {
+	Tournament string
+	Day        string
+	// Shikonas matches bouts won or lost by any of the names, usually a
+	// rikishi's shikona history.
+	Shikonas []string
+}
+
+func (f BoutFilter) Matches(bout data.Bout) bool {
+	if f.Tournament != "" && bout.Tournament != f.Tournament {
+		return false
+	}
+
+	if f.Day != "" && bout.Day != f.Day {
+		return false
+	}
+
+	if len(f.Shikonas) == 0 {
+		return true
+	}
+
+	for _, shikona := range f.Shikonas {
+		if bout.Winner == shikona || bout.Loser == shikona {
+			return true
+		}
+	}
+
+	return false
+}
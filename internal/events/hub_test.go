@@ -0,0 +1,142 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub(0)
+
+	sub := hub.Subscribe(4)
+	defer sub.Close()
+
+	bout := &data.Bout{ID: 1, Winner: "Terunofuji", Loser: "Takayasu"}
+
+	hub.Publish(BoutCreated, bout)
+	bout.Winner = "Takayasu"
+	hub.Publish(BoutUpdated, bout)
+
+	for i, want := range []Event{
+		{ID: 1, Type: BoutCreated, Bout: data.Bout{ID: 1, Winner: "Terunofuji", Loser: "Takayasu"}},
+		{ID: 2, Type: BoutUpdated, Bout: data.Bout{ID: 1, Winner: "Takayasu", Loser: "Takayasu"}},
+	} {
+		got := <-sub.Events()
+		if got != want {
+			t.Errorf("event %d: got %+v; want %+v", i, got, want)
+		}
+	}
+}
+
+func TestHubDropsSlowSubscribers(t *testing.T) {
+	hub := NewHub(0)
+
+	slow := hub.Subscribe(1)
+	fast := hub.Subscribe(2)
+	defer fast.Close()
+
+	hub.Publish(BoutCreated, &data.Bout{ID: 1})
+	hub.Publish(BoutCreated, &data.Bout{ID: 2})
+
+	if _, ok := <-slow.Events(); !ok {
+		t.Fatal("got closed channel; want the buffered event")
+	}
+
+	if _, ok := <-slow.Events(); ok {
+		t.Fatal("got an event; want the slow subscriber dropped")
+	}
+
+	if len(fast.Events()) != 2 {
+		t.Errorf("got %d buffered events; want 2", len(fast.Events()))
+	}
+
+	slow.Close()
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub(0)
+
+	sub := hub.Subscribe(1)
+	hub.Close()
+
+	if _, ok := <-sub.Events(); ok {
+		t.Fatal("got an event; want the subscription closed")
+	}
+
+	hub.Publish(BoutCreated, &data.Bout{ID: 1})
+
+	if _, ok := <-hub.Subscribe(1).Events(); ok {
+		t.Fatal("got an event; want subscriptions after close to be closed")
+	}
+}
+
+func TestHubResume(t *testing.T) {
+	hub := NewHub(2)
+
+	for id := int64(1); id <= 3; id++ {
+		hub.Publish(BoutCreated, &data.Bout{ID: id})
+	}
+
+	tests := []struct {
+		name         string
+		epoch        string
+		lastID       int64
+		wantMissed   []int64
+		wantComplete bool
+	}{
+		{name: "Up to date", epoch: hub.Epoch(), lastID: 3, wantComplete: true},
+		{name: "In log", epoch: hub.Epoch(), lastID: 2, wantMissed: []int64{3}, wantComplete: true},
+		{name: "Start of log", epoch: hub.Epoch(), lastID: 1, wantMissed: []int64{2, 3}, wantComplete: true},
+		{name: "Before log", epoch: hub.Epoch(), lastID: 0, wantMissed: []int64{2, 3}, wantComplete: false},
+		{name: "Unknown", epoch: hub.Epoch(), lastID: 42, wantComplete: false},
+		{name: "Other epoch", epoch: NewHub(2).Epoch(), lastID: 2, wantMissed: []int64{2, 3}, wantComplete: false},
+		{name: "No epoch", lastID: 3, wantMissed: []int64{2, 3}, wantComplete: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, missed, complete := hub.Resume(tt.epoch, tt.lastID, 1)
+			defer sub.Close()
+
+			if complete != tt.wantComplete {
+				t.Errorf("got complete %t; want %t", complete, tt.wantComplete)
+			}
+
+			if len(missed) != len(tt.wantMissed) {
+				t.Fatalf("got %d missed events; want %d", len(missed), len(tt.wantMissed))
+			}
+
+			for i, event := range missed {
+				if event.ID != tt.wantMissed[i] {
+					t.Errorf("got missed event %d; want %d", event.ID, tt.wantMissed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBoutFilter(t *testing.T) {
+	bout := data.Bout{Tournament: "2022 Nov", Day: "1", Winner: "Takakeisho", Loser: "Takayasu"}
+
+	tests := []struct {
+		name   string
+		filter BoutFilter
+		want   bool
+	}{
+		{name: "Empty", filter: BoutFilter{}, want: true},
+		{name: "Tournament", filter: BoutFilter{Tournament: "2022 Nov"}, want: true},
+		{name: "Other tournament", filter: BoutFilter{Tournament: "2022 Sep"}, want: false},
+		{name: "Other day", filter: BoutFilter{Day: "2"}, want: false},
+		{name: "Loser", filter: BoutFilter{Shikonas: []string{"Takayasu"}}, want: true},
+		{name: "Other rikishi", filter: BoutFilter{Shikonas: []string{"Terunofuji"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(bout); got != tt.want {
+				t.Errorf("got %t; want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type executor struct {
+	params    Params
+	doc       *document
+	variables map[string]interface{}
+	defined   map[string]bool
+	args      map[*field]map[string]interface{}
+	errors    []*Error
+}
+
+func (e *executor) fieldError(err error, f *field, path []interface{}) {
+	var gqlErr *Error
+
+	message := "internal server error"
+
+	if errors.As(err, &gqlErr) {
+		message = gqlErr.Message
+	} else if e.params.ReportError != nil {
+		e.params.ReportError(err)
+	}
+
+	e.errors = append(e.errors, &Error{Message: message, Locations: []Location{f.location}, Path: path})
+}
+
+func (e *executor) executeSelections(ctx context.Context, obj *Object, sources []interface{}, set []selection, path []interface{}) []*orderedMap {
+	results := make([]*orderedMap, len(sources))
+	for i := range results {
+		results[i] = newOrderedMap()
+	}
+
+	groups, err := e.collectFields(obj, set)
+	if err != nil {
+		e.errors = append(e.errors, toError(err))
+		return results
+	}
+
+	for _, group := range groups {
+		f := group.fields[0]
+
+		fieldPath := append(append([]interface{}{}, path...), group.key)
+
+		if f.name == "__typename" {
+			for _, result := range results {
+				result.set(group.key, obj.Name)
+			}
+			continue
+		}
+
+		def, _ := e.fieldDef(obj, f.name)
+
+		values, err := def.Resolve(ctx, sources, e.args[f])
+		if err == nil && len(values) != len(sources) {
+			err = fmt.Errorf("graphql: %s.%s resolved %d values for %d sources", obj.Name, f.name, len(values), len(sources))
+		}
+		if err != nil {
+			e.fieldError(err, f, fieldPath)
+			for _, result := range results {
+				result.set(group.key, nil)
+			}
+			continue
+		}
+
+		if def.Object == nil {
+			for i, result := range results {
+				result.set(group.key, serialize(def, values[i]))
+			}
+			continue
+		}
+
+		children := []interface{}{}
+		owners := [][2]int{}
+		lists := make([][]interface{}, len(values))
+
+		for i, value := range values {
+			if !def.List {
+				if !isNil(value) {
+					children = append(children, value)
+					owners = append(owners, [2]int{i, -1})
+				}
+				continue
+			}
+
+			list, ok := toList(value)
+			if !ok {
+				e.fieldError(fmt.Errorf("graphql: %s.%s resolved %T for a list field", obj.Name, f.name, value), f, fieldPath)
+				continue
+			}
+
+			if list != nil {
+				lists[i] = make([]interface{}, len(list))
+			}
+
+			for j, item := range list {
+				if !isNil(item) {
+					children = append(children, item)
+					owners = append(owners, [2]int{i, j})
+				}
+			}
+		}
+
+		for i, result := range results {
+			if lists[i] != nil {
+				result.set(group.key, lists[i])
+			} else {
+				result.set(group.key, nil)
+			}
+		}
+
+		if len(children) == 0 {
+			continue
+		}
+
+		objects := e.executeSelections(ctx, def.Object, children, group.selectionSet(), fieldPath)
+
+		for k, owner := range owners {
+			if owner[1] < 0 {
+				results[owner[0]].set(group.key, objects[k])
+			} else {
+				lists[owner[0]][owner[1]] = objects[k]
+			}
+		}
+	}
+
+	return results
+}
+
+// fieldDef looks up a field on obj, including the introspection fields of the
+// query type.
+func (e *executor) fieldDef(obj *Object, name string) (*Field, bool) {
+	if obj == e.params.Schema.Query {
+		if def, ok := e.params.Schema.metaField(name); ok {
+			return def, true
+		}
+	}
+
+	def, ok := obj.Fields[name]
+	return def, ok
+}
+
+func serialize(def *Field, value interface{}) interface{} {
+	if def.Type != ID || isNil(value) {
+		return value
+	}
+
+	if list, ok := toList(value); ok && def.List {
+		ids := make([]interface{}, len(list))
+		for i, item := range list {
+			ids[i] = fmt.Sprint(item)
+		}
+		return ids
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func Errorf(format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...)}
+}
+
+type Params struct {
+	Schema        *Schema
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	MaxDepth      int
+	MaxComplexity int
+	ReportError   func(err error)
+}
+
+type Result struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+func Execute(ctx context.Context, p Params) *Result {
+	doc, err := parse(p.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	e := &executor{
+		params:    p,
+		doc:       doc,
+		variables: make(map[string]interface{}),
+		defined:   make(map[string]bool),
+		args:      make(map[*field]map[string]interface{}),
+	}
+
+	op, err := e.operation()
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	err = e.prepare(op)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	data := e.executeSelections(ctx, p.Schema.Query, []interface{}{nil}, op.selectionSet, nil)
+
+	return &Result{Data: data[0], Errors: e.errors}
+}
+
+func toError(err error) *Error {
+	var gqlErr *Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+
+	return &Error{Message: err.Error()}
+}
+
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		js, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(js)
+		buf.WriteByte(':')
+
+		js, err = json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(js)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
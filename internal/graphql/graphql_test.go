@@ -0,0 +1,344 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testNode struct {
+	Name     string
+	Children []*testNode
+}
+
+func testSchema(calls map[string]int) *Schema {
+	node := &Object{Name: "Node"}
+
+	node.Fields = map[string]*Field{
+		"name": {
+			Type: String,
+			Resolve: Property(func(src interface{}) interface{} {
+				return src.(*testNode).Name
+			}),
+		},
+		"children": {
+			Object:     node,
+			List:       true,
+			Args:       map[string]*Argument{"first": {Type: Int, Default: 10}},
+			Multiplier: func(args map[string]interface{}) int { return args["first"].(int) },
+			Resolve: func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+				calls["children"]++
+
+				values := make([]interface{}, len(sources))
+				for i, src := range sources {
+					children := src.(*testNode).Children
+					if first := args["first"].(int); len(children) > first {
+						children = children[:first]
+					}
+					values[i] = children
+				}
+
+				return values, nil
+			},
+		},
+		"broken": {
+			Type: String,
+			Resolve: func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+				return nil, Errorf("broken field")
+			},
+		},
+		"internal": {
+			Type: String,
+			Resolve: func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+				return nil, errors.New("connection refused")
+			},
+		},
+	}
+
+	root := &testNode{Name: "root", Children: []*testNode{
+		{Name: "a", Children: []*testNode{{Name: "a1"}, {Name: "a2"}}},
+		{Name: "b", Children: []*testNode{{Name: "b1"}}},
+	}}
+
+	query := &Object{Name: "Query", Fields: map[string]*Field{
+		"node": {
+			Object: node,
+			Args:   map[string]*Argument{"name": {Type: String, NonNull: true}},
+			Resolve: Each(func(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+				if args["name"] != root.Name {
+					return nil, nil
+				}
+				return root, nil
+			}),
+		},
+	}}
+
+	return &Schema{Query: query}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]interface{}
+		wantData   string
+		wantErrors []string
+	}{
+		{
+			name:     "Nested lists",
+			query:    `{ node(name: "root") { name children { name children { name } } } }`,
+			wantData: `{"node":{"name":"root","children":[{"name":"a","children":[{"name":"a1"},{"name":"a2"}]},{"name":"b","children":[{"name":"b1"}]}]}}`,
+		},
+		{
+			name:     "Aliases and typename",
+			query:    `{ root: node(name: "root") { __typename first: children(first: 1) { label: name } } }`,
+			wantData: `{"root":{"__typename":"Node","first":[{"label":"a"}]}}`,
+		},
+		{
+			name:      "Variables",
+			query:     `query ($name: String!, $first: Int = 1) { node(name: $name) { children(first: $first) { name } } }`,
+			variables: map[string]interface{}{"name": "root", "first": 2.0},
+			wantData:  `{"node":{"children":[{"name":"a"},{"name":"b"}]}}`,
+		},
+		{
+			name:     "Inline fragments and include",
+			query:    `query ($on: Boolean = false) { node(name: "root") { ... on Node { name } children @include(if: $on) { name } } }`,
+			wantData: `{"node":{"name":"root"}}`,
+		},
+		{
+			name:     "Missing node",
+			query:    `{ node(name: "other") { name } }`,
+			wantData: `{"node":null}`,
+		},
+		{
+			name:       "Field errors",
+			query:      `{ node(name: "root") { name broken internal } }`,
+			wantData:   `{"node":{"name":"root","broken":null,"internal":null}}`,
+			wantErrors: []string{"broken field", "internal server error"},
+		},
+		{
+			name:       "Missing required argument",
+			query:      `{ node { name } }`,
+			wantErrors: []string{`argument "name" on field "node" is required`},
+		},
+		{
+			name:       "Wrong argument type",
+			query:      `{ node(name: 1) { name } }`,
+			wantErrors: []string{`argument "name" on field "node" expected a value of type String`},
+		},
+		{
+			name:       "Undefined variable",
+			query:      `{ node(name: $name) { name } }`,
+			wantErrors: []string{"variable $name is not defined"},
+		},
+		{
+			name:       "Scalar selection",
+			query:      `{ node(name: "root") { name { length } } }`,
+			wantErrors: []string{`field "name" of type "String" must not have a selection`},
+		},
+		{
+			name:       "Missing selection",
+			query:      `{ node(name: "root") }`,
+			wantErrors: []string{`field "node" of type "Node" must have a selection of subfields`},
+		},
+		{
+			name:       "Unknown fragment",
+			query:      `{ node(name: "root") { ...missing } }`,
+			wantErrors: []string{`unknown fragment "missing"`},
+		},
+		{
+			name:       "Too deep",
+			query:      `{ node(name: "root") { children { children { children { name } } } } }`,
+			wantErrors: []string{"query depth exceeds the maximum of 4"},
+		},
+		{
+			name:       "Too complex",
+			query:      `{ node(name: "root") { children(first: 20) { children(first: 20) { name } } } }`,
+			wantErrors: []string{"query complexity"},
+		},
+		{
+			name:     "Type introspection",
+			query:    `{ __type(name: "Node") { kind name fields { name type { kind name ofType { kind name } } } } }`,
+			wantData: `{"__type":{"kind":"OBJECT","name":"Node","fields":[{"name":"broken","type":{"kind":"SCALAR","name":"String","ofType":null}},{"name":"children","type":{"kind":"LIST","name":null,"ofType":{"kind":"OBJECT","name":"Node"}}},{"name":"internal","type":{"kind":"SCALAR","name":"String","ofType":null}},{"name":"name","type":{"kind":"SCALAR","name":"String","ofType":null}}]}}`,
+		},
+		{
+			name:     "Argument introspection",
+			query:    `{ query: __type(name: "Query") { fields { name args { name defaultValue type { kind ofType { name } } } } } node: __type(name: "Node") { fields(includeDeprecated: true) { args { name defaultValue } } } }`,
+			wantData: `{"query":{"fields":[{"name":"node","args":[{"name":"name","defaultValue":null,"type":{"kind":"NON_NULL","ofType":{"name":"String"}}}]}]},"node":{"fields":[{"args":[]},{"args":[{"name":"first","defaultValue":"10"}]},{"args":[]},{"args":[]}]}}`,
+		},
+		{
+			name:     "Enum introspection",
+			query:    `{ __type(name: "__TypeKind") { kind enumValues { name } } }`,
+			wantData: `{"__type":{"kind":"ENUM","enumValues":[{"name":"SCALAR"},{"name":"OBJECT"},{"name":"INTERFACE"},{"name":"UNION"},{"name":"ENUM"},{"name":"INPUT_OBJECT"},{"name":"LIST"},{"name":"NON_NULL"}]}}`,
+		},
+		{
+			name:     "Unknown type",
+			query:    `{ __type(name: "Missing") { name } }`,
+			wantData: `{"__type":null}`,
+		},
+		{
+			name:     "Schema introspection",
+			query:    `{ __schema { queryType { name } mutationType { name } directives { name locations args { name } } } }`,
+			wantData: `{"__schema":{"queryType":{"name":"Query"},"mutationType":null,"directives":[{"name":"include","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if"}]},{"name":"skip","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if"}]}]}}`,
+		},
+		{
+			name:     "Deep introspection",
+			query:    `{ __type(name: "Node") { fields { type { ofType { ofType { name } } } } } }`,
+			wantData: `{"__type":{"fields":[{"type":{"ofType":null}},{"type":{"ofType":{"ofType":null}}},{"type":{"ofType":null}},{"type":{"ofType":null}}]}}`,
+		},
+		{
+			name:       "Introspection below the query type",
+			query:      `{ node(name: "root") { __schema { queryType { name } } } }`,
+			wantErrors: []string{`cannot query field "__schema" on type "Node"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+
+			result := Execute(context.Background(), Params{
+				Schema:        testSchema(make(map[string]int)),
+				Query:         tt.query,
+				Variables:     tt.variables,
+				MaxDepth:      4,
+				MaxComplexity: 300,
+				ReportError: func(err error) {
+					reported = append(reported, err)
+				},
+			})
+
+			if tt.wantData == "" && result.Data != nil {
+				t.Errorf("got data; want none")
+			}
+
+			if tt.wantData != "" {
+				js, err := json.Marshal(result.Data)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(js) != tt.wantData {
+					t.Errorf("got data %s; want %s", js, tt.wantData)
+				}
+			}
+
+			if len(result.Errors) != len(tt.wantErrors) {
+				t.Fatalf("got errors %+v; want %q", result.Errors, tt.wantErrors)
+			}
+
+			for i, want := range tt.wantErrors {
+				if !strings.HasPrefix(result.Errors[i].Message, want) {
+					t.Errorf("got error %q; want %q", result.Errors[i].Message, want)
+				}
+			}
+
+			for _, err := range reported {
+				if err.Error() != "connection refused" {
+					t.Errorf("got reported error %q", err)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteBatchesResolvers(t *testing.T) {
+	calls := make(map[string]int)
+
+	result := Execute(context.Background(), Params{
+		Schema: testSchema(calls),
+		Query:  `{ node(name: "root") { children { children { name } } } }`,
+	})
+
+	if len(result.Errors) > 0 {
+		t.Fatalf("got errors %+v", result.Errors)
+	}
+
+	if calls["children"] != 2 {
+		t.Errorf("got %d calls to the children resolver; want one per level", calls["children"])
+	}
+}
+
+// introspectionQuery is the query GraphiQL and most client generators send.
+const introspectionQuery = `
+	query IntrospectionQuery {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			subscriptionType { name }
+			types { ...FullType }
+			directives { name description locations args { ...InputValue } }
+		}
+	}
+
+	fragment FullType on __Type {
+		kind name description
+		fields(includeDeprecated: true) { name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason }
+		inputFields { ...InputValue }
+		interfaces { ...TypeRef }
+		enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+		possibleTypes { ...TypeRef }
+	}
+
+	fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+
+	fragment TypeRef on __Type {
+		kind name
+		ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } }
+	}`
+
+func TestIntrospectionQuery(t *testing.T) {
+	result := Execute(context.Background(), Params{
+		Schema:        testSchema(make(map[string]int)),
+		Query:         introspectionQuery,
+		MaxDepth:      4,
+		MaxComplexity: 300,
+	})
+
+	if len(result.Errors) > 0 {
+		t.Fatalf("got errors %+v", result.Errors)
+	}
+
+	js, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data struct {
+		Schema struct {
+			Types []struct {
+				Kind string
+				Name string
+			}
+		} `json:"__schema"`
+	}
+
+	err = json.Unmarshal(js, &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := make(map[string]string)
+	for _, typ := range data.Schema.Types {
+		kinds[typ.Name] = typ.Kind
+	}
+
+	want := map[string]string{
+		"Query":      "OBJECT",
+		"Node":       "OBJECT",
+		"String":     "SCALAR",
+		"Int":        "SCALAR",
+		"Boolean":    "SCALAR",
+		"__Schema":   "OBJECT",
+		"__Type":     "OBJECT",
+		"__TypeKind": "ENUM",
+	}
+
+	for name, kind := range want {
+		if kinds[name] != kind {
+			t.Errorf("got %s of kind %q; want %q", name, kinds[name], kind)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+// The introspection types describe a schema in terms of these structs, which
+// are built once per schema. Descriptions and deprecations are not tracked,
+// so they are always null and false.
+
+type typeInfo struct {
+	kind       string
+	name       string
+	fields     []*fieldInfo
+	enumValues []string
+	ofType     *typeInfo
+}
+
+type fieldInfo struct {
+	name string
+	args []*inputValueInfo
+	typ  *typeInfo
+}
+
+type inputValueInfo struct {
+	name         string
+	typ          *typeInfo
+	defaultValue interface{}
+}
+
+type directiveInfo struct {
+	name      string
+	locations []string
+	args      []*inputValueInfo
+}
+
+type schemaInfo struct {
+	types      []*typeInfo
+	byName     map[string]*typeInfo
+	query      *typeInfo
+	directives []*directiveInfo
+}
+
+// enums lists the enum types, which only occur in the introspection types.
+var enums = map[string][]string{
+	"__TypeKind":          {"SCALAR", "OBJECT", "INTERFACE", "UNION", "ENUM", "INPUT_OBJECT", "LIST", "NON_NULL"},
+	"__DirectiveLocation": {"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD", "INLINE_FRAGMENT", "VARIABLE_DEFINITION"},
+}
+
+var schemaObject, typeObject = newIntrospectionObjects()
+
+// newIntrospectionObjects builds the __Schema and __Type types and the types
+// reachable from them. They are the same for every schema.
+func newIntrospectionObjects() (*Object, *Object) {
+	schema := &Object{Name: "__Schema"}
+	typ := &Object{Name: "__Type"}
+	field := &Object{Name: "__Field"}
+	inputValue := &Object{Name: "__InputValue"}
+	value := &Object{Name: "__EnumValue"}
+	directive := &Object{Name: "__Directive"}
+
+	includeDeprecated := map[string]*Argument{"includeDeprecated": {Type: Boolean, Default: false}}
+	null := func(typ string) *Field {
+		return &Field{Type: typ, Resolve: Property(func(src interface{}) interface{} { return nil })}
+	}
+	notDeprecated := &Field{Type: Boolean, Resolve: Property(func(src interface{}) interface{} { return false })}
+
+	schema.Fields = map[string]*Field{
+		"description":      null(String),
+		"types":            {Object: typ, List: true, Resolve: Property(func(src interface{}) interface{} { return src.(*schemaInfo).types })},
+		"queryType":        {Object: typ, Resolve: Property(func(src interface{}) interface{} { return src.(*schemaInfo).query })},
+		"mutationType":     {Object: typ, Resolve: Property(func(src interface{}) interface{} { return nil })},
+		"subscriptionType": {Object: typ, Resolve: Property(func(src interface{}) interface{} { return nil })},
+		"directives":       {Object: directive, List: true, Resolve: Property(func(src interface{}) interface{} { return src.(*schemaInfo).directives })},
+	}
+
+	typ.Fields = map[string]*Field{
+		"kind": {Type: "__TypeKind", Resolve: Property(func(src interface{}) interface{} { return src.(*typeInfo).kind })},
+		"name": {Type: String, Resolve: Property(func(src interface{}) interface{} {
+			if name := src.(*typeInfo).name; name != "" {
+				return name
+			}
+			return nil
+		})},
+		"description":    null(String),
+		"specifiedByURL": null(String),
+		"fields": {Object: field, List: true, Args: includeDeprecated, Resolve: Property(func(src interface{}) interface{} {
+			if t := src.(*typeInfo); t.kind == "OBJECT" {
+				return t.fields
+			}
+			return nil
+		})},
+		"interfaces": {Object: typ, List: true, Resolve: Property(func(src interface{}) interface{} {
+			if src.(*typeInfo).kind == "OBJECT" {
+				return []*typeInfo{}
+			}
+			return nil
+		})},
+		"possibleTypes": {Object: typ, List: true, Resolve: Property(func(src interface{}) interface{} { return nil })},
+		"enumValues": {Object: value, List: true, Args: includeDeprecated, Resolve: Property(func(src interface{}) interface{} {
+			return src.(*typeInfo).enumValues
+		})},
+		"inputFields": {Object: inputValue, List: true, Args: includeDeprecated, Resolve: Property(func(src interface{}) interface{} { return nil })},
+		"ofType":      {Object: typ, Resolve: Property(func(src interface{}) interface{} { return src.(*typeInfo).ofType })},
+	}
+
+	field.Fields = map[string]*Field{
+		"name":              {Type: String, Resolve: Property(func(src interface{}) interface{} { return src.(*fieldInfo).name })},
+		"description":       null(String),
+		"args":              {Object: inputValue, List: true, Args: includeDeprecated, Resolve: Property(func(src interface{}) interface{} { return src.(*fieldInfo).args })},
+		"type":              {Object: typ, Resolve: Property(func(src interface{}) interface{} { return src.(*fieldInfo).typ })},
+		"isDeprecated":      notDeprecated,
+		"deprecationReason": null(String),
+	}
+
+	inputValue.Fields = map[string]*Field{
+		"name":              {Type: String, Resolve: Property(func(src interface{}) interface{} { return src.(*inputValueInfo).name })},
+		"description":       null(String),
+		"type":              {Object: typ, Resolve: Property(func(src interface{}) interface{} { return src.(*inputValueInfo).typ })},
+		"defaultValue":      {Type: String, Resolve: Property(func(src interface{}) interface{} { return literal(src.(*inputValueInfo).defaultValue) })},
+		"isDeprecated":      notDeprecated,
+		"deprecationReason": null(String),
+	}
+
+	value.Fields = map[string]*Field{
+		"name":              {Type: String, Resolve: Property(func(src interface{}) interface{} { return src.(string) })},
+		"description":       null(String),
+		"isDeprecated":      notDeprecated,
+		"deprecationReason": null(String),
+	}
+
+	directive.Fields = map[string]*Field{
+		"name":         {Type: String, Resolve: Property(func(src interface{}) interface{} { return src.(*directiveInfo).name })},
+		"description":  null(String),
+		"locations":    {Type: "__DirectiveLocation", List: true, Resolve: Property(func(src interface{}) interface{} { return src.(*directiveInfo).locations })},
+		"args":         {Object: inputValue, List: true, Args: includeDeprecated, Resolve: Property(func(src interface{}) interface{} { return src.(*directiveInfo).args })},
+		"isRepeatable": {Type: Boolean, Resolve: Property(func(src interface{}) interface{} { return false })},
+	}
+
+	return schema, typ
+}
+
+// literal formats an argument's default value as GraphQL input. The values
+// are scalars or lists of them, which JSON writes the same way.
+func literal(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	if v, ok := value.(enumValue); ok {
+		return string(v)
+	}
+
+	js, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return string(js)
+}
+
+// introspect describes the types reachable from the query type and the
+// introspection types, sorted by name.
+func (s *Schema) introspect() *schemaInfo {
+	info := &schemaInfo{byName: make(map[string]*typeInfo)}
+
+	named := func(name, kind string) *typeInfo {
+		t, ok := info.byName[name]
+		if !ok {
+			t = &typeInfo{kind: kind, name: name, enumValues: enums[name]}
+			info.byName[name] = t
+			info.types = append(info.types, t)
+		}
+		return t
+	}
+
+	scalar := func(name string) *typeInfo {
+		if _, ok := enums[name]; ok {
+			return named(name, "ENUM")
+		}
+		return named(name, "SCALAR")
+	}
+
+	args := func(defs map[string]*Argument) []*inputValueInfo {
+		values := []*inputValueInfo{}
+
+		for name, def := range defs {
+			t := scalar(def.Type)
+			if def.List {
+				t = &typeInfo{kind: "LIST", ofType: &typeInfo{kind: "NON_NULL", ofType: t}}
+			}
+			if def.NonNull {
+				t = &typeInfo{kind: "NON_NULL", ofType: t}
+			}
+
+			values = append(values, &inputValueInfo{name: name, typ: t, defaultValue: def.Default})
+		}
+
+		sort.Slice(values, func(i, j int) bool { return values[i].name < values[j].name })
+
+		return values
+	}
+
+	var object func(obj *Object) *typeInfo
+
+	object = func(obj *Object) *typeInfo {
+		if t, ok := info.byName[obj.Name]; ok {
+			return t
+		}
+
+		t := named(obj.Name, "OBJECT")
+		t.fields = []*fieldInfo{}
+
+		for name, def := range obj.Fields {
+			var ft *typeInfo
+			if def.Object != nil {
+				ft = object(def.Object)
+			} else {
+				ft = scalar(def.Type)
+			}
+			if def.List {
+				ft = &typeInfo{kind: "LIST", ofType: ft}
+			}
+
+			t.fields = append(t.fields, &fieldInfo{name: name, args: args(def.Args), typ: ft})
+		}
+
+		sort.Slice(t.fields, func(i, j int) bool { return t.fields[i].name < t.fields[j].name })
+
+		return t
+	}
+
+	info.query = object(s.Query)
+	object(schemaObject)
+
+	// String and Boolean are used by the introspection types, but are listed
+	// explicitly as every schema must include them.
+	scalar(String)
+	scalar(Boolean)
+
+	condition := []*inputValueInfo{{name: "if", typ: &typeInfo{kind: "NON_NULL", ofType: scalar(Boolean)}}}
+	locations := []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"}
+
+	info.directives = []*directiveInfo{
+		{name: "include", locations: locations, args: condition},
+		{name: "skip", locations: locations, args: condition},
+	}
+
+	sort.Slice(info.types, func(i, j int) bool { return info.types[i].name < info.types[j].name })
+
+	return info
+}
+
+// metaField returns the definitions of __schema and __type, which every
+// query type has on top of its own fields.
+func (s *Schema) metaField(name string) (*Field, bool) {
+	s.once.Do(func() {
+		info := s.introspect()
+
+		s.meta = map[string]*Field{
+			"__schema": {
+				Object:  schemaObject,
+				Resolve: Property(func(src interface{}) interface{} { return info }),
+			},
+			"__type": {
+				Object: typeObject,
+				Args:   map[string]*Argument{"name": {Type: String, NonNull: true}},
+				Resolve: Each(func(ctx context.Context, src interface{}, args map[string]interface{}) (interface{}, error) {
+					if t, ok := info.byName[args["name"].(string)]; ok {
+						return t, nil
+					}
+					return nil, nil
+				}),
+			},
+		}
+	})
+
+	def, ok := s.meta[name]
+	return def, ok
+}
@@ -0,0 +1,226 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, column: 1}
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return &Error{
+		Message:   "syntax error: " + fmt.Sprintf(format, args...),
+		Locations: []Location{{l.line, l.column}},
+	}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	tok := token{line: l.line, column: l.column}
+
+	if l.pos >= len(l.src) {
+		tok.kind = tokenEOF
+		return tok, nil
+	}
+
+	c := l.src[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		tok.kind, tok.value = tokenPunctuator, "..."
+		l.advance(3)
+	case strings.IndexByte("!$()&:=@[]{}|", c) >= 0:
+		tok.kind, tok.value = tokenPunctuator, string(c)
+		l.advance(1)
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind, tok.value = tokenName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		return l.string(tok)
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return tok, l.errorf("unexpected character %q", r)
+	}
+
+	return tok, nil
+}
+
+func (l *lexer) number(tok token) (token, error) {
+	start := l.pos
+	tok.kind = tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+
+	if digits() == 0 {
+		return tok, l.errorf("invalid number")
+	}
+
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if digits() == 0 {
+			return tok, l.errorf("invalid number")
+		}
+	}
+
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		tok.kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return tok, l.errorf("invalid number")
+		}
+	}
+
+	tok.value = l.src[start:l.pos]
+
+	return tok, nil
+}
+
+func (l *lexer) string(tok token) (token, error) {
+	tok.kind = tokenString
+
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		if end < 0 {
+			return tok, l.errorf("unterminated string")
+		}
+		tok.value = strings.TrimSpace(l.src[l.pos : l.pos+end])
+		l.advance(end + 3)
+		return tok, nil
+	}
+
+	l.advance(1)
+
+	var b strings.Builder
+
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return tok, l.errorf("unterminated string")
+		}
+
+		c := l.src[l.pos]
+
+		switch c {
+		case '"':
+			l.advance(1)
+			tok.value = b.String()
+			return tok, nil
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return tok, l.errorf("unterminated string")
+			}
+
+			escapes := map[byte]string{'"': `"`, '\\': `\`, '/': "/", 'b': "\b", 'f': "\f", 'n': "\n", 'r': "\r", 't': "\t"}
+
+			e := l.src[l.pos+1]
+			if s, ok := escapes[e]; ok {
+				b.WriteString(s)
+				l.advance(2)
+				continue
+			}
+
+			if e == 'u' && l.pos+6 <= len(l.src) {
+				var r rune
+				_, err := fmt.Sscanf(l.src[l.pos+2:l.pos+6], "%04x", &r)
+				if err == nil {
+					b.WriteRune(r)
+					l.advance(6)
+					continue
+				}
+			}
+
+			return tok, l.errorf("invalid escape sequence")
+		default:
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteString(l.src[l.pos : l.pos+size])
+			l.pos += size
+			l.column++
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
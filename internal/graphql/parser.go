@@ -0,0 +1,661 @@
+package graphql
+
+import (
+	"strconv"
+)
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind         string
+	name         string
+	variables    []*variableDefinition
+	directives   []*directive
+	selectionSet []selection
+}
+
+type variableDefinition struct {
+	name         string
+	typ          *typeRef
+	defaultValue *value
+}
+
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+type selection interface{}
+
+type field struct {
+	alias        string
+	name         string
+	arguments    []*argument
+	directives   []*directive
+	selectionSet []selection
+	location     Location
+}
+
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type argument struct {
+	name  string
+	value *value
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	location   Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	location      Location
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selectionSet  []selection
+	location      Location
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*argument
+}
+
+func (v *value) resolve(variables map[string]interface{}) interface{} {
+	switch v.kind {
+	case valueVariable:
+		return variables[v.raw]
+	case valueInt:
+		i, _ := strconv.Atoi(v.raw)
+		return i
+	case valueFloat:
+		f, _ := strconv.ParseFloat(v.raw, 64)
+		return f
+	case valueBoolean:
+		return v.raw == "true"
+	case valueNull:
+		return nil
+	case valueList:
+		list := make([]interface{}, 0, len(v.list))
+		for _, item := range v.list {
+			list = append(list, item.resolve(variables))
+		}
+		return list
+	case valueObject:
+		obj := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			obj[f.name] = f.value.resolve(variables)
+		}
+		return obj
+	default:
+		return v.raw
+	}
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func parse(src string) (*document, error) {
+	p := &parser{lex: newLexer(src)}
+
+	err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			set, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selectionSet: set})
+		case p.peekName("query", "mutation", "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peekName("fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.fragments[frag.name]; exists {
+				return nil, &Error{Message: "fragment " + frag.name + " is defined more than once", Locations: []Location{frag.location}}
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "document does not contain an operation"}
+	}
+
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+
+	return nil
+}
+
+func (p *parser) location() Location {
+	return Location{p.tok.line, p.tok.column}
+}
+
+func (p *parser) unexpected() error {
+	desc := p.tok.value
+	if p.tok.kind == tokenEOF {
+		desc = "end of document"
+	}
+
+	return &Error{Message: "syntax error: unexpected " + strconv.Quote(desc), Locations: []Location{p.location()}}
+}
+
+func (p *parser) peek(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) peekName(names ...string) bool {
+	if p.tok.kind != tokenName {
+		return false
+	}
+
+	for _, name := range names {
+		if p.tok.value == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+func (p *parser) skip(punctuator string) (bool, error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+
+	return true, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.tok.value
+
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.tok.value}
+
+	err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(")") {
+			def, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, def)
+		}
+
+		err = p.advance()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	op.directives, err = p.directives()
+	if err != nil {
+		return nil, err
+	}
+
+	op.selectionSet, err = p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (p *parser) variableDefinition() (*variableDefinition, error) {
+	err := p.expect("$")
+	if err != nil {
+		return nil, err
+	}
+
+	def := &variableDefinition{}
+
+	def.name, err = p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.expect(":")
+	if err != nil {
+		return nil, err
+	}
+
+	def.typ, err = p.typeRef()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		def.defaultValue, err = p.value(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return def, nil
+}
+
+func (p *parser) typeRef() (*typeRef, error) {
+	t := &typeRef{}
+
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		t.elem, err = p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect("]")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		t.name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return nil, err
+	}
+	t.nonNull = nonNull
+
+	return t, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	frag := &fragment{location: p.location()}
+
+	err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	frag.name, err = p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+
+	err = p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	frag.typeCondition, err = p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.directives()
+	if err != nil {
+		return nil, err
+	}
+
+	frag.selectionSet, err = p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return frag, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	err := p.expect("{")
+	if err != nil {
+		return nil, err
+	}
+
+	set := []selection{}
+
+	for !p.peek("}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.unexpected()
+		}
+
+		var sel selection
+
+		if p.peek("...") {
+			sel, err = p.fragmentSelection()
+		} else {
+			sel, err = p.field()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, sel)
+	}
+
+	return set, p.advance()
+}
+
+func (p *parser) fragmentSelection() (selection, error) {
+	location := p.location()
+
+	err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := &fragmentSpread{location: location}
+
+		spread.name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		spread.directives, err = p.directives()
+		if err != nil {
+			return nil, err
+		}
+
+		return spread, nil
+	}
+
+	inline := &inlineFragment{location: location}
+
+	if p.peekName("on") {
+		err = p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		inline.typeCondition, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inline.directives, err = p.directives()
+	if err != nil {
+		return nil, err
+	}
+
+	inline.selectionSet, err = p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return inline, nil
+}
+
+func (p *parser) field() (*field, error) {
+	f := &field{location: p.location()}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.alias = name
+
+		name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f.name = name
+
+	f.arguments, err = p.arguments()
+	if err != nil {
+		return nil, err
+	}
+
+	f.directives, err = p.directives()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek("{") {
+		f.selectionSet, err = p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func (p *parser) arguments() ([]*argument, error) {
+	ok, err := p.skip("(")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	args := []*argument{}
+
+	for !p.peek(")") {
+		arg := &argument{}
+
+		arg.name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect(":")
+		if err != nil {
+			return nil, err
+		}
+
+		arg.value, err = p.value(false)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	directives := []*directive{}
+
+	for p.peek("@") {
+		err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		d := &directive{}
+
+		d.name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		d.arguments, err = p.arguments()
+		if err != nil {
+			return nil, err
+		}
+
+		directives = append(directives, d)
+	}
+
+	return directives, nil
+}
+
+func (p *parser) value(constant bool) (*value, error) {
+	tok := p.tok
+
+	switch {
+	case p.peek("$") && !constant:
+		err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		return &value{kind: valueVariable, raw: name}, nil
+	case p.peek("["):
+		err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		v := &value{kind: valueList}
+
+		for !p.peek("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			v.list = append(v.list, item)
+		}
+
+		return v, p.advance()
+	case p.peek("{"):
+		err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		v := &value{kind: valueObject}
+
+		for !p.peek("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+
+			err = p.expect(":")
+			if err != nil {
+				return nil, err
+			}
+
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+
+			v.fields = append(v.fields, &argument{name: name, value: item})
+		}
+
+		return v, p.advance()
+	case tok.kind == tokenInt:
+		return &value{kind: valueInt, raw: tok.value}, p.advance()
+	case tok.kind == tokenFloat:
+		return &value{kind: valueFloat, raw: tok.value}, p.advance()
+	case tok.kind == tokenString:
+		return &value{kind: valueString, raw: tok.value}, p.advance()
+	case tok.kind == tokenName:
+		switch tok.value {
+		case "true", "false":
+			return &value{kind: valueBoolean, raw: tok.value}, p.advance()
+		case "null":
+			return &value{kind: valueNull}, p.advance()
+		default:
+			return &value{kind: valueEnum, raw: tok.value}, p.advance()
+		}
+	}
+
+	return nil, p.unexpected()
+}
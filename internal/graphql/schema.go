@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+const (
+	Int     = "Int"
+	Float   = "Float"
+	String  = "String"
+	Boolean = "Boolean"
+	ID      = "ID"
+)
+
+type ResolveFunc func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error)
+
+// Schema is safe for concurrent use, so it can be built once and shared by
+// every request.
+type Schema struct {
+	Query *Object
+
+	once sync.Once
+	meta map[string]*Field
+}
+
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+type Field struct {
+	Type       string
+	Object     *Object
+	List       bool
+	Args       map[string]*Argument
+	Multiplier func(args map[string]interface{}) int
+	Resolve    ResolveFunc
+}
+
+type Argument struct {
+	Type    string
+	List    bool
+	NonNull bool
+	Default interface{}
+}
+
+func (f *Field) typeName() string {
+	name := f.Type
+	if f.Object != nil {
+		name = f.Object.Name
+	}
+
+	if f.List {
+		return "[" + name + "]"
+	}
+
+	return name
+}
+
+func Each(fn func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)) ResolveFunc {
+	return func(ctx context.Context, sources []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		values := make([]interface{}, len(sources))
+
+		for i, source := range sources {
+			value, err := fn(ctx, source, args)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+
+		return values, nil
+	}
+}
+
+func Property(fn func(source interface{}) interface{}) ResolveFunc {
+	return Each(func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+		return fn(source), nil
+	})
+}
+
+func toList(value interface{}) ([]interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	if list, ok := value.([]interface{}); ok {
+		return list, true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	if rv.IsNil() {
+		return nil, true
+	}
+
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+
+	return list, true
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,529 @@
+package graphql
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type enumValue string
+
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+func (g *fieldGroup) selectionSet() []selection {
+	set := []selection{}
+	for _, f := range g.fields {
+		set = append(set, f.selectionSet...)
+	}
+
+	return set
+}
+
+func (e *executor) operation() (*operation, error) {
+	ops := e.doc.operations
+
+	if e.params.OperationName == "" {
+		if len(ops) > 1 {
+			return nil, Errorf("an operation name is required when the document contains multiple operations")
+		}
+		return ops[0], nil
+	}
+
+	for _, op := range ops {
+		if op.name == e.params.OperationName {
+			return op, nil
+		}
+	}
+
+	return nil, Errorf("unknown operation %q", e.params.OperationName)
+}
+
+func (e *executor) prepare(op *operation) error {
+	if op.kind != "query" {
+		return Errorf("%s operations are not supported", op.kind)
+	}
+
+	err := e.coerceVariables(op)
+	if err != nil {
+		return err
+	}
+
+	err = e.checkFragmentCycles()
+	if err != nil {
+		return err
+	}
+
+	complexity, err := e.analyze(e.params.Schema.Query, op.selectionSet, 0, true)
+	if err != nil {
+		return err
+	}
+
+	if max := e.params.MaxComplexity; max > 0 && complexity > max {
+		return Errorf("query complexity %d exceeds the maximum of %d", complexity, max)
+	}
+
+	return nil
+}
+
+func (e *executor) coerceVariables(op *operation) error {
+	for _, def := range op.variables {
+		if e.defined[def.name] {
+			return Errorf("variable $%s is defined more than once", def.name)
+		}
+		e.defined[def.name] = true
+
+		typ, list, ok := scalarType(def.typ)
+		if !ok {
+			return Errorf("variable $%s has an unsupported type", def.name)
+		}
+
+		raw, provided := e.params.Variables[def.name]
+		if !provided && def.defaultValue != nil {
+			raw, provided = def.defaultValue.resolve(nil), true
+		}
+
+		if raw == nil {
+			if def.typ.nonNull {
+				return Errorf("variable $%s of required type was not provided", def.name)
+			}
+			if provided {
+				e.variables[def.name] = nil
+			}
+			continue
+		}
+
+		value, err := coerceValue(typ, list, raw)
+		if err != nil {
+			return Errorf("variable $%s %s", def.name, err.Message)
+		}
+
+		e.variables[def.name] = value
+	}
+
+	return nil
+}
+
+func scalarType(t *typeRef) (string, bool, bool) {
+	list := false
+
+	if t.elem != nil {
+		list, t = true, t.elem
+		if t.elem != nil {
+			return "", false, false
+		}
+	}
+
+	switch t.name {
+	case Int, Float, String, Boolean, ID:
+		return t.name, list, true
+	}
+
+	return "", false, false
+}
+
+func coerceValue(typ string, list bool, raw interface{}) (interface{}, *Error) {
+	if list {
+		items, ok := raw.([]interface{})
+		if !ok {
+			items = []interface{}{raw}
+		}
+
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			if item == nil {
+				return nil, Errorf("must not contain null values")
+			}
+
+			value, err := coerceValue(typ, false, item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+
+		return values, nil
+	}
+
+	switch typ {
+	case Int:
+		switch v := raw.(type) {
+		case int:
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int(v), nil
+			}
+		}
+	case Float:
+		switch v := raw.(type) {
+		case int:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case String:
+		if v, ok := raw.(string); ok {
+			return v, nil
+		}
+	case Boolean:
+		if v, ok := raw.(bool); ok {
+			return v, nil
+		}
+	case ID:
+		switch v := raw.(type) {
+		case string:
+			return v, nil
+		case int:
+			return strconv.Itoa(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return strconv.FormatFloat(v, 'f', 0, 64), nil
+			}
+		}
+	}
+
+	return nil, Errorf("expected a value of type %s", typ)
+}
+
+func (e *executor) resolveValue(v *value) (interface{}, bool, error) {
+	if v.kind == valueVariable {
+		if !e.defined[v.raw] {
+			return nil, false, Errorf("variable $%s is not defined", v.raw)
+		}
+		value, ok := e.variables[v.raw]
+		return value, ok, nil
+	}
+
+	if v.kind == valueEnum {
+		return enumValue(v.raw), true, nil
+	}
+
+	if v.kind == valueList {
+		for _, item := range v.list {
+			if item.kind == valueVariable && !e.defined[item.raw] {
+				return nil, false, Errorf("variable $%s is not defined", item.raw)
+			}
+		}
+	}
+
+	return v.resolve(e.variables), true, nil
+}
+
+func (e *executor) coerceArguments(def *Field, f *field) (map[string]interface{}, error) {
+	provided := make(map[string]*argument, len(f.arguments))
+
+	for _, arg := range f.arguments {
+		if _, ok := def.Args[arg.name]; !ok {
+			return nil, &Error{Message: "unknown argument \"" + arg.name + "\" on field \"" + f.name + "\"", Locations: []Location{f.location}}
+		}
+		if _, ok := provided[arg.name]; ok {
+			return nil, &Error{Message: "argument \"" + arg.name + "\" is provided more than once", Locations: []Location{f.location}}
+		}
+		provided[arg.name] = arg
+	}
+
+	args := make(map[string]interface{}, len(def.Args))
+
+	for name, argDef := range def.Args {
+		var raw interface{}
+		present := false
+
+		if arg, ok := provided[name]; ok {
+			var err error
+			raw, present, err = e.resolveValue(arg.value)
+			if err != nil {
+				return nil, &Error{Message: err.Error(), Locations: []Location{f.location}}
+			}
+		}
+
+		if !present {
+			if argDef.Default != nil {
+				args[name] = argDef.Default
+			} else if argDef.NonNull {
+				return nil, &Error{Message: "argument \"" + name + "\" on field \"" + f.name + "\" is required", Locations: []Location{f.location}}
+			}
+			continue
+		}
+
+		if raw == nil {
+			if argDef.NonNull {
+				return nil, &Error{Message: "argument \"" + name + "\" on field \"" + f.name + "\" must not be null", Locations: []Location{f.location}}
+			}
+			args[name] = nil
+			continue
+		}
+
+		value, err := coerceValue(argDef.Type, argDef.List, raw)
+		if err != nil {
+			return nil, &Error{Message: "argument \"" + name + "\" on field \"" + f.name + "\" " + err.Message, Locations: []Location{f.location}}
+		}
+
+		args[name] = value
+	}
+
+	return args, nil
+}
+
+func (e *executor) included(directives []*directive) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			return false, Errorf("unknown directive @%s", d.name)
+		}
+
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			return false, Errorf("directive @%s requires a single \"if\" argument", d.name)
+		}
+
+		value, _, err := e.resolveValue(d.arguments[0].value)
+		if err != nil {
+			return false, err
+		}
+
+		cond, ok := value.(bool)
+		if !ok {
+			return false, Errorf("directive @%s expects a Boolean", d.name)
+		}
+
+		if (d.name == "skip") == cond {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (e *executor) collectFields(obj *Object, set []selection) ([]*fieldGroup, error) {
+	groups := []*fieldGroup{}
+	index := make(map[string]*fieldGroup)
+
+	var collect func(set []selection, visited map[string]bool) error
+
+	collect = func(set []selection, visited map[string]bool) error {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *field:
+				ok, err := e.included(sel.directives)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+
+				key := sel.responseKey()
+
+				group, exists := index[key]
+				if !exists {
+					group = &fieldGroup{key: key}
+					index[key] = group
+					groups = append(groups, group)
+				} else if group.fields[0].name != sel.name {
+					return &Error{Message: "fields \"" + key + "\" conflict because they select different fields", Locations: []Location{sel.location}}
+				}
+
+				group.fields = append(group.fields, sel)
+			case *fragmentSpread:
+				ok, err := e.included(sel.directives)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+
+				if visited[sel.name] {
+					continue
+				}
+				visited[sel.name] = true
+
+				frag, ok := e.doc.fragments[sel.name]
+				if !ok {
+					return &Error{Message: "unknown fragment \"" + sel.name + "\"", Locations: []Location{sel.location}}
+				}
+
+				if frag.typeCondition != obj.Name {
+					continue
+				}
+
+				err = collect(frag.selectionSet, visited)
+				if err != nil {
+					return err
+				}
+			case *inlineFragment:
+				ok, err := e.included(sel.directives)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+
+				if sel.typeCondition != "" && sel.typeCondition != obj.Name {
+					continue
+				}
+
+				err = collect(sel.selectionSet, visited)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	err := collect(set, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (e *executor) checkFragmentCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[string]int)
+
+	var visit func(name string) error
+	var walk func(set []selection) error
+
+	walk = func(set []selection) error {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *field:
+				err := walk(sel.selectionSet)
+				if err != nil {
+					return err
+				}
+			case *inlineFragment:
+				err := walk(sel.selectionSet)
+				if err != nil {
+					return err
+				}
+			case *fragmentSpread:
+				err := visit(sel.name)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	visit = func(name string) error {
+		frag, ok := e.doc.fragments[name]
+		if !ok {
+			return nil
+		}
+
+		switch state[name] {
+		case visiting:
+			return &Error{Message: "fragment \"" + name + "\" cannot spread itself", Locations: []Location{frag.location}}
+		case done:
+			return nil
+		}
+
+		state[name] = visiting
+
+		err := walk(frag.selectionSet)
+		if err != nil {
+			return err
+		}
+
+		state[name] = done
+
+		return nil
+	}
+
+	for name := range e.doc.fragments {
+		err := visit(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// analyze validates the fields selected on obj and returns their complexity.
+// The depth limit is lifted below __schema and __type, whose nested type
+// references are resolved in memory.
+func (e *executor) analyze(obj *Object, set []selection, depth int, limitDepth bool) (int, error) {
+	groups, err := e.collectFields(obj, set)
+	if err != nil {
+		return 0, err
+	}
+
+	complexity := 0
+
+	for _, group := range groups {
+		f := group.fields[0]
+		children := group.selectionSet()
+
+		if max := e.params.MaxDepth; limitDepth && max > 0 && depth+1 > max {
+			return 0, &Error{Message: "query depth exceeds the maximum of " + strconv.Itoa(max), Locations: []Location{f.location}}
+		}
+
+		if f.name == "__typename" {
+			if len(children) > 0 {
+				return 0, &Error{Message: "field \"__typename\" must not have a selection", Locations: []Location{f.location}}
+			}
+			continue
+		}
+
+		def, ok := e.fieldDef(obj, f.name)
+		if !ok {
+			return 0, &Error{Message: "cannot query field \"" + f.name + "\" on type \"" + obj.Name + "\"", Locations: []Location{f.location}}
+		}
+
+		args, err := e.coerceArguments(def, f)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, other := range group.fields {
+			e.args[other] = args
+		}
+
+		if def.Object == nil {
+			if len(children) > 0 {
+				return 0, &Error{Message: "field \"" + f.name + "\" of type \"" + def.typeName() + "\" must not have a selection", Locations: []Location{f.location}}
+			}
+			complexity++
+			continue
+		}
+
+		if len(children) == 0 {
+			return 0, &Error{Message: "field \"" + f.name + "\" of type \"" + def.typeName() + "\" must have a selection of subfields", Locations: []Location{f.location}}
+		}
+
+		meta := obj == e.params.Schema.Query && strings.HasPrefix(f.name, "__")
+
+		childComplexity, err := e.analyze(def.Object, children, depth+1, limitDepth && !meta)
+		if err != nil {
+			return 0, err
+		}
+
+		multiplier := 1
+		if def.List && def.Multiplier != nil {
+			multiplier = def.Multiplier(args)
+		}
+
+		complexity += multiplier * (1 + childComplexity)
+
+		if max := e.params.MaxComplexity; max > 0 && complexity > max {
+			return complexity, nil
+		}
+	}
+
+	return complexity, nil
+}
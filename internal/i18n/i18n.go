@@ -0,0 +1,208 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	English  = "en"
+	Japanese = "ja"
+)
+
+var Languages = []string{English, Japanese}
+
+type translation struct {
+	rx       *regexp.Regexp
+	template string
+}
+
+func t(pattern, template string) translation {
+	return translation{regexp.MustCompile("^" + pattern + "$"), template}
+}
+
+var messages = map[string][]translation{
+	Japanese: {
+		t(`must be provided`, "入力してください"),
+		t(`must not be more than (\d+) bytes long`, "${1}バイト以内で入力してください"),
+		t(`must be at least (\d+) bytes long`, "${1}バイト以上で入力してください"),
+		t(`must be between (\d+) and (\d+)`, "${1}から${2}の間で入力してください"),
+		t(`must be greater than zero`, "0より大きい値を指定してください"),
+		t(`must be a maximum of 10 million`, "最大値は1000万です"),
+		t(`must be a maximum of (\d+)`, "最大値は${1}です"),
+		t(`must exist in the database`, "データベースに登録されている必要があります"),
+		t(`must not contain duplicate values`, "重複した値を含めることはできません"),
+		t(`must contain at least 1 .+`, "1件以上指定してください"),
+		t(`must not contain more than (\d+) .+`, "${1}件以下にしてください"),
+		t(`must be a valid email address`, "有効なメールアドレスを入力してください"),
+		t(`a user with this email address already exist`, "このメールアドレスのユーザーは既に登録されています"),
+		t(`must be integer value`, "整数で指定してください"),
+		t(`must be a non-negative integer`, "0以上の整数で指定してください"),
+		t(`must be a boolean value`, "trueまたはfalseで指定してください"),
+		t(`must only contain values from: (.+)`, "次の値のみ指定できます: ${1}"),
+		t(`must be one of (.+)`, "次のいずれかを指定してください: ${1}"),
+		t(`must only contain hiragana`, "ひらがなで入力してください"),
+		t(`must be a JSON object`, "JSONオブジェクトで指定してください"),
+		t(`must be an absolute http or https URL`, "httpまたはhttpsの絶対URLを指定してください"),
+		t(`must not point to a loopback or private address`, "ループバックアドレスやプライベートアドレスは指定できません"),
+		t(`invalid sort value`, "並び順の値が正しくありません"),
+		t(`invalid cursor value`, "カーソルの値が正しくありません"),
+		t(`must match the sort the cursor was issued for`, "カーソル発行時と同じ並び順を指定してください"),
+		t(`year must be between 1900 and 2050\. Month must be 3 letters\. Example: 2022 Nov`, "年は1900から2050の間、月は英語の3文字で指定してください（例: 2022 Nov）"),
+		t(`must be a tournament\. Example: 2022 Nov`, "場所を指定してください（例: 2022 Nov）"),
+		t(`must be a number from 1 to 15\. Alternatively can be 'Playoff'`, "1から15の数字、または'Playoff'を指定してください"),
+		t(`is not supported for csv and ndjson exports`, "CSVおよびNDJSON形式では指定できません"),
+		t(`is only supported for csv and ndjson exports`, "CSVまたはNDJSON形式でのみ指定できます"),
+		t(`"(.+)" already belongs to (.+)`, "「${1}」は既に${2}の四股名です"),
+
+		t(`invalid id parameter`, "IDパラメーターが正しくありません"),
+		t(`invalid shikona parameter`, "四股名パラメーターが正しくありません"),
+		t(`body contains badly formed JSON \(at character (\d+)\)`, "リクエストボディのJSONが正しくありません（${1}文字目）"),
+		t(`body contains badly formed JSON`, "リクエストボディのJSONが正しくありません"),
+		t(`body contains incorrect JSON type for field "(.+)"`, "フィールド「${1}」の型が正しくありません"),
+		t(`body contains incorrect JSON type \(at character (\d+)\)`, "リクエストボディの型が正しくありません（${1}文字目）"),
+		t(`body must not be empty`, "リクエストボディが空です"),
+		t(`body contains unknown key (.+)`, "不明なキー${1}が含まれています"),
+		t(`body must not be larger than (\d+) bytes`, "リクエストボディは${1}バイト以下にしてください"),
+		t(`body must only contain a single JSON value`, "リクエストボディには単一のJSON値のみ指定してください"),
+
+		t(`unable to update the record due to an edit conflict, please try again later`, "編集が競合したため更新できませんでした。しばらくしてから再度お試しください"),
+		t(`invalid or missing API key`, "APIキーが無効か、指定されていません"),
+		t(`the (\S+) method is not allowed for this resourse`, "このリソースでは${1}メソッドを使用できません"),
+		t(`you must provide an API key to access this resource`, "このリソースにアクセスするにはAPIキーが必要です"),
+		t(`the requested resourse cannot be found`, "指定されたリソースが見つかりません"),
+		t(`the record has been modified since it was retrieved, fetch the latest version and try again`, "取得後にレコードが変更されました。最新のバージョンを取得して再度お試しください"),
+		t(`this request must include an If-Match header`, "このリクエストにはIf-Matchヘッダーが必要です"),
+		t(`rate limit exceeded`, "リクエスト数が上限を超えました"),
+		t(`The server encountered a problem and could not process your request`, "サーバーで問題が発生したため、リクエストを処理できませんでした"),
+		t(`the request was canceled before it could complete`, "リクエストは完了する前にキャンセルされました"),
+		t(`the server timed out waiting for the database, please try again later`, "データベースの応答がタイムアウトしました。しばらくしてから再度お試しください"),
+	},
+}
+
+var labels = map[string]map[string]string{
+	Japanese: {
+		"shikona":             "四股名",
+		"new_shikona":         "新しい四股名",
+		"shikona history":     "四股名履歴",
+		"shikona_history":     "四股名履歴",
+		"shikona_kanji":       "四股名（漢字）",
+		"shikona_kana":        "四股名（ひらがな）",
+		"highest rank":        "最高位",
+		"highest_rank":        "最高位",
+		"heya":                "部屋",
+		"heya_kanji":          "部屋（漢字）",
+		"heya_kana":           "部屋（ひらがな）",
+		"rikishi":             "力士",
+		"rank":                "番付",
+		"wins":                "勝ち",
+		"losses":              "負け",
+		"absent":              "休み",
+		"tournament":          "場所",
+		"tournaments_results": "場所成績",
+		"day":                 "日目",
+		"division":            "階級",
+		"winner":              "勝者",
+		"loser":               "敗者",
+		"kimarite":            "決まり手",
+		"bouts":               "取組",
+		"name":                "名前",
+		"email":               "メールアドレス",
+		"password":            "パスワード",
+		"page":                "ページ",
+		"page_size":           "ページサイズ",
+		"sort":                "並び順",
+		"cursor":              "カーソル",
+		"total":               "件数表示",
+		"fields":              "フィールド",
+		"include":             "関連データ",
+		"format":              "形式",
+		"all":                 "全件",
+		"from":                "開始場所",
+		"to":                  "終了場所",
+		"q":                   "検索語",
+		"types":               "種類",
+		"limit":               "件数",
+		"query":               "クエリ",
+		"variables":           "変数",
+		"last_event_id":       "最終イベントID",
+		"url":                 "URL",
+		"events":              "イベント",
+		"secret":              "シークレット",
+		"level":               "ログレベル",
+	},
+}
+
+func Negotiate(header string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+
+	candidates := []candidate{}
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		for _, lang := range Languages {
+			if primary == lang && q > 0 {
+				candidates = append(candidates, candidate{lang, q})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	if len(candidates) == 0 {
+		return English
+	}
+
+	return candidates[0].lang
+}
+
+func Message(lang, message string) string {
+	for _, tr := range messages[lang] {
+		if tr.rx.MatchString(message) {
+			return tr.rx.ReplaceAllString(message, tr.template)
+		}
+	}
+
+	return message
+}
+
+func Label(lang, field string) string {
+	if label, ok := labels[lang][field]; ok {
+		return label
+	}
+
+	return field
+}
+
+func Errors(lang string, errors map[string]string) map[string]string {
+	if lang == English {
+		return errors
+	}
+
+	localized := make(map[string]string, len(errors))
+
+	for field, message := range errors {
+		localized[field] = Label(lang, field) + ": " + Message(lang, message)
+	}
+
+	return localized
+}
@@ -0,0 +1,235 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// validatorMessages collects the messages passed to Validator.Check and
+// Validator.AddError across the module. Parts of a message computed at run
+// time are replaced with sample values.
+func validatorMessages(t *testing.T) map[string]string {
+	t.Helper()
+
+	messages := make(map[string]string)
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && (d.Name() == ".git" || d.Name() == "vendor") {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "Check" && sel.Sel.Name != "AddError") {
+				return true
+			}
+
+			if message, ok := sampleMessage(call.Args[len(call.Args)-1]); ok {
+				messages[message] = fset.Position(call.Pos()).String()
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return messages
+}
+
+// sampleMessage evaluates a message expression. Only messages built from
+// string literals are reported, as anything else is passed through.
+func sampleMessage(expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.STRING {
+			return "", false
+		}
+
+		s, err := strconv.Unquote(expr.Value)
+		return s, err == nil
+	case *ast.BinaryExpr:
+		left, ok := sampleMessage(expr.X)
+		if !ok || expr.Op != token.ADD {
+			return "", false
+		}
+
+		right, ok := sampleMessage(expr.Y)
+		if !ok {
+			right = sampleValue(expr.Y)
+		}
+
+		return left + right, true
+	case *ast.CallExpr:
+		sel, ok := expr.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Sprintf" || len(expr.Args) == 0 {
+			return "", false
+		}
+
+		format, ok := sampleMessage(expr.Args[0])
+		if !ok {
+			return "", false
+		}
+
+		return strings.NewReplacer("%d", "3", "%q", `"x"`, "%s", "x", "%v", "x").Replace(format), true
+	default:
+		return "", false
+	}
+}
+
+func sampleValue(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Itoa" {
+			return "3"
+		}
+	}
+
+	return "x"
+}
+
+func TestValidatorMessagesTranslated(t *testing.T) {
+	messages := validatorMessages(t)
+	if len(messages) < 20 {
+		t.Fatalf("found %d validator messages; the source walk is broken", len(messages))
+	}
+
+	for message, pos := range messages {
+		if got := Message(Japanese, message); got == message {
+			t.Errorf("%s: %q has no Japanese translation", pos, message)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"Empty", "", English},
+		{"Japanese", "ja", Japanese},
+		{"Region subtag", "ja-JP", Japanese},
+		{"Case insensitive", "JA-jp", Japanese},
+		{"Unsupported", "fr-FR, de", English},
+		{"First supported", "fr, ja, en", Japanese},
+		{"Highest q-value", "en;q=0.5, ja;q=0.8", Japanese},
+		{"Default q-value", "ja;q=0.9, en", English},
+		{"Equal q-values", "en;q=0.7, ja;q=0.7", English},
+		{"Refused", "ja;q=0", English},
+		{"Malformed q-value", "ja;q=high, en;q=0.1", English},
+		{"Whitespace", " ja ; q=0.4 ,fr", Japanese},
+		{"Wildcard", "*", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.header); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		message string
+		want    string
+	}{
+		{"Fixed", Japanese, "must be provided", "入力してください"},
+		{"English", English, "must be provided", "must be provided"},
+		{"Unknown language", "fr", "must be provided", "must be provided"},
+		{"Unknown message", Japanese, "must be a prime number", "must be a prime number"},
+		{"Partial match", Japanese, "name must be provided", "name must be provided"},
+		{"Byte limit", Japanese, "must not be more than 500 bytes long", "500バイト以内で入力してください"},
+		{"Byte minimum", Japanese, "must be at least 16 bytes long", "16バイト以上で入力してください"},
+		{"Range", Japanese, "must be between 0 and 15", "0から15の間で入力してください"},
+		{"Specific maximum", Japanese, "must be a maximum of 10 million", "最大値は1000万です"},
+		{"Maximum", Japanese, "must be a maximum of 100", "最大値は100です"},
+		{"Count limit", Japanese, "must not contain more than 3 tournament results", "3件以下にしてください"},
+		{"Values list", Japanese, "must only contain values from: rikishi, bout", "次の値のみ指定できます: rikishi, bout"},
+		{"Quoted values", Japanese, `"Kisenosato" already belongs to Kisenosato`, "「Kisenosato」は既にKisenosatoの四股名です"},
+		{"Escaped pattern", Japanese, "must be a tournament. Example: 2022 Nov", "場所を指定してください（例: 2022 Nov）"},
+		{"Escaped pattern mismatch", Japanese, "must be a tournament! Example: 2022 Nov", "must be a tournament! Example: 2022 Nov"},
+		{"JSON position", Japanese, "body contains badly formed JSON (at character 12)", "リクエストボディのJSONが正しくありません（12文字目）"},
+		{"Method", Japanese, "the PATCH method is not allowed for this resourse", "このリソースではPATCHメソッドを使用できません"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.lang, tt.message); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	if got := Label(Japanese, "shikona"); got != "四股名" {
+		t.Errorf("got %q; want 四股名", got)
+	}
+
+	if got := Label(Japanese, "nickname"); got != "nickname" {
+		t.Errorf("got %q for an unknown field; want it unchanged", got)
+	}
+
+	if got := Label(English, "shikona"); got != "shikona" {
+		t.Errorf("got %q in English; want it unchanged", got)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	errors := map[string]string{
+		"shikona":  "must be provided",
+		"page":     "must be a maximum of 10 million",
+		"nickname": "must be a prime number",
+	}
+
+	if got := Errors(English, errors); !maps.Equal(got, errors) {
+		t.Errorf("got %v in English; want the errors unchanged", got)
+	}
+
+	want := map[string]string{
+		"shikona":  "四股名: 入力してください",
+		"page":     "ページ: 最大値は1000万です",
+		"nickname": "nickname: must be a prime number",
+	}
+
+	if got := Errors(Japanese, errors); !maps.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	if errors["shikona"] != "must be provided" {
+		t.Error("got the errors modified in place")
+	}
+}
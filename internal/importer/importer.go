@@ -0,0 +1,296 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+	"github.com/corsairconstantine/sumodb/internal/validator"
+)
+
+type pendingRow struct {
+	source string
+	line   int
+	insert func(ctx context.Context, tx data.ImportTx) error
+}
+
+type Importer struct {
+	models    data.Models
+	logger    *jsonlog.Logger
+	report    io.Writer
+	dryRun    bool
+	batchSize int
+
+	aliases    map[string]string
+	known      data.ShikonaSet
+	seen       map[string]bool
+	rikishis   map[string]*data.Rikishi
+	pending    []pendingRow
+	imported   int
+	rejected   int
+	duplicates int
+}
+
+func New(ctx context.Context, models data.Models, logger *jsonlog.Logger, report io.Writer, dryRun bool, batchSize int) (*Importer, error) {
+	aliases, err := models.Rikishis.Aliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &Importer{
+		models:    models,
+		logger:    logger,
+		report:    report,
+		dryRun:    dryRun,
+		batchSize: batchSize,
+		aliases:   make(map[string]string),
+		known:     make(data.ShikonaSet),
+		seen:      make(map[string]bool),
+		rikishis:  make(map[string]*data.Rikishi),
+	}
+
+	for alias, shikona := range aliases {
+		imp.addAlias(alias, shikona)
+	}
+
+	return imp, nil
+}
+
+func (imp *Importer) addAlias(alias, shikona string) {
+	imp.aliases[strings.ToLower(alias)] = shikona
+	imp.known[shikona] = true
+}
+
+func (imp *Importer) Resolve(shikona string) string {
+	if current, ok := imp.aliases[strings.ToLower(strings.TrimSpace(shikona))]; ok {
+		return current
+	}
+
+	return strings.TrimSpace(shikona)
+}
+
+func (imp *Importer) Known(shikona string) bool {
+	_, ok := imp.aliases[strings.ToLower(strings.TrimSpace(shikona))]
+	return ok
+}
+
+func (imp *Importer) duplicate(source string, line int, description string) {
+	imp.duplicates++
+
+	fmt.Fprintf(imp.report, "%s:%d: duplicate: %s\n", source, line, description)
+}
+
+func (imp *Importer) Reject(source string, line int, errors map[string]string) {
+	imp.rejected++
+
+	keys := make([]string, 0, len(errors))
+	for key := range errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, len(keys))
+	for i, key := range keys {
+		messages[i] = fmt.Sprintf("%s: %s", key, errors[key])
+	}
+
+	fmt.Fprintf(imp.report, "%s:%d: %s\n", source, line, strings.Join(messages, "; "))
+}
+
+func (imp *Importer) Rikishi(source string, line int, rikishi *data.Rikishi) error {
+	if len(rikishi.ShikonaHistory) == 0 || rikishi.ShikonaHistory[len(rikishi.ShikonaHistory)-1] != rikishi.Shikona {
+		rikishi.ShikonaHistory = append(rikishi.ShikonaHistory, rikishi.Shikona)
+	}
+
+	duplicate, err := imp.isDuplicateRikishi(rikishi)
+	if err != nil {
+		return err
+	}
+
+	if duplicate {
+		imp.duplicate(source, line, rikishi.Shikona)
+		return nil
+	}
+
+	v := validator.New()
+
+	data.ValidateRikishi(v, rikishi)
+	for _, alias := range rikishi.ShikonaHistory {
+		if current, ok := imp.aliases[strings.ToLower(alias)]; ok {
+			v.AddError("shikona", fmt.Sprintf("%q already belongs to %s", alias, current))
+		}
+	}
+
+	if !v.Valid() {
+		imp.Reject(source, line, v.Errors)
+		return nil
+	}
+
+	for _, alias := range rikishi.ShikonaHistory {
+		imp.addAlias(alias, rikishi.Shikona)
+	}
+	imp.rikishis[rikishi.Shikona] = rikishi
+
+	return imp.add(source, line, func(ctx context.Context, tx data.ImportTx) error {
+		return tx.InsertRikishi(ctx, rikishi)
+	})
+}
+
+// isDuplicateRikishi reports whether rikishi matches one already in the
+// database or earlier in the import, so that an import can be re-run. A row
+// that differs is left to be rejected as a conflict.
+func (imp *Importer) isDuplicateRikishi(rikishi *data.Rikishi) (bool, error) {
+	existing, ok := imp.rikishis[rikishi.Shikona]
+	if !ok {
+		if imp.aliases[strings.ToLower(rikishi.Shikona)] != rikishi.Shikona {
+			return false, nil
+		}
+
+		var err error
+
+		existing, err = imp.models.Rikishis.Get(context.Background(), rikishi.Shikona)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return false, nil
+			}
+
+			return false, err
+		}
+	}
+
+	return existing.HighestRank == rikishi.HighestRank &&
+		existing.Heya == rikishi.Heya &&
+		slices.Equal(existing.ShikonaHistory, rikishi.ShikonaHistory) &&
+		existing.ShikonaKanji == rikishi.ShikonaKanji &&
+		existing.ShikonaKana == rikishi.ShikonaKana &&
+		existing.HeyaKanji == rikishi.HeyaKanji &&
+		existing.HeyaKana == rikishi.HeyaKana, nil
+}
+
+func (imp *Importer) TournamentResult(source string, line int, tr *data.TournamentResult) error {
+	tr.Rikishi = imp.Resolve(tr.Rikishi)
+
+	v := validator.New()
+	err := data.ValidateTournamentResult(context.Background(), v, tr, imp.known)
+	if err != nil {
+		return err
+	}
+
+	if !v.Valid() {
+		imp.Reject(source, line, v.Errors)
+		return nil
+	}
+
+	key := strings.Join([]string{"tournament_result", tr.Tournament, tr.Rikishi}, "|")
+	description := fmt.Sprintf("%s result for %s", tr.Tournament, tr.Rikishi)
+
+	duplicate, err := imp.models.TournamentsResults.IsDuplicate(context.Background(), tr)
+	if err != nil {
+		return err
+	}
+
+	if duplicate || imp.seen[key] {
+		imp.duplicate(source, line, description)
+		return nil
+	}
+	imp.seen[key] = true
+
+	return imp.add(source, line, func(ctx context.Context, tx data.ImportTx) error {
+		return tx.InsertTournamentResult(ctx, tr)
+	})
+}
+
+func (imp *Importer) Bout(source string, line int, bout *data.Bout) error {
+	bout.Winner = imp.Resolve(bout.Winner)
+	bout.Loser = imp.Resolve(bout.Loser)
+
+	v := validator.New()
+	err := data.ValidateBout(context.Background(), v, bout, imp.known)
+	if err != nil {
+		return err
+	}
+
+	if !v.Valid() {
+		imp.Reject(source, line, v.Errors)
+		return nil
+	}
+
+	pair := []string{bout.Winner, bout.Loser}
+	sort.Strings(pair)
+
+	key := strings.Join(append([]string{"bout", bout.Tournament, bout.Day}, pair...), "|")
+	description := fmt.Sprintf("%s day %s %s vs %s", bout.Tournament, bout.Day, bout.Winner, bout.Loser)
+
+	duplicate, err := imp.models.Bouts.IsDuplicate(context.Background(), bout)
+	if err != nil {
+		return err
+	}
+
+	if duplicate || imp.seen[key] {
+		imp.duplicate(source, line, description)
+		return nil
+	}
+	imp.seen[key] = true
+
+	return imp.add(source, line, func(ctx context.Context, tx data.ImportTx) error {
+		return tx.InsertBout(ctx, bout)
+	})
+}
+
+func (imp *Importer) add(source string, line int, insert func(ctx context.Context, tx data.ImportTx) error) error {
+	imp.pending = append(imp.pending, pendingRow{source: source, line: line, insert: insert})
+
+	if len(imp.pending) >= imp.batchSize {
+		return imp.Flush()
+	}
+
+	return nil
+}
+
+func (imp *Importer) Flush() error {
+	if len(imp.pending) == 0 {
+		return nil
+	}
+
+	if !imp.dryRun {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		err := imp.models.Imports.Import(ctx, func(tx data.ImportTx) error {
+			for _, row := range imp.pending {
+				err := row.insert(ctx, tx)
+				if err != nil {
+					return fmt.Errorf("%s:%d: %w", row.source, row.line, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	imp.imported += len(imp.pending)
+	imp.pending = imp.pending[:0]
+
+	imp.logger.PrintInfo("batch processed", jsonlog.Properties{
+		"imported":   imp.imported,
+		"rejected":   imp.rejected,
+		"duplicates": imp.duplicates,
+		"dry_run":    imp.dryRun,
+	})
+
+	return nil
+}
+
+func (imp *Importer) Summary() (imported, rejected, duplicates int) {
+	return imp.imported, imp.rejected, imp.duplicates
+}
@@ -0,0 +1,170 @@
+package importer_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"github.com/corsairconstantine/sumodb/internal/importer"
+	"github.com/corsairconstantine/sumodb/internal/jsonlog"
+)
+
+type counts struct {
+	imported, rejected, duplicates int
+}
+
+// row feeds one row to the importer. Rows are built on each call, as the
+// importer fills in their history, IDs and versions.
+type row func(imp *importer.Importer) error
+
+func rikishi(shikona, heya string, history ...string) row {
+	return func(imp *importer.Importer) error {
+		return imp.Rikishi("rikishis.csv", 2, &data.Rikishi{Shikona: shikona, HighestRank: "Yokozuna", Heya: heya, ShikonaHistory: history})
+	}
+}
+
+func result(tournament, shikona string) row {
+	return func(imp *importer.Importer) error {
+		return imp.TournamentResult("results.csv", 2, &data.TournamentResult{Tournament: tournament, Rikishi: shikona, Rank: "Y1e", Wins: 12, Losses: 3})
+	}
+}
+
+func bout(day, winner, loser string) row {
+	return func(imp *importer.Importer) error {
+		return imp.Bout("bouts.csv", 2, &data.Bout{Tournament: "2022 Nov", Day: day, Division: "Makuuchi", Winner: winner, Loser: loser, Kimarite: "yorikiri"})
+	}
+}
+
+var fixture = []row{
+	rikishi("Terunofuji", "Isegahama", "Wakamisho"),
+	rikishi("Takakeisho", "Tokiwayama"),
+	result("2022 Nov", "Terunofuji"),
+	result("2022 Sep", "Wakamisho"),
+	bout("1", "Terunofuji", "Takakeisho"),
+	bout("2", "Takakeisho", "Wakamisho"),
+}
+
+func runImport(t *testing.T, models data.Models, dryRun bool, rows ...row) (counts, string) {
+	t.Helper()
+
+	var report bytes.Buffer
+
+	imp, err := importer.New(t.Context(), models, jsonlog.New(io.Discard, jsonlog.LevelInfo), &report, dryRun, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		err = row(imp)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = imp.Flush()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c counts
+	c.imported, c.rejected, c.duplicates = imp.Summary()
+
+	return c, report.String()
+}
+
+func assertCounts(t *testing.T, got, want counts, report string) {
+	t.Helper()
+
+	if got != want {
+		t.Errorf("got %+v; want %+v; report:\n%s", got, want, report)
+	}
+}
+
+func TestImporter(t *testing.T) {
+	t.Run("Insert", func(t *testing.T) {
+		models := data.NewMemoryModels()
+
+		got, report := runImport(t, models, false, fixture...)
+		assertCounts(t, got, counts{imported: 6}, report)
+
+		rikishi, err := models.Rikishis.Get(t.Context(), "Terunofuji")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(rikishi.ShikonaHistory, []string{"Wakamisho", "Terunofuji"}) {
+			t.Errorf("got shikona history %v", rikishi.ShikonaHistory)
+		}
+
+		bouts, _, err := models.Bouts.GetAll(t.Context(), "", "", "", "", nil, nil, []string{"Takakeisho"}, nil, "", "", data.Filters{Page: 1, PageSize: 20, Sort: "id", SortSafelist: []string{"id"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(bouts) != 1 || bouts[0].Loser != "Terunofuji" {
+			t.Errorf("got %d bouts won by Takakeisho; want the one with the resolved loser", len(bouts))
+		}
+	})
+
+	t.Run("Re-run", func(t *testing.T) {
+		models := data.NewMemoryModels()
+
+		runImport(t, models, false, fixture...)
+
+		got, report := runImport(t, models, false, fixture...)
+		assertCounts(t, got, counts{duplicates: 6}, report)
+
+		if strings.Contains(report, "already belongs to") {
+			t.Errorf("got existing rikishis rejected:\n%s", report)
+		}
+	})
+
+	t.Run("Duplicates in one import", func(t *testing.T) {
+		models := data.NewMemoryModels()
+
+		got, report := runImport(t, models, false, append(fixture, fixture...)...)
+		assertCounts(t, got, counts{imported: 6, duplicates: 6}, report)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		models := data.NewMemoryModels()
+
+		runImport(t, models, false, fixture...)
+
+		got, report := runImport(t, models, false,
+			rikishi("Terunofuji", "Miyagino", "Wakamisho"),
+			rikishi("Hakuho", "Miyagino", "Wakamisho"),
+			rikishi("Takakeisho", "Tokiwayama"),
+		)
+		assertCounts(t, got, counts{rejected: 2, duplicates: 1}, report)
+
+		if n := strings.Count(report, "already belongs to Terunofuji"); n != 2 {
+			t.Errorf("got %d shikona conflicts reported; want 2:\n%s", n, report)
+		}
+
+		rikishi, err := models.Rikishis.Get(t.Context(), "Terunofuji")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rikishi.Heya != "Isegahama" {
+			t.Errorf("got heya %q; want the existing row kept", rikishi.Heya)
+		}
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		models := data.NewMemoryModels()
+
+		got, report := runImport(t, models, true, append(fixture, rikishi("Hakuho", "Miyagino", "Takakeisho"))...)
+		assertCounts(t, got, counts{imported: 6, rejected: 1}, report)
+
+		_, err := models.Rikishis.Get(t.Context(), "Terunofuji")
+		if !errors.Is(err, data.ErrRecordNotFound) {
+			t.Errorf("got %v; want nothing written", err)
+		}
+	})
+}
@@ -2,17 +2,23 @@ package jsonlog
 
 import (
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"io"
 	"os"
 	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Level int8
 
 const (
-	LevelInfo Level = iota
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
 	LevelError
 	LevelFatal
 	LevelOff
@@ -20,62 +26,158 @@ const (
 
 func (l Level) String() string {
 	switch l {
+	case LevelDebug:
+		return "DEBUG"
 	case LevelInfo:
 		return "INFO"
+	case LevelWarn:
+		return "WARN"
 	case LevelError:
 		return "ERROR"
 	case LevelFatal:
 		return "FATAL"
+	case LevelOff:
+		return "OFF"
 	default:
 		return ""
 	}
 }
 
+func ParseLevel(s string) (Level, error) {
+	for l := LevelDebug; l <= LevelOff; l++ {
+		if strings.EqualFold(s, l.String()) {
+			return l, nil
+		}
+	}
+
+	return 0, fmt.Errorf("jsonlog: unknown level %q", s)
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(l.String())), nil
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+	return nil
+}
+
+// Properties are written as JSON. Errors and durations are written as
+// strings, since they would otherwise marshal as {} and nanoseconds.
+type Properties map[string]interface{}
+
+// Sampling limits repeated messages. Within each Tick, the first First
+// messages with the same level and text are written, then every
+// Thereafter-th; a Thereafter of zero drops the rest. Tick defaults to a
+// second and fatal messages are never sampled.
+type Sampling struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+type Options struct {
+	MinLevel Level
+	// FatalStacksOnly skips capturing stack traces for errors, which is
+	// expensive and noisy, keeping them for fatal messages.
+	FatalStacksOnly bool
+	// Sampling is disabled when nil.
+	Sampling *Sampling
+}
+
 type Logger struct {
-	out      io.Writer
-	minLevel Level
-	mu       sync.Mutex
+	out             io.Writer
+	minLevel        atomic.Int32
+	fatalStacksOnly bool
+	sampler         *sampler
+	mu              sync.Mutex
 }
 
 func New(out io.Writer, minLevel Level) *Logger {
-	return &Logger{
-		out:      out,
-		minLevel: minLevel,
+	return NewWithOptions(out, Options{MinLevel: minLevel})
+}
+
+func NewWithOptions(out io.Writer, opts Options) *Logger {
+	l := &Logger{
+		out:             out,
+		fatalStacksOnly: opts.FatalStacksOnly,
+	}
+
+	l.minLevel.Store(int32(opts.MinLevel))
+
+	if opts.Sampling != nil && opts.Sampling.First > 0 {
+		l.sampler = &sampler{Sampling: *opts.Sampling}
+
+		if l.sampler.Tick <= 0 {
+			l.sampler.Tick = time.Second
+		}
 	}
+
+	return l
+}
+
+func (l *Logger) Level() Level {
+	return Level(l.minLevel.Load())
+}
+
+// SetLevel changes the minimum level while the logger is in use.
+func (l *Logger) SetLevel(level Level) {
+	l.minLevel.Store(int32(level))
+}
+
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
 }
 
-func (l *Logger) PrintInfo(message string, properties map[string]string) {
+func (l *Logger) PrintDebug(message string, properties Properties) {
+	l.print(LevelDebug, message, properties)
+}
+
+func (l *Logger) PrintInfo(message string, properties Properties) {
 	l.print(LevelInfo, message, properties)
 }
 
-func (l *Logger) PrintError(err error, properties map[string]string) {
+func (l *Logger) PrintWarn(message string, properties Properties) {
+	l.print(LevelWarn, message, properties)
+}
+
+func (l *Logger) PrintError(err error, properties Properties) {
 	l.print(LevelError, err.Error(), properties)
 }
 
-func (l *Logger) PrintFatal(err error, properties map[string]string) {
+func (l *Logger) PrintFatal(err error, properties Properties) {
 	l.print(LevelFatal, err.Error(), properties)
 	os.Exit(1)
 }
 
-func (l *Logger) print(level Level, message string, properties map[string]string) (int, error) {
-	if level < l.minLevel {
+func (l *Logger) print(level Level, message string, properties Properties) (int, error) {
+	if !l.Enabled(level) || level >= LevelOff {
+		return 0, nil
+	}
+
+	if l.sampler != nil && level < LevelFatal && !l.sampler.allow(level, message, time.Now()) {
 		return 0, nil
 	}
 
 	aux := struct {
-		Level      string            `json:"level"`
-		Time       string            `json:"time"`
-		Message    string            `json:"message"`
-		Properties map[string]string `json:"properties,omitempty"`
-		Trace      string            `json:"trace,omitempty"`
+		Level      string     `json:"level"`
+		Time       string     `json:"time"`
+		Message    string     `json:"message"`
+		Properties Properties `json:"properties,omitempty"`
+		Trace      string     `json:"trace,omitempty"`
 	}{
 		Level:      level.String(),
 		Time:       time.Now().UTC().Format(time.RFC3339),
 		Message:    message,
-		Properties: properties,
+		Properties: encodable(properties),
 	}
 
-	if level >= LevelError {
+	if level == LevelFatal || (level >= LevelError && !l.fatalStacksOnly) {
 		aux.Trace = string(debug.Stack())
 	}
 
@@ -92,6 +194,66 @@ func (l *Logger) print(level Level, message string, properties map[string]string
 	return l.out.Write(append(line, '\n'))
 }
 
+func encodable(properties Properties) Properties {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	out := make(Properties, len(properties))
+
+	for key, value := range properties {
+		switch v := value.(type) {
+		case error:
+			out[key] = v.Error()
+		case time.Duration:
+			out[key] = v.String()
+		default:
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
 func (l *Logger) Write(message []byte) (n int, err error) {
 	return l.print(LevelError, string(message), nil)
 }
+
+// sampler counts messages in a fixed table indexed by hash, so messages
+// containing unbounded values cannot grow it; collisions only make
+// sampling slightly more aggressive.
+type sampler struct {
+	Sampling
+	mu     sync.Mutex
+	counts [4096]sampleCount
+}
+
+type sampleCount struct {
+	window int64
+	n      int
+}
+
+func (s *sampler) allow(level Level, message string, now time.Time) bool {
+	h := fnv.New32a()
+	h.Write([]byte{byte(level)})
+	h.Write([]byte(message))
+
+	window := now.UnixNano() / int64(s.Tick)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := &s.counts[h.Sum32()%uint32(len(s.counts))]
+	if c.window != window {
+		c.window = window
+		c.n = 0
+	}
+
+	c.n++
+
+	if c.n <= s.First {
+		return true
+	}
+
+	return s.Thereafter > 0 && (c.n-s.First)%s.Thereafter == 0
+}
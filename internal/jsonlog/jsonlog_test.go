@@ -0,0 +1,180 @@
+package jsonlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type line struct {
+	Level      string                 `json:"level"`
+	Message    string                 `json:"message"`
+	Properties map[string]interface{} `json:"properties"`
+	Trace      string                 `json:"trace"`
+}
+
+func readLines(t *testing.T, buf *bytes.Buffer) []line {
+	t.Helper()
+
+	var lines []line
+
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		var l line
+
+		err := json.Unmarshal(scanner.Bytes(), &l)
+		if err != nil {
+			t.Fatalf("decoding %q: %s", scanner.Text(), err)
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelWarn)
+
+	l.PrintDebug("debug", nil)
+	l.PrintInfo("info", nil)
+	l.PrintWarn("warn", nil)
+	l.PrintError(errors.New("error"), nil)
+
+	l.SetLevel(LevelDebug)
+	l.PrintDebug("debug again", nil)
+
+	var got []string
+	for _, line := range readLines(t, &buf) {
+		got = append(got, line.Level+" "+line.Message)
+	}
+
+	want := []string{"WARN warn", "ERROR error", "DEBUG debug again"}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %q; want %q", got, want)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, s := range []string{"debug", "INFO", "Warn", "error", "fatal", "off"} {
+		level, err := ParseLevel(s)
+		if err != nil {
+			t.Errorf("ParseLevel(%q): %s", s, err)
+			continue
+		}
+
+		text, _ := level.MarshalText()
+
+		var parsed Level
+		if err := parsed.UnmarshalText(text); err != nil || parsed != level {
+			t.Errorf("got %v round-tripping %q; want %v", parsed, text, level)
+		}
+	}
+
+	_, err := ParseLevel("loud")
+	if err == nil {
+		t.Error("got no error for an unknown level")
+	}
+}
+
+func TestProperties(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelDebug)
+
+	properties := Properties{
+		"status":   404,
+		"dry_run":  true,
+		"duration": 1500 * time.Millisecond,
+		"cause":    errors.New("boom"),
+		"level":    LevelWarn,
+	}
+
+	l.PrintInfo("typed", properties)
+
+	got := readLines(t, &buf)[0].Properties
+
+	for key, want := range map[string]interface{}{
+		"status":   float64(404),
+		"dry_run":  true,
+		"duration": "1.5s",
+		"cause":    "boom",
+		"level":    "warn",
+	} {
+		if got[key] != want {
+			t.Errorf("got %s %#v; want %#v", key, got[key], want)
+		}
+	}
+
+	if _, ok := properties["cause"].(error); !ok {
+		t.Error("got the caller's properties modified")
+	}
+}
+
+func TestStackTraces(t *testing.T) {
+	var buf bytes.Buffer
+
+	New(&buf, LevelDebug).PrintError(errors.New("boom"), nil)
+	NewWithOptions(&buf, Options{MinLevel: LevelDebug, FatalStacksOnly: true}).PrintError(errors.New("boom"), nil)
+
+	lines := readLines(t, &buf)
+
+	if lines[0].Trace == "" {
+		t.Error("got no stack trace for an error")
+	}
+
+	if lines[1].Trace != "" {
+		t.Error("got a stack trace for an error with FatalStacksOnly")
+	}
+}
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{MinLevel: LevelDebug, Sampling: &Sampling{Tick: time.Hour, First: 2, Thereafter: 3}})
+
+	for i := 0; i < 10; i++ {
+		l.PrintInfo("repeated", nil)
+	}
+
+	l.PrintWarn("repeated", nil)
+	l.PrintInfo("other", nil)
+
+	counts := make(map[string]int)
+	for _, line := range readLines(t, &buf) {
+		counts[line.Level+" "+line.Message]++
+	}
+
+	// The 1st, 2nd, 5th and 8th repeats are written.
+	if counts["INFO repeated"] != 4 || counts["WARN repeated"] != 1 || counts["INFO other"] != 1 {
+		t.Errorf("got %v; want 4 sampled repeats and every distinct message", counts)
+	}
+}
+
+func TestSamplingWindow(t *testing.T) {
+	s := &sampler{Sampling: Sampling{Tick: time.Second, First: 1}}
+	now := time.Now()
+
+	if !s.allow(LevelInfo, "repeated", now) {
+		t.Error("got the first message dropped")
+	}
+
+	if s.allow(LevelInfo, "repeated", now) {
+		t.Error("got a repeat written with a Thereafter of zero")
+	}
+
+	if !s.allow(LevelInfo, "repeated", now.Add(time.Second)) {
+		t.Error("got the first message of a new tick dropped")
+	}
+}
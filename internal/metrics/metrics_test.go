@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	requests := r.NewCounterVec("requests_total", "Requests served.", "route", "status")
+	panics := r.NewCounterVec("panics_total", "Panics recovered.")
+	latency := r.NewHistogramVec("latency_seconds", "Request latency.", []float64{1, 0.1}, "route")
+	r.NewGaugeFunc("connections", "Open connections.", func() float64 { return 3 })
+
+	requests.Inc("/v1/bouts/:id", "200")
+	requests.Inc("/v1/bouts/:id", "200")
+	requests.Add(2, `say "hi"`, "404")
+
+	latency.Observe(0.05, "/v1/bouts")
+	latency.Observe(0.5, "/v1/bouts")
+	latency.Observe(5, "/v1/bouts")
+
+	var b strings.Builder
+
+	err := r.Write(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{route="/v1/bouts/:id",status="200"} 2
+requests_total{route="say \"hi\"",status="404"} 2
+# HELP panics_total Panics recovered.
+# TYPE panics_total counter
+panics_total 0
+# HELP latency_seconds Request latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/v1/bouts",le="0.1"} 1
+latency_seconds_bucket{route="/v1/bouts",le="1"} 2
+latency_seconds_bucket{route="/v1/bouts",le="+Inf"} 3
+latency_seconds_sum{route="/v1/bouts"} 5.55
+latency_seconds_count{route="/v1/bouts"} 3
+# HELP connections Open connections.
+# TYPE connections gauge
+connections 3
+`
+
+	if got := b.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	panics.Inc()
+
+	b.Reset()
+	r.Write(&b)
+
+	if !strings.Contains(b.String(), "\npanics_total 1\n") {
+		t.Errorf("got %q; want panics_total 1", b.String())
+	}
+}
+
+func TestLabelCountMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic for a missing label value")
+		}
+	}()
+
+	NewRegistry().NewCounterVec("requests_total", "Requests served.", "route").Inc()
+}
@@ -0,0 +1,341 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const lockID = 7_231_946_105
+
+var (
+	ErrDirty          = errors.New("database is in a dirty state, fix the schema manually and run force")
+	ErrNoChange       = errors.New("no change")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+var filenameRX = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+func New(db *sql.DB, driver string, fsys fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		matches := filenameRX.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%s: invalid migration version", entry.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("%s: version %d is used by more than one migration", entry.Name(), version)
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrator := &Migrator{db: db, driver: driver}
+
+	for _, m := range byVersion {
+		migrator.migrations = append(migrator.migrations, *m)
+	}
+
+	sort.Slice(migrator.migrations, func(i, j int) bool {
+		return migrator.migrations[i].Version < migrator.migrations[j].Version
+	})
+
+	return migrator, nil
+}
+
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) Version(ctx context.Context) (int, bool, error) {
+	var version int
+	var dirty bool
+
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, dirty, err = m.version(ctx, conn)
+		return err
+	})
+
+	return version, dirty, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, int, bool, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= version,
+		}
+	}
+
+	return statuses, version, dirty, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Goto(ctx, m.Latest())
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(version int) (int, error) {
+		i := m.index(version)
+		if i < 0 {
+			return 0, ErrNoChange
+		}
+
+		target := i - steps
+		if target < -1 {
+			target = -1
+		}
+
+		if target == -1 {
+			return 0, nil
+		}
+
+		return m.migrations[target].Version, nil
+	})
+}
+
+func (m *Migrator) Goto(ctx context.Context, target int) error {
+	if target != 0 && m.index(target) < 0 {
+		return ErrUnknownVersion
+	}
+
+	return m.withLock(ctx, func(version int) (int, error) {
+		return target, nil
+	})
+}
+
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != 0 && m.index(version) < 0 {
+		return ErrUnknownVersion
+	}
+
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		_, _, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		return m.setVersion(ctx, conn, version, false)
+	})
+}
+
+func (m *Migrator) withLock(ctx context.Context, target func(int) (int, error)) error {
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		if m.driver == "postgres" {
+			_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID)
+			if err != nil {
+				return err
+			}
+			defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+		}
+
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return ErrDirty
+		}
+
+		to, err := target(version)
+		if err != nil {
+			return err
+		}
+
+		if to == version {
+			return ErrNoChange
+		}
+
+		return m.migrate(ctx, conn, version, to)
+	})
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, from, to int) error {
+	if to > from {
+		for _, migration := range m.migrations {
+			if migration.Version <= from || migration.Version > to {
+				continue
+			}
+
+			err := m.apply(ctx, conn, migration.Version, migration.Up, migration.Version)
+			if err != nil {
+				return fmt.Errorf("applying %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > from || migration.Version <= to {
+			continue
+		}
+
+		previous := 0
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+
+		err := m.apply(ctx, conn, migration.Version, migration.Down, previous)
+		if err != nil {
+			return fmt.Errorf("reverting %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, version int, query string, result int) error {
+	err := m.setVersion(ctx, conn, version, true)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if query != "" {
+		_, err = tx.ExecContext(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, conn, result, false)
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (int, bool, error) {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint NOT NULL PRIMARY KEY,
+			dirty boolean NOT NULL
+		)`)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var version int
+	var dirty bool
+
+	err = conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *sql.Conn, version int, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 || dirty {
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) withConn(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(conn)
+}
+
+func (m *Migrator) index(version int) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
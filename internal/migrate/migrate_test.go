@@ -0,0 +1,235 @@
+package migrate_test
+
+import (
+	"database/sql"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/corsairconstantine/sumodb/internal/migrate"
+	"github.com/corsairconstantine/sumodb/migrations"
+	_ "modernc.org/sqlite"
+)
+
+// openMemoryDB opens an in-memory SQLite database. It is limited to one
+// connection, as each connection to :memory: is a separate database.
+func openMemoryDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.SetMaxOpenConns(1)
+
+	return db
+}
+
+func newMigrator(t *testing.T, db *sql.DB, fsys fs.FS) *migrate.Migrator {
+	t.Helper()
+
+	m, err := migrate.New(db, "sqlite", fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// assertVersion checks the row in schema_migrations, which is absent at
+// version 0.
+func assertVersion(t *testing.T, db *sql.DB, want int, wantDirty bool) {
+	t.Helper()
+
+	var version int
+	var dirty bool
+
+	err := db.QueryRow(`SELECT version, dirty FROM schema_migrations`).Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		t.Fatal(err)
+	}
+
+	if version != want || dirty != wantDirty {
+		t.Errorf("got version %d, dirty %t; want version %d, dirty %t", version, dirty, want, wantDirty)
+	}
+}
+
+func assertTable(t *testing.T, db *sql.DB, table string, want bool) {
+	t.Helper()
+
+	var n int
+
+	err := db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = $1`, table).Scan(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if (n == 1) != want {
+		t.Errorf("got table %s existing %t; want %t", table, n == 1, want)
+	}
+}
+
+func assertErr(t *testing.T, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v; want %v", err, want)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := t.Context()
+	db := openMemoryDB(t)
+	m := newMigrator(t, db, migrations.SQLite)
+
+	latest := m.Latest()
+	if latest < 3 {
+		t.Fatalf("got latest version %d; want the sqlite migrations", latest)
+	}
+
+	t.Run("Up", func(t *testing.T) {
+		assertErr(t, m.Up(ctx), nil)
+		assertVersion(t, db, latest, false)
+		assertTable(t, db, "bouts", true)
+		assertTable(t, db, "webhooks", true)
+
+		version, dirty, err := m.Version(ctx)
+		if err != nil || version != latest || dirty {
+			t.Errorf("got version %d, dirty %t, %v; want %d", version, dirty, err, latest)
+		}
+	})
+
+	t.Run("Up to date", func(t *testing.T) {
+		assertErr(t, m.Up(ctx), migrate.ErrNoChange)
+		assertVersion(t, db, latest, false)
+	})
+
+	t.Run("Down", func(t *testing.T) {
+		assertErr(t, m.Down(ctx, 1), nil)
+		assertVersion(t, db, latest-1, false)
+
+		statuses, version, dirty, err := m.Status(ctx)
+		if err != nil || version != latest-1 || dirty {
+			t.Fatalf("got version %d, dirty %t, %v", version, dirty, err)
+		}
+
+		if last := statuses[len(statuses)-1]; last.Version != latest || last.Applied {
+			t.Errorf("got %+v; want the last migration pending", last)
+		}
+
+		if statuses[0].Version != 1 || !statuses[0].Applied {
+			t.Errorf("got %+v; want the first migration applied", statuses[0])
+		}
+	})
+
+	t.Run("Goto", func(t *testing.T) {
+		assertErr(t, m.Goto(ctx, 2), nil)
+		assertVersion(t, db, 2, false)
+		assertTable(t, db, "webhooks", false)
+
+		assertErr(t, m.Goto(ctx, latest), nil)
+		assertVersion(t, db, latest, false)
+		assertTable(t, db, "webhooks", true)
+	})
+
+	t.Run("Goto current version", func(t *testing.T) {
+		assertErr(t, m.Goto(ctx, latest), migrate.ErrNoChange)
+		assertVersion(t, db, latest, false)
+	})
+
+	t.Run("Goto unknown version", func(t *testing.T) {
+		assertErr(t, m.Goto(ctx, latest+100), migrate.ErrUnknownVersion)
+		assertVersion(t, db, latest, false)
+	})
+
+	t.Run("Down past the first migration", func(t *testing.T) {
+		assertErr(t, m.Down(ctx, latest+100), nil)
+		assertVersion(t, db, 0, false)
+		assertTable(t, db, "bouts", false)
+		assertTable(t, db, "rikishis", false)
+
+		assertErr(t, m.Down(ctx, 1), migrate.ErrNoChange)
+		assertVersion(t, db, 0, false)
+	})
+}
+
+func TestMigratorDirty(t *testing.T) {
+	ctx := t.Context()
+	db := openMemoryDB(t)
+
+	m := newMigrator(t, db, fstest.MapFS{
+		"000001_create_rikishis.up.sql":   {Data: []byte(`CREATE TABLE rikishis (shikona text PRIMARY KEY)`)},
+		"000001_create_rikishis.down.sql": {Data: []byte(`DROP TABLE rikishis`)},
+		"000002_broken.up.sql":            {Data: []byte(`CREATE TABLE bouts (id integer PRIMARY KEY`)},
+		"000002_broken.down.sql":          {Data: []byte(`DROP TABLE IF EXISTS bouts`)},
+	})
+
+	err := m.Up(ctx)
+	if err == nil {
+		t.Fatal("got no error applying a broken migration")
+	}
+
+	assertVersion(t, db, 2, true)
+	assertTable(t, db, "rikishis", true)
+	assertTable(t, db, "bouts", false)
+
+	_, dirty, err := m.Version(ctx)
+	if err != nil || !dirty {
+		t.Errorf("got dirty %t, %v; want the version reported dirty", dirty, err)
+	}
+
+	assertErr(t, m.Up(ctx), migrate.ErrDirty)
+	assertErr(t, m.Down(ctx, 1), migrate.ErrDirty)
+	assertErr(t, m.Goto(ctx, 0), migrate.ErrDirty)
+	assertVersion(t, db, 2, true)
+
+	assertErr(t, m.Force(ctx, 3), migrate.ErrUnknownVersion)
+	assertVersion(t, db, 2, true)
+
+	assertErr(t, m.Force(ctx, 1), nil)
+	assertVersion(t, db, 1, false)
+
+	assertErr(t, m.Down(ctx, 1), nil)
+	assertVersion(t, db, 0, false)
+	assertTable(t, db, "rikishis", false)
+
+	assertErr(t, m.Force(ctx, 2), nil)
+	assertVersion(t, db, 2, false)
+
+	assertErr(t, m.Force(ctx, 0), nil)
+	assertVersion(t, db, 0, false)
+}
+
+func TestNew(t *testing.T) {
+	db := openMemoryDB(t)
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"Zero version", fstest.MapFS{"000000_init.up.sql": {}}},
+		{"Reused version", fstest.MapFS{"000001_a.up.sql": {}, "000001_b.up.sql": {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migrate.New(db, "sqlite", tt.fsys)
+			if err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+
+	m := newMigrator(t, db, fstest.MapFS{
+		"000010_b.up.sql": {},
+		"000002_a.up.sql": {},
+		"README.md":       {},
+	})
+
+	if m.Latest() != 10 {
+		t.Errorf("got latest %d; want 10", m.Latest())
+	}
+}
@@ -0,0 +1,548 @@
+package sumohtml
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/corsairconstantine/sumodb/internal/data"
+	"golang.org/x/net/html"
+)
+
+var (
+	ErrUnknownPage       = errors.New("unrecognised page: no banzuke, results or torikumi table found")
+	ErrMissingTournament = errors.New("unable to determine the tournament from the page title")
+)
+
+type PageKind int
+
+const (
+	KindBanzuke PageKind = iota
+	KindResults
+	KindTorikumi
+)
+
+func (k PageKind) String() string {
+	switch k {
+	case KindBanzuke:
+		return "banzuke"
+	case KindResults:
+		return "results"
+	case KindTorikumi:
+		return "torikumi"
+	default:
+		return ""
+	}
+}
+
+var bashoMonths = map[string]string{
+	"hatsu":  "Jan",
+	"haru":   "Mar",
+	"natsu":  "May",
+	"nagoya": "Jul",
+	"aki":    "Sep",
+	"kyushu": "Nov",
+}
+
+var (
+	rxBasho    = regexp.MustCompile(`(?i)\b(Hatsu|Haru|Natsu|Nagoya|Aki|Kyushu)\s+(\d{4})\b`)
+	rxBashoID  = regexp.MustCompile(`\b(\d{4})\.(\d{2})\b`)
+	rxDay      = regexp.MustCompile(`(?i)\bDay\s+(\d{1,2})\b`)
+	rxPlayoff  = regexp.MustCompile(`(?i)\bPlayoff\b`)
+	rxRecord   = regexp.MustCompile(`(\d+)-(\d+)(?:-(\d+))?`)
+	rxRankCode = regexp.MustCompile(`^([A-Za-z]+)(\d*)([ew]?)$`)
+)
+
+var monthNames = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+type Entry struct {
+	Row       int
+	Division  string
+	Rank      string
+	Shikona   string
+	Heya      string
+	Wins      int32
+	Losses    int32
+	Absent    int32
+	HasRecord bool
+}
+
+type Pairing struct {
+	Row      int
+	Division string
+	East     string
+	West     string
+	Winner   string
+	Loser    string
+	Kimarite string
+}
+
+func (p Pairing) Decided() bool {
+	return p.Winner != "" && p.Loser != ""
+}
+
+type Page struct {
+	Kind       PageKind
+	Tournament string
+	Day        string
+	Entries    []Entry
+	Pairings   []Pairing
+}
+
+func Parse(r io.Reader) (*Page, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{}
+
+	heading := text(find(doc, isElement("title")))
+	if h1 := find(doc, isElement("h1")); h1 != nil {
+		heading += " " + text(h1)
+	}
+
+	page.Tournament = parseTournament(heading)
+	if page.Tournament == "" {
+		return nil, ErrMissingTournament
+	}
+
+	switch {
+	case find(doc, isTable("banzuke")) != nil:
+		page.Kind = KindBanzuke
+		for _, table := range findAll(doc, isTable("banzuke")) {
+			page.parseBanzuke(table)
+		}
+	case find(doc, isTable("results")) != nil:
+		page.Kind = KindResults
+		for _, table := range findAll(doc, isTable("results")) {
+			page.parseResults(table)
+		}
+	case find(doc, isTable("torikumi")) != nil:
+		page.Kind = KindTorikumi
+		page.Day = parseDay(heading)
+		if page.Day == "" {
+			return nil, fmt.Errorf("torikumi page: unable to determine the day from %q", strings.TrimSpace(heading))
+		}
+		for _, table := range findAll(doc, isTable("torikumi")) {
+			page.parseTorikumi(table)
+		}
+	default:
+		return nil, ErrUnknownPage
+	}
+
+	return page, nil
+}
+
+func (p *Page) parseBanzuke(table *html.Node) {
+	division := parseDivision(table)
+
+	for i, row := range rows(table) {
+		cells := findAll(row, isElement("td"))
+		if len(cells) != 5 {
+			continue
+		}
+
+		rank := text(cells[2])
+
+		for _, side := range []struct {
+			suffix string
+			name   *html.Node
+			record *html.Node
+		}{
+			{"e", cells[1], cells[0]},
+			{"w", cells[3], cells[4]},
+		} {
+			entry, ok := parseRikishiCell(side.name)
+			if !ok {
+				continue
+			}
+
+			entry.Row = i + 1
+			entry.Division = division
+			entry.Rank = rank + side.suffix
+			entry.Wins, entry.Losses, entry.Absent, entry.HasRecord = parseRecord(text(side.record))
+
+			p.Entries = append(p.Entries, entry)
+		}
+	}
+}
+
+func (p *Page) parseResults(table *html.Node) {
+	division := parseDivision(table)
+
+	for i, row := range rows(table) {
+		cells := findAll(row, isElement("td"))
+		if len(cells) != 3 {
+			continue
+		}
+
+		entry, ok := parseRikishiCell(cells[1])
+		if !ok {
+			continue
+		}
+
+		entry.Row = i + 1
+		entry.Division = division
+		entry.Rank = text(cells[0])
+		entry.Wins, entry.Losses, entry.Absent, entry.HasRecord = parseRecord(text(cells[2]))
+
+		p.Entries = append(p.Entries, entry)
+	}
+}
+
+func (p *Page) parseTorikumi(table *html.Node) {
+	division := parseDivision(table)
+
+	for i, row := range rows(table) {
+		cells := findAll(row, isElement("td"))
+		if len(cells) != 5 {
+			continue
+		}
+
+		east, eastOK := parseRikishiCell(cells[1])
+		west, westOK := parseRikishiCell(cells[3])
+		if !eastOK || !westOK {
+			continue
+		}
+
+		pairing := Pairing{
+			Row:      i + 1,
+			Division: division,
+			East:     east.Shikona,
+			West:     west.Shikona,
+			Kimarite: text(cells[2]),
+		}
+
+		eastResult, eastFusen := parseResultMarker(cells[0])
+		westResult, westFusen := parseResultMarker(cells[4])
+
+		switch {
+		case eastResult == "win" || westResult == "loss":
+			pairing.Winner, pairing.Loser = east.Shikona, west.Shikona
+		case westResult == "win" || eastResult == "loss":
+			pairing.Winner, pairing.Loser = west.Shikona, east.Shikona
+		}
+
+		// Walkovers are stored under the "fusen" kimarite whatever the page
+		// shows in the kimarite column.
+		if eastFusen || westFusen {
+			pairing.Kimarite = "fusen"
+		}
+
+		p.Pairings = append(p.Pairings, pairing)
+	}
+}
+
+func (p *Page) TournamentResults() []*data.TournamentResult {
+	trs := []*data.TournamentResult{}
+
+	for _, entry := range p.Entries {
+		if !entry.HasRecord {
+			continue
+		}
+
+		trs = append(trs, &data.TournamentResult{
+			Tournament: p.Tournament,
+			Rikishi:    entry.Shikona,
+			Rank:       entry.Rank,
+			Wins:       entry.Wins,
+			Losses:     entry.Losses,
+			Absent:     entry.Absent,
+		})
+	}
+
+	return trs
+}
+
+func (p *Page) Bouts() []*data.Bout {
+	bouts := []*data.Bout{}
+
+	for _, pairing := range p.Pairings {
+		if !pairing.Decided() {
+			continue
+		}
+
+		bouts = append(bouts, &data.Bout{
+			Tournament: p.Tournament,
+			Day:        p.Day,
+			Division:   pairing.Division,
+			Winner:     pairing.Winner,
+			Loser:      pairing.Loser,
+			Kimarite:   pairing.Kimarite,
+		})
+	}
+
+	return bouts
+}
+
+func RankTitle(rank string) string {
+	m := rxRankCode.FindStringSubmatch(rank)
+	if m == nil {
+		return ""
+	}
+
+	switch strings.ToLower(m[1]) {
+	case "y":
+		return "Yokozuna"
+	case "o":
+		return "Ozeki"
+	case "s":
+		return "Sekiwake"
+	case "k":
+		return "Komusubi"
+	case "m":
+		return "Maegashira"
+	case "j":
+		return "Juryo"
+	case "ms":
+		return "Makushita"
+	case "sd":
+		return "Sandanme"
+	case "jd":
+		return "Jonidan"
+	case "jk":
+		return "Jonokuchi"
+	case "mz":
+		return "Mae-zumo"
+	default:
+		return ""
+	}
+}
+
+func RankOrder(rank string) int {
+	titles := []string{"Yokozuna", "Ozeki", "Sekiwake", "Komusubi", "Maegashira", "Juryo", "Makushita", "Sandanme", "Jonidan", "Jonokuchi", "Mae-zumo"}
+
+	m := rxRankCode.FindStringSubmatch(rank)
+	if m == nil {
+		return len(titles) * 1000
+	}
+
+	title := RankTitle(rank)
+
+	for i, t := range titles {
+		if t == title {
+			number, _ := strconv.Atoi(m[2])
+			order := i*1000 + number*2
+			if m[3] == "w" {
+				order++
+			}
+			return order
+		}
+	}
+
+	return len(titles) * 1000
+}
+
+func parseTournament(s string) string {
+	if m := rxBasho.FindStringSubmatch(s); m != nil {
+		return m[2] + " " + bashoMonths[strings.ToLower(m[1])]
+	}
+
+	if m := rxBashoID.FindStringSubmatch(s); m != nil {
+		month, err := strconv.Atoi(m[2])
+		if err != nil || month < 1 || month > 12 {
+			return ""
+		}
+		return m[1] + " " + monthNames[month-1]
+	}
+
+	return ""
+}
+
+func parseDay(s string) string {
+	if rxPlayoff.MatchString(s) {
+		return "Playoff"
+	}
+
+	if m := rxDay.FindStringSubmatch(s); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		return strconv.Itoa(day)
+	}
+
+	return ""
+}
+
+func parseDivision(table *html.Node) string {
+	caption := text(find(table, isElement("caption")))
+
+	for _, division := range data.Divisions {
+		if strings.Contains(strings.ToLower(caption), strings.ToLower(division)) {
+			return division
+		}
+	}
+
+	return ""
+}
+
+func parseRecord(s string) (wins, losses, absent int32, ok bool) {
+	m := rxRecord.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+
+	values := make([]int32, 3)
+	for i, group := range m[1:] {
+		if group == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(group)
+		values[i] = int32(n)
+	}
+
+	return values[0], values[1], values[2], true
+}
+
+func parseRikishiCell(cell *html.Node) (Entry, bool) {
+	link := find(cell, isElement("a"))
+	if link == nil {
+		return Entry{}, false
+	}
+
+	entry := Entry{Shikona: text(link)}
+	if entry.Shikona == "" {
+		return Entry{}, false
+	}
+
+	if heya := find(cell, hasClass("heya")); heya != nil {
+		entry.Heya = text(heya)
+	}
+
+	return entry, true
+}
+
+func parseResultMarker(cell *html.Node) (result string, fusen bool) {
+	img := find(cell, isElement("img"))
+	if img == nil {
+		return "", false
+	}
+
+	src := attr(img, "src")
+
+	switch {
+	case strings.Contains(src, "fusensho"):
+		return "win", true
+	case strings.Contains(src, "fusenpai"):
+		return "loss", true
+	case strings.Contains(src, "shiro"):
+		return "win", false
+	case strings.Contains(src, "kuro"):
+		return "loss", false
+	default:
+		return "", false
+	}
+}
+
+func rows(table *html.Node) []*html.Node {
+	var body []*html.Node
+
+	for _, row := range findAll(table, isElement("tr")) {
+		if find(row, isElement("th")) != nil {
+			continue
+		}
+		body = append(body, row)
+	}
+
+	return body
+}
+
+func isElement(tag string) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == tag
+	}
+}
+
+func hasClass(class string) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return false
+		}
+
+		for _, c := range strings.Fields(attr(n, "class")) {
+			if c == class {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func isTable(class string) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		return isElement("table")(n) && hasClass(class)(n)
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n == nil {
+		return nil
+	}
+
+	if match(n) {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := find(c, match); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func findAll(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	var found []*html.Node
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if match(c) {
+				found = append(found, c)
+				continue
+			}
+			walk(c)
+		}
+	}
+
+	walk(n)
+
+	return found
+}
+
+func text(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(n)
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,160 @@
+package sumohtml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func parseFixture(t *testing.T, name string) *Page {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	page, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", name, err)
+	}
+
+	return page
+}
+
+func TestParseBanzuke(t *testing.T) {
+	page := parseFixture(t, "banzuke_202211.html")
+
+	if page.Kind != KindBanzuke {
+		t.Fatalf("got kind %s; want banzuke", page.Kind)
+	}
+
+	if page.Tournament != "2022 Nov" {
+		t.Errorf("got tournament %q; want %q", page.Tournament, "2022 Nov")
+	}
+
+	if len(page.Entries) != 9 {
+		t.Fatalf("got %d entries; want 9", len(page.Entries))
+	}
+
+	tests := []Entry{
+		{Row: 1, Division: "Makuuchi", Rank: "Y1e", Shikona: "Terunofuji", Heya: "Isegahama", Absent: 15, HasRecord: true},
+		{Row: 2, Division: "Makuuchi", Rank: "O1w", Shikona: "Takakeisho", Heya: "Tokiwayama", Wins: 12, Losses: 3, HasRecord: true},
+		{Row: 4, Division: "Makuuchi", Rank: "M10w", Shikona: "Abi", Heya: "Shikoroyama", Wins: 12, Losses: 3, HasRecord: true},
+		{Row: 1, Division: "Juryo", Rank: "J1w", Shikona: "Ryuden", Heya: "Takadagawa"},
+	}
+
+	for _, want := range tests {
+		found := false
+
+		for _, got := range page.Entries {
+			if got.Shikona == want.Shikona {
+				found = true
+				if got != want {
+					t.Errorf("got entry %+v; want %+v", got, want)
+				}
+			}
+		}
+
+		if !found {
+			t.Errorf("entry for %s not found", want.Shikona)
+		}
+	}
+
+	if trs := page.TournamentResults(); len(trs) != 8 {
+		t.Errorf("got %d tournament results; want 8", len(trs))
+	}
+}
+
+func TestParseResults(t *testing.T) {
+	page := parseFixture(t, "results_202211.html")
+
+	if page.Kind != KindResults {
+		t.Fatalf("got kind %s; want results", page.Kind)
+	}
+
+	if page.Tournament != "2022 Nov" {
+		t.Errorf("got tournament %q; want %q", page.Tournament, "2022 Nov")
+	}
+
+	trs := page.TournamentResults()
+	if len(trs) != 3 {
+		t.Fatalf("got %d tournament results; want 3", len(trs))
+	}
+
+	abi := trs[1]
+	if abi.Rikishi != "Abi" || abi.Rank != "M9w" || abi.Wins != 12 || abi.Losses != 3 || abi.Absent != 0 {
+		t.Errorf("got %+v; want Abi M9w 12-3", *abi)
+	}
+}
+
+func TestParseTorikumi(t *testing.T) {
+	page := parseFixture(t, "torikumi_202211_15.html")
+
+	if page.Kind != KindTorikumi {
+		t.Fatalf("got kind %s; want torikumi", page.Kind)
+	}
+
+	if page.Day != "15" {
+		t.Errorf("got day %q; want %q", page.Day, "15")
+	}
+
+	if len(page.Pairings) != 4 {
+		t.Fatalf("got %d pairings; want 4", len(page.Pairings))
+	}
+
+	bouts := page.Bouts()
+	if len(bouts) != 3 {
+		t.Fatalf("got %d bouts; want 3", len(bouts))
+	}
+
+	tests := []struct {
+		winner, loser, kimarite string
+	}{
+		{"Takakeisho", "Abi", "tsukiotoshi"},
+		{"Takayasu", "Shodai", "yorikiri"},
+		{"Wakatakakage", "Hoshoryu", "fusen"},
+	}
+
+	for i, tt := range tests {
+		b := bouts[i]
+		if b.Winner != tt.winner || b.Loser != tt.loser || b.Kimarite != tt.kimarite {
+			t.Errorf("bout %d: got %s def. %s by %s; want %s def. %s by %s", i, b.Winner, b.Loser, b.Kimarite, tt.winner, tt.loser, tt.kimarite)
+		}
+
+		if b.Tournament != "2022 Nov" || b.Day != "15" || b.Division != "Makuuchi" {
+			t.Errorf("bout %d: got %s day %s %s; want 2022 Nov day 15 Makuuchi", i, b.Tournament, b.Day, b.Division)
+		}
+	}
+}
+
+func TestParseTournament(t *testing.T) {
+	tests := map[string]string{
+		"Hatsu 2023 Banzuke":       "2023 Jan",
+		"SumoDB - Results 1989.07": "1989 Jul",
+		"aki 2001":                 "2001 Sep",
+		"Results 2022.13":          "",
+		"Banzuke":                  "",
+	}
+
+	for input, want := range tests {
+		if got := parseTournament(input); got != want {
+			t.Errorf("parseTournament(%q) = %q; want %q", input, got, want)
+		}
+	}
+}
+
+func TestRankOrder(t *testing.T) {
+	ranks := []string{"Y1e", "Y1w", "O2e", "S1w", "K1e", "M1e", "M16w", "J1e", "Ms5e", "Sd90w", "Jd1e", "Jk30w"}
+
+	for i := 1; i < len(ranks); i++ {
+		if RankOrder(ranks[i-1]) >= RankOrder(ranks[i]) {
+			t.Errorf("RankOrder(%s) should be lower than RankOrder(%s)", ranks[i-1], ranks[i])
+		}
+	}
+
+	if got := RankTitle("Ms5e"); got != "Makushita" {
+		t.Errorf("RankTitle(Ms5e) = %q; want Makushita", got)
+	}
+}
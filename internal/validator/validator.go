@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 type Validator struct {
@@ -96,3 +97,13 @@ func ValidEmail(email string) bool {
 	_, err := mail.ParseAddress(email)
 	return err == nil
 }
+
+func Hiragana(value string) bool {
+	for _, r := range value {
+		if !unicode.In(r, unicode.Hiragana) && r != 'ー' && r != '・' && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
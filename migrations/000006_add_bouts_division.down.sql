@@ -0,0 +1 @@
+ALTER TABLE bouts DROP COLUMN IF EXISTS division;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE bouts ADD COLUMN IF NOT EXISTS division text NOT NULL DEFAULT '';
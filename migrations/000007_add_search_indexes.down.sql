@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS bouts_kimarite_trgm_idx;
+DROP INDEX IF EXISTS rikishis_heya_trgm_idx;
+DROP INDEX IF EXISTS rikishis_shikona_trgm_idx;
+
+DROP FUNCTION IF EXISTS f_unaccent(text);
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS
+$$ SELECT public.unaccent('public.unaccent', $1) $$
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+CREATE INDEX IF NOT EXISTS rikishis_shikona_trgm_idx ON rikishis USING GIN (f_unaccent(shikona) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rikishis_heya_trgm_idx ON rikishis USING GIN (f_unaccent(heya) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS bouts_kimarite_trgm_idx ON bouts USING GIN (f_unaccent(kimarite) gin_trgm_ops);
@@ -0,0 +1,4 @@
+ALTER TABLE rikishis DROP COLUMN IF EXISTS heya_kana;
+ALTER TABLE rikishis DROP COLUMN IF EXISTS heya_kanji;
+ALTER TABLE rikishis DROP COLUMN IF EXISTS shikona_kana;
+ALTER TABLE rikishis DROP COLUMN IF EXISTS shikona_kanji;
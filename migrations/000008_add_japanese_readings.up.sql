@@ -0,0 +1,4 @@
+ALTER TABLE rikishis ADD COLUMN IF NOT EXISTS shikona_kanji text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN IF NOT EXISTS shikona_kana text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN IF NOT EXISTS heya_kanji text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN IF NOT EXISTS heya_kana text NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Walkovers imported as 'fusensho' cannot be told apart from ones stored as
+-- 'fusen', so there is nothing to undo.
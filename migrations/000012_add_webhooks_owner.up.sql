@@ -0,0 +1,5 @@
+-- Webhooks created before owners were recorded belong to no API key, so they
+-- are kept for their pending deliveries but cannot be read or changed.
+ALTER TABLE webhooks ADD COLUMN owner text NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS webhooks_owner_idx ON webhooks (owner);
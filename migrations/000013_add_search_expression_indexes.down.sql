@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS bouts_kimarite_lower_idx;
+DROP INDEX IF EXISTS rikishis_heya_kana_trgm_idx;
+DROP INDEX IF EXISTS rikishis_heya_kanji_trgm_idx;
+DROP INDEX IF EXISTS rikishis_shikona_kana_trgm_idx;
+DROP INDEX IF EXISTS rikishis_shikona_kanji_trgm_idx;
+DROP INDEX IF EXISTS rikishis_shikona_history_trgm_idx;
+
+DROP FUNCTION IF EXISTS f_array_to_string(text[]);
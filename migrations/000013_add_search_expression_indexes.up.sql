@@ -0,0 +1,11 @@
+-- array_to_string is only stable, so it is wrapped to be usable in an index.
+CREATE OR REPLACE FUNCTION f_array_to_string(text[]) RETURNS text AS
+$$ SELECT array_to_string($1, ' ') $$
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+CREATE INDEX IF NOT EXISTS rikishis_shikona_history_trgm_idx ON rikishis USING GIN (f_unaccent(f_array_to_string(shikona_history)) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rikishis_shikona_kanji_trgm_idx ON rikishis USING GIN (f_unaccent(shikona_kanji) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rikishis_shikona_kana_trgm_idx ON rikishis USING GIN (f_unaccent(shikona_kana) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rikishis_heya_kanji_trgm_idx ON rikishis USING GIN (f_unaccent(heya_kanji) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS rikishis_heya_kana_trgm_idx ON rikishis USING GIN (f_unaccent(heya_kana) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS bouts_kimarite_lower_idx ON bouts (lower(f_unaccent(kimarite)));
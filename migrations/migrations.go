@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+var SQLite, _ = fs.Sub(sqliteFS, "sqlite")
+
+func For(driver string) fs.FS {
+	if driver == "sqlite" {
+		return SQLite
+	}
+
+	return FS
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS bouts;
+DROP TABLE IF EXISTS tournaments_results;
+DROP TRIGGER IF EXISTS rikishis_search_delete;
+DROP TRIGGER IF EXISTS rikishis_search_update;
+DROP TRIGGER IF EXISTS rikishis_search_insert;
+DROP TABLE IF EXISTS rikishis_search;
+DROP TABLE IF EXISTS rikishis;
@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS rikishis (
+    shikona text PRIMARY KEY NOT NULL,
+    highest_rank text NOT NULL,
+    heya text NOT NULL,
+    shikona_history text NOT NULL CHECK (json_array_length(shikona_history) BETWEEN 1 AND 10),
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS rikishis_search USING fts5(
+    shikona UNINDEXED,
+    shikona_history,
+    tokenize = "unicode61 remove_diacritics 0"
+);
+
+CREATE TRIGGER IF NOT EXISTS rikishis_search_insert AFTER INSERT ON rikishis BEGIN
+    INSERT INTO rikishis_search (shikona, shikona_history)
+    VALUES (new.shikona, (SELECT group_concat(value, ' ') FROM json_each(new.shikona_history)));
+END;
+
+CREATE TRIGGER IF NOT EXISTS rikishis_search_update AFTER UPDATE ON rikishis BEGIN
+    DELETE FROM rikishis_search WHERE shikona = old.shikona;
+    INSERT INTO rikishis_search (shikona, shikona_history)
+    VALUES (new.shikona, (SELECT group_concat(value, ' ') FROM json_each(new.shikona_history)));
+END;
+
+CREATE TRIGGER IF NOT EXISTS rikishis_search_delete AFTER DELETE ON rikishis BEGIN
+    DELETE FROM rikishis_search WHERE shikona = old.shikona;
+END;
+
+CREATE TABLE IF NOT EXISTS tournaments_results (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    tournament text NOT NULL,
+    rikishi text REFERENCES rikishis(shikona),
+    rank text NOT NULL,
+    wins integer NOT NULL,
+    losses integer NOT NULL,
+    absent integer NOT NULL,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS bouts (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    tournament text NOT NULL,
+    day text NOT NULL,
+    division text NOT NULL DEFAULT '',
+    winner text REFERENCES rikishis(shikona),
+    loser text REFERENCES rikishis(shikona),
+    kimarite text,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    name text NOT NULL,
+    email text UNIQUE NOT NULL COLLATE NOCASE,
+    password_hash blob NOT NULL,
+    activated boolean NOT NULL,
+    version integer NOT NULL DEFAULT 1
+);
@@ -0,0 +1,4 @@
+ALTER TABLE rikishis DROP COLUMN heya_kana;
+ALTER TABLE rikishis DROP COLUMN heya_kanji;
+ALTER TABLE rikishis DROP COLUMN shikona_kana;
+ALTER TABLE rikishis DROP COLUMN shikona_kanji;
@@ -0,0 +1,4 @@
+ALTER TABLE rikishis ADD COLUMN shikona_kanji text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN shikona_kana text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN heya_kanji text NOT NULL DEFAULT '';
+ALTER TABLE rikishis ADD COLUMN heya_kana text NOT NULL DEFAULT '';
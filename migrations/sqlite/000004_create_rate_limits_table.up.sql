@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key text PRIMARY KEY,
+    tat integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limits_tat_idx ON rate_limits (tat);
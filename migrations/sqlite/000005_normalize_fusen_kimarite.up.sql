@@ -0,0 +1,3 @@
+UPDATE bouts
+SET kimarite = 'fusen', version = version + 1
+WHERE kimarite = 'fusensho';
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Bout struct {
+	ID            int64  `json:"id,omitempty"`
+	Tournament    string `json:"tournament"`
+	Day           string `json:"day"`
+	Division      string `json:"division"`
+	Winner        string `json:"winner"`
+	Loser         string `json:"loser"`
+	Kimarite      string `json:"kimarite"`
+	KimariteKanji string `json:"kimarite_kanji,omitempty"`
+	KimariteKana  string `json:"kimarite_kana,omitempty"`
+	Version       int32  `json:"version,omitempty"`
+}
+
+type BoutUpdate struct {
+	Tournament *string `json:"tournament,omitempty"`
+	Day        *string `json:"day,omitempty"`
+	Division   *string `json:"division,omitempty"`
+	Winner     *string `json:"winner,omitempty"`
+	Loser      *string `json:"loser,omitempty"`
+	Kimarite   *string `json:"kimarite,omitempty"`
+}
+
+type BoutFilter struct {
+	Tournament string
+	Day        string
+	Division   string
+	Kimarite   string
+	Rikishi1   string
+	Rikishi2   string
+	Winner     string
+	Loser      string
+	From       string
+	To         string
+	ListOptions
+}
+
+func (f BoutFilter) values() url.Values {
+	qs := f.ListOptions.values()
+	setString(qs, "tournament", f.Tournament)
+	setString(qs, "day", f.Day)
+	setString(qs, "division", f.Division)
+	setString(qs, "kimarite", f.Kimarite)
+	setString(qs, "rikishi1", f.Rikishi1)
+	setString(qs, "rikishi2", f.Rikishi2)
+	setString(qs, "winner", f.Winner)
+	setString(qs, "loser", f.Loser)
+	setString(qs, "from", f.From)
+	setString(qs, "to", f.To)
+	return qs
+}
+
+func boutPath(id int64) string {
+	return "/v1/bouts/" + strconv.FormatInt(id, 10)
+}
+
+func boutBody(bout *Bout) interface{} {
+	return struct {
+		Tournament string `json:"tournament"`
+		Day        string `json:"day"`
+		Division   string `json:"division"`
+		Winner     string `json:"winner"`
+		Loser      string `json:"loser"`
+		Kimarite   string `json:"kimarite"`
+	}{bout.Tournament, bout.Day, bout.Division, bout.Winner, bout.Loser, bout.Kimarite}
+}
+
+func (c *Client) CreateBout(ctx context.Context, bout *Bout) (*Bout, error) {
+	var res struct {
+		Bout *Bout `json:"bout"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/bouts", body: boutBody(bout)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Bout, nil
+}
+
+func (c *Client) CreateBouts(ctx context.Context, bouts []*Bout) ([]*Bout, error) {
+	var res struct {
+		Bouts []*Bout `json:"bouts"`
+	}
+
+	body := make([]interface{}, 0, len(bouts))
+	for _, bout := range bouts {
+		body = append(body, boutBody(bout))
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/bouts/batch", body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Bouts, nil
+}
+
+func (c *Client) GetBout(ctx context.Context, id int64) (*Bout, error) {
+	var res struct {
+		Bout *Bout `json:"bout"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: boutPath(id)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Bout, nil
+}
+
+func (c *Client) ListBouts(ctx context.Context, filter BoutFilter) ([]*Bout, Metadata, error) {
+	var res struct {
+		Bouts    []*Bout  `json:"bouts"`
+		Metadata Metadata `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/bouts", query: filter.values()}, &res)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return res.Bouts, res.Metadata, nil
+}
+
+func (c *Client) AllBouts(ctx context.Context, filter BoutFilter) iter.Seq2[*Bout, error] {
+	return paginate(ctx, filter.ListOptions, func(ctx context.Context, opts ListOptions) ([]*Bout, Metadata, error) {
+		filter.ListOptions = opts
+		return c.ListBouts(ctx, filter)
+	})
+}
+
+func (c *Client) UpdateBout(ctx context.Context, id int64, version int32, update BoutUpdate) (*Bout, error) {
+	var res struct {
+		Bout *Bout `json:"bout"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: boutPath(id), body: update, version: version}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Bout, nil
+}
+
+func (c *Client) DeleteBout(ctx context.Context, id int64, version int32) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: boutPath(id), version: version}, nil)
+}
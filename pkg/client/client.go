@@ -0,0 +1,240 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	baseURL    string
+	apiKey     string
+	language   string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
+}
+
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type Metadata struct {
+	CurrentPage  int    `json:"current_page,omitempty"`
+	PageSize     int    `json:"page_size,omitempty"`
+	FirstPage    int    `json:"first_page,omitempty"`
+	LastPage     int    `json:"last_page,omitempty"`
+	TotalRecords int    `json:"total_records,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
+}
+
+type ListOptions struct {
+	Page      int
+	PageSize  int
+	Sort      string
+	Cursor    string
+	Fields    []string
+	Include   []string
+	SkipTotal bool
+}
+
+func (o ListOptions) values() url.Values {
+	qs := url.Values{}
+
+	if o.Page > 0 {
+		qs.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		qs.Set("page_size", strconv.Itoa(o.PageSize))
+	}
+	if o.Sort != "" {
+		qs.Set("sort", o.Sort)
+	}
+	if o.Cursor != "" {
+		qs.Set("cursor", o.Cursor)
+	}
+	if len(o.Fields) > 0 {
+		qs.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if len(o.Include) > 0 {
+		qs.Set("include", strings.Join(o.Include, ","))
+	}
+	if o.SkipTotal {
+		qs.Set("total", "false")
+	}
+
+	return qs
+}
+
+func setString(qs url.Values, key, value string) {
+	if value != "" {
+		qs.Set(key, value)
+	}
+}
+
+func etag(version int32) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	body    interface{}
+	version int32
+}
+
+func (c *Client) do(ctx context.Context, req request, dst interface{}) error {
+	var body []byte
+
+	if req.body != nil {
+		var err error
+
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, target, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+
+		httpReq.Header.Set("Accept", "application/json")
+		if body != nil {
+			httpReq.Header.Set("Content-Type", "application/json")
+		}
+		if c.apiKey != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		if c.language != "" {
+			httpReq.Header.Set("Accept-Language", c.language)
+		}
+		if req.version > 0 {
+			httpReq.Header.Set("If-Match", etag(req.version))
+		}
+
+		res, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode == http.StatusTooManyRequests && attempt < c.maxRetries {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+
+			err = c.wait(ctx, attempt, res.Header.Get("Retry-After"))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		return decodeResponse(res, dst)
+	}
+}
+
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	delay := c.backoff << attempt
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decodeResponse(res *http.Response, dst interface{}) error {
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newError(res)
+	}
+
+	if dst == nil {
+		_, err := io.Copy(io.Discard, res.Body)
+		return err
+	}
+
+	return json.NewDecoder(res.Body).Decode(dst)
+}
+
+type Health struct {
+	Status     string `json:"status"`
+	SystemInfo struct {
+		Environment string `json:"environment"`
+		Version     string `json:"version"`
+	} `json:"system_info"`
+}
+
+func (c *Client) Healthcheck(ctx context.Context) (*Health, error) {
+	var health Health
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/healthcheck"}, &health)
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/corsairconstantine/sumodb/pkg/client"
+)
+
+func newFakeServer(t *testing.T, handler http.HandlerFunc) *client.Client {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	return client.New(ts.URL, client.WithAPIKey("secret"), client.WithRetries(2, time.Millisecond))
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("got Authorization %q", r.Header.Get("Authorization"))
+		}
+
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": "rate limit exceeded"}`))
+			return
+		}
+
+		w.Write([]byte(`{"rikishi": {"shikona": "Abi", "version": 1}}`))
+	})
+
+	rikishi, err := c.GetRikishi(context.Background(), "Abi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rikishi.Shikona != "Abi" || calls.Load() != 3 {
+		t.Errorf("got %+v after %d calls", rikishi, calls.Load())
+	}
+}
+
+func TestGivesUpAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+
+	c := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": "rate limit exceeded"}`))
+	})
+
+	_, err := c.GetRikishi(context.Background(), "Abi")
+	if !errors.Is(err, client.ErrRateLimited) {
+		t.Errorf("got %v; want ErrRateLimited", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("got %d calls; want 3", calls.Load())
+	}
+}
+
+func TestErrorConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{"Not found", http.StatusNotFound, `{"error": "the requested resourse cannot be found"}`, func(t *testing.T, err error) {
+			if !errors.Is(err, client.ErrNotFound) {
+				t.Errorf("got %v; want ErrNotFound", err)
+			}
+		}},
+		{"Edit conflict", http.StatusConflict, `{"error": "edit conflict"}`, func(t *testing.T, err error) {
+			if !errors.Is(err, client.ErrEditConflict) {
+				t.Errorf("got %v; want ErrEditConflict", err)
+			}
+		}},
+		{"Precondition failed", http.StatusPreconditionFailed, `{"error": "modified"}`, func(t *testing.T, err error) {
+			if !errors.Is(err, client.ErrEditConflict) {
+				t.Errorf("got %v; want ErrEditConflict", err)
+			}
+		}},
+		{"Validation", http.StatusUnprocessableEntity, `{"error": {"heya": "must be provided"}}`, func(t *testing.T, err error) {
+			var verr *client.ValidationError
+			if !errors.As(err, &verr) || verr.Fields["heya"] != "must be provided" {
+				t.Errorf("got %v; want a ValidationError for heya", err)
+			}
+		}},
+		{"Batch validation", http.StatusUnprocessableEntity, `{"error": {"1": {"winner": "must be provided"}}}`, func(t *testing.T, err error) {
+			var berr *client.BatchValidationError
+			if !errors.As(err, &berr) || berr.Items[1]["winner"] != "must be provided" {
+				t.Errorf("got %v; want a BatchValidationError for item 1", err)
+			}
+		}},
+		{"Server error", http.StatusInternalServerError, `{"error": "boom", "request_id": "abc123"}`, func(t *testing.T, err error) {
+			var aerr *client.APIError
+			if !errors.As(err, &aerr) || aerr.StatusCode != http.StatusInternalServerError || aerr.Message != "boom" || aerr.RequestID != "abc123" {
+				t.Errorf("got %v; want an APIError", err)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := c.GetBout(context.Background(), 1)
+			tt.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNotFound             = errors.New("client: record not found")
+	ErrEditConflict         = errors.New("client: edit conflict")
+	ErrPreconditionRequired = errors.New("client: If-Match header required")
+	ErrRateLimited          = errors.New("client: rate limit exceeded")
+	ErrUnauthorized         = errors.New("client: invalid or missing API key")
+)
+
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return ErrEditConflict
+	case http.StatusPreconditionRequired:
+		return ErrPreconditionRequired
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+": "+e.Fields[key])
+	}
+
+	return "client: validation failed: " + strings.Join(parts, "; ")
+}
+
+type BatchValidationError struct {
+	Items map[int]map[string]string
+}
+
+func (e *BatchValidationError) Error() string {
+	return fmt.Sprintf("client: validation failed for %d batch items", len(e.Items))
+}
+
+func newError(res *http.Response) error {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Error     json.RawMessage `json:"error"`
+		RequestID string          `json:"request_id"`
+	}
+
+	requestID := res.Header.Get("X-Request-ID")
+
+	err = json.Unmarshal(body, &envelope)
+	if err != nil || len(envelope.Error) == 0 {
+		return &APIError{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(body)), RequestID: requestID}
+	}
+
+	if envelope.RequestID != "" {
+		requestID = envelope.RequestID
+	}
+
+	var message string
+	if json.Unmarshal(envelope.Error, &message) == nil {
+		return &APIError{StatusCode: res.StatusCode, Message: message, RequestID: requestID}
+	}
+
+	if res.StatusCode == http.StatusUnprocessableEntity {
+		var fields map[string]string
+		if json.Unmarshal(envelope.Error, &fields) == nil {
+			return &ValidationError{Fields: fields}
+		}
+
+		var items map[int]map[string]string
+		if json.Unmarshal(envelope.Error, &items) == nil {
+			return &BatchValidationError{Items: items}
+		}
+	}
+
+	return &APIError{StatusCode: res.StatusCode, Message: string(envelope.Error), RequestID: requestID}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+func paginate[T any](ctx context.Context, opts ListOptions, fetch func(context.Context, ListOptions) ([]*T, Metadata, error)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		opts.Page = 0
+		opts.SkipTotal = true
+
+		for {
+			items, metadata, err := fetch(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if metadata.NextCursor == "" {
+				return
+			}
+
+			opts.Cursor = metadata.NextCursor
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+type Rikishi struct {
+	Shikona        string   `json:"shikona"`
+	HighestRank    string   `json:"highest_rank"`
+	Heya           string   `json:"heya"`
+	ShikonaHistory []string `json:"shikona_history"`
+	ShikonaKanji   string   `json:"shikona_kanji,omitempty"`
+	ShikonaKana    string   `json:"shikona_kana,omitempty"`
+	HeyaKanji      string   `json:"heya_kanji,omitempty"`
+	HeyaKana       string   `json:"heya_kana,omitempty"`
+	Version        int32    `json:"version,omitempty"`
+}
+
+type RikishiUpdate struct {
+	NewShikona     *string  `json:"new_shikona,omitempty"`
+	HighestRank    *string  `json:"highest_rank,omitempty"`
+	Heya           *string  `json:"heya,omitempty"`
+	ShikonaHistory []string `json:"shikona_history,omitempty"`
+	ShikonaKanji   *string  `json:"shikona_kanji,omitempty"`
+	ShikonaKana    *string  `json:"shikona_kana,omitempty"`
+	HeyaKanji      *string  `json:"heya_kanji,omitempty"`
+	HeyaKana       *string  `json:"heya_kana,omitempty"`
+}
+
+type RikishiFilter struct {
+	Shikona     string
+	HighestRank string
+	Heya        string
+	ListOptions
+}
+
+func (f RikishiFilter) values() url.Values {
+	qs := f.ListOptions.values()
+	setString(qs, "shikona", f.Shikona)
+	setString(qs, "highest_rank", f.HighestRank)
+	setString(qs, "heya", f.Heya)
+	return qs
+}
+
+func (c *Client) CreateRikishi(ctx context.Context, rikishi *Rikishi) (*Rikishi, error) {
+	var res struct {
+		Rikishi *Rikishi `json:"rikishi"`
+	}
+
+	body := *rikishi
+	body.Version = 0
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/rikishis", body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Rikishi, nil
+}
+
+func (c *Client) GetRikishi(ctx context.Context, shikona string) (*Rikishi, error) {
+	var res struct {
+		Rikishi *Rikishi `json:"rikishi"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/rikishis/" + url.PathEscape(shikona)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Rikishi, nil
+}
+
+func (c *Client) ListRikishis(ctx context.Context, filter RikishiFilter) ([]*Rikishi, Metadata, error) {
+	var res struct {
+		Rikishis []*Rikishi `json:"rikishis"`
+		Metadata Metadata   `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/rikishis", query: filter.values()}, &res)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return res.Rikishis, res.Metadata, nil
+}
+
+func (c *Client) AllRikishis(ctx context.Context, filter RikishiFilter) iter.Seq2[*Rikishi, error] {
+	return paginate(ctx, filter.ListOptions, func(ctx context.Context, opts ListOptions) ([]*Rikishi, Metadata, error) {
+		filter.ListOptions = opts
+		return c.ListRikishis(ctx, filter)
+	})
+}
+
+func (c *Client) UpdateRikishi(ctx context.Context, shikona string, version int32, update RikishiUpdate) (*Rikishi, error) {
+	var res struct {
+		Rikishi *Rikishi `json:"rikishi"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: "/v1/rikishis/" + url.PathEscape(shikona), body: update, version: version}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Rikishi, nil
+}
+
+func (c *Client) DeleteRikishi(ctx context.Context, shikona string, version int32) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/v1/rikishis/" + url.PathEscape(shikona), version: version}, nil)
+}
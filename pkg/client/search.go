@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type SearchHit struct {
+	Kind      string  `json:"kind"`
+	Value     string  `json:"value"`
+	Rikishi   string  `json:"rikishi,omitempty"`
+	Score     float64 `json:"score"`
+	Highlight string  `json:"highlight"`
+}
+
+func (c *Client) Search(ctx context.Context, query string, kinds []string, limit int) ([]*SearchHit, error) {
+	var res struct {
+		Results []*SearchHit `json:"results"`
+	}
+
+	qs := url.Values{"q": {query}}
+	if len(kinds) > 0 {
+		qs.Set("types", strings.Join(kinds, ","))
+	}
+	if limit > 0 {
+		qs.Set("limit", strconv.Itoa(limit))
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/search", query: qs}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Results, nil
+}
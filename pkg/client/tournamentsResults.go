@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type TournamentResult struct {
+	ID         int64  `json:"id,omitempty"`
+	Tournament string `json:"tournament"`
+	Rikishi    string `json:"rikishi"`
+	Rank       string `json:"rank"`
+	Wins       int32  `json:"wins"`
+	Losses     int32  `json:"losses"`
+	Absent     int32  `json:"absent"`
+	Version    int32  `json:"version,omitempty"`
+}
+
+type TournamentResultUpdate struct {
+	Tournament *string `json:"tournament,omitempty"`
+	Rikishi    *string `json:"rikishi,omitempty"`
+	Rank       *string `json:"rank,omitempty"`
+	Wins       *int32  `json:"wins,omitempty"`
+	Losses     *int32  `json:"losses,omitempty"`
+	Absent     *int32  `json:"absent,omitempty"`
+}
+
+type TournamentResultFilter struct {
+	Tournament string
+	Rikishi    string
+	Rank       string
+	Wins       int
+	ListOptions
+}
+
+func (f TournamentResultFilter) values() url.Values {
+	qs := f.ListOptions.values()
+	setString(qs, "tournament", f.Tournament)
+	setString(qs, "rikishi", f.Rikishi)
+	setString(qs, "rank", f.Rank)
+	if f.Wins > 0 {
+		qs.Set("wins", strconv.Itoa(f.Wins))
+	}
+	return qs
+}
+
+func tournamentResultPath(id int64) string {
+	return "/v1/tournamentsresults/" + strconv.FormatInt(id, 10)
+}
+
+func tournamentResultBody(tr *TournamentResult) interface{} {
+	return struct {
+		Tournament string `json:"tournament"`
+		Rikishi    string `json:"rikishi"`
+		Rank       string `json:"rank"`
+		Wins       int32  `json:"wins"`
+		Losses     int32  `json:"losses"`
+		Absent     int32  `json:"absent"`
+	}{tr.Tournament, tr.Rikishi, tr.Rank, tr.Wins, tr.Losses, tr.Absent}
+}
+
+func (c *Client) CreateTournamentResult(ctx context.Context, tr *TournamentResult) (*TournamentResult, error) {
+	var res struct {
+		TournamentResult *TournamentResult `json:"tournament_result"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/tournamentsresults", body: tournamentResultBody(tr)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TournamentResult, nil
+}
+
+func (c *Client) CreateTournamentsResults(ctx context.Context, trs []*TournamentResult) ([]*TournamentResult, error) {
+	var res struct {
+		TournamentsResults []*TournamentResult `json:"tournaments_results"`
+	}
+
+	body := make([]interface{}, 0, len(trs))
+	for _, tr := range trs {
+		body = append(body, tournamentResultBody(tr))
+	}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/tournamentsresults/batch", body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TournamentsResults, nil
+}
+
+func (c *Client) GetTournamentResult(ctx context.Context, id int64) (*TournamentResult, error) {
+	var res struct {
+		TournamentResult *TournamentResult `json:"tournament_result"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: tournamentResultPath(id)}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TournamentResult, nil
+}
+
+func (c *Client) ListTournamentsResults(ctx context.Context, filter TournamentResultFilter) ([]*TournamentResult, Metadata, error) {
+	var res struct {
+		TournamentsResults []*TournamentResult `json:"tournaments_results"`
+		Metadata           Metadata            `json:"metadata"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodGet, path: "/v1/tournamentsresults", query: filter.values()}, &res)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	return res.TournamentsResults, res.Metadata, nil
+}
+
+func (c *Client) AllTournamentsResults(ctx context.Context, filter TournamentResultFilter) iter.Seq2[*TournamentResult, error] {
+	return paginate(ctx, filter.ListOptions, func(ctx context.Context, opts ListOptions) ([]*TournamentResult, Metadata, error) {
+		filter.ListOptions = opts
+		return c.ListTournamentsResults(ctx, filter)
+	})
+}
+
+func (c *Client) UpdateTournamentResult(ctx context.Context, id int64, version int32, update TournamentResultUpdate) (*TournamentResult, error) {
+	var res struct {
+		TournamentResult *TournamentResult `json:"tournament_result"`
+	}
+
+	err := c.do(ctx, request{method: http.MethodPatch, path: tournamentResultPath(id), body: update, version: version}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TournamentResult, nil
+}
+
+func (c *Client) DeleteTournamentResult(ctx context.Context, id int64, version int32) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: tournamentResultPath(id), version: version}, nil)
+}
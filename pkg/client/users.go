@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type User struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Activated bool      `json:"activated"`
+}
+
+func (c *Client) RegisterUser(ctx context.Context, name, email, password string) (*User, error) {
+	var res struct {
+		User *User `json:"user"`
+	}
+
+	body := map[string]string{"name": name, "email": email, "password": password}
+
+	err := c.do(ctx, request{method: http.MethodPost, path: "/v1/users", body: body}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.User, nil
+}
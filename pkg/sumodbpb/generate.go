@@ -0,0 +1,5 @@
+// Package sumodbpb holds the protobuf messages and gRPC service definitions
+// for the sumodb API.
+package sumodbpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative sumodb.proto
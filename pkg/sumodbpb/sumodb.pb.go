@@ -0,0 +1,3102 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.0
+// source: sumodb.proto
+
+package sumodbpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BoutEvent_Type int32
+
+const (
+	BoutEvent_TYPE_UNSPECIFIED BoutEvent_Type = 0
+	BoutEvent_TYPE_CREATED     BoutEvent_Type = 1
+	BoutEvent_TYPE_UPDATED     BoutEvent_Type = 2
+	BoutEvent_TYPE_DELETED     BoutEvent_Type = 3
+)
+
+// Enum value maps for BoutEvent_Type.
+var (
+	BoutEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_DELETED",
+	}
+	BoutEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_DELETED":     3,
+	}
+)
+
+func (x BoutEvent_Type) Enum() *BoutEvent_Type {
+	p := new(BoutEvent_Type)
+	*p = x
+	return p
+}
+
+func (x BoutEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoutEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_sumodb_proto_enumTypes[0].Descriptor()
+}
+
+func (BoutEvent_Type) Type() protoreflect.EnumType {
+	return &file_sumodb_proto_enumTypes[0]
+}
+
+func (x BoutEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoutEvent_Type.Descriptor instead.
+func (BoutEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{32, 0}
+}
+
+type HealthcheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthcheckRequest) Reset() {
+	*x = HealthcheckRequest{}
+	mi := &file_sumodb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthcheckRequest) ProtoMessage() {}
+
+func (x *HealthcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthcheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthcheckRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{0}
+}
+
+type HealthcheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Environment   string                 `protobuf:"bytes,2,opt,name=environment,proto3" json:"environment,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthcheckResponse) Reset() {
+	*x = HealthcheckResponse{}
+	mi := &file_sumodb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthcheckResponse) ProtoMessage() {}
+
+func (x *HealthcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthcheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthcheckResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthcheckResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthcheckResponse) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *HealthcheckResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type PageRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Page      int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize  int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Sort      string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Cursor    string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	SkipTotal bool                   `protobuf:"varint,5,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"`
+	// all returns every matching record and requires an API key.
+	All           bool `protobuf:"varint,6,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_sumodb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PageRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *PageRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *PageRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
+func (x *PageRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPage   int32                  `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	FirstPage     int32                  `protobuf:"varint,3,opt,name=first_page,json=firstPage,proto3" json:"first_page,omitempty"`
+	LastPage      int32                  `protobuf:"varint,4,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
+	TotalRecords  int32                  `protobuf:"varint,5,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_sumodb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Metadata) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *Metadata) GetFirstPage() int32 {
+	if x != nil {
+		return x.FirstPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *Metadata) GetTotalRecords() int32 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+func (x *Metadata) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *Metadata) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_sumodb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_sumodb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Rikishi struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Shikona        string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	HighestRank    string                 `protobuf:"bytes,2,opt,name=highest_rank,json=highestRank,proto3" json:"highest_rank,omitempty"`
+	Heya           string                 `protobuf:"bytes,3,opt,name=heya,proto3" json:"heya,omitempty"`
+	ShikonaHistory []string               `protobuf:"bytes,4,rep,name=shikona_history,json=shikonaHistory,proto3" json:"shikona_history,omitempty"`
+	ShikonaKanji   string                 `protobuf:"bytes,5,opt,name=shikona_kanji,json=shikonaKanji,proto3" json:"shikona_kanji,omitempty"`
+	ShikonaKana    string                 `protobuf:"bytes,6,opt,name=shikona_kana,json=shikonaKana,proto3" json:"shikona_kana,omitempty"`
+	HeyaKanji      string                 `protobuf:"bytes,7,opt,name=heya_kanji,json=heyaKanji,proto3" json:"heya_kanji,omitempty"`
+	HeyaKana       string                 `protobuf:"bytes,8,opt,name=heya_kana,json=heyaKana,proto3" json:"heya_kana,omitempty"`
+	Version        int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Rikishi) Reset() {
+	*x = Rikishi{}
+	mi := &file_sumodb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rikishi) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rikishi) ProtoMessage() {}
+
+func (x *Rikishi) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rikishi.ProtoReflect.Descriptor instead.
+func (*Rikishi) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Rikishi) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+func (x *Rikishi) GetHighestRank() string {
+	if x != nil {
+		return x.HighestRank
+	}
+	return ""
+}
+
+func (x *Rikishi) GetHeya() string {
+	if x != nil {
+		return x.Heya
+	}
+	return ""
+}
+
+func (x *Rikishi) GetShikonaHistory() []string {
+	if x != nil {
+		return x.ShikonaHistory
+	}
+	return nil
+}
+
+func (x *Rikishi) GetShikonaKanji() string {
+	if x != nil {
+		return x.ShikonaKanji
+	}
+	return ""
+}
+
+func (x *Rikishi) GetShikonaKana() string {
+	if x != nil {
+		return x.ShikonaKana
+	}
+	return ""
+}
+
+func (x *Rikishi) GetHeyaKanji() string {
+	if x != nil {
+		return x.HeyaKanji
+	}
+	return ""
+}
+
+func (x *Rikishi) GetHeyaKana() string {
+	if x != nil {
+		return x.HeyaKana
+	}
+	return ""
+}
+
+func (x *Rikishi) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateRikishiRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Shikona        string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	HighestRank    string                 `protobuf:"bytes,2,opt,name=highest_rank,json=highestRank,proto3" json:"highest_rank,omitempty"`
+	Heya           string                 `protobuf:"bytes,3,opt,name=heya,proto3" json:"heya,omitempty"`
+	ShikonaHistory []string               `protobuf:"bytes,4,rep,name=shikona_history,json=shikonaHistory,proto3" json:"shikona_history,omitempty"`
+	ShikonaKanji   string                 `protobuf:"bytes,5,opt,name=shikona_kanji,json=shikonaKanji,proto3" json:"shikona_kanji,omitempty"`
+	ShikonaKana    string                 `protobuf:"bytes,6,opt,name=shikona_kana,json=shikonaKana,proto3" json:"shikona_kana,omitempty"`
+	HeyaKanji      string                 `protobuf:"bytes,7,opt,name=heya_kanji,json=heyaKanji,proto3" json:"heya_kanji,omitempty"`
+	HeyaKana       string                 `protobuf:"bytes,8,opt,name=heya_kana,json=heyaKana,proto3" json:"heya_kana,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateRikishiRequest) Reset() {
+	*x = CreateRikishiRequest{}
+	mi := &file_sumodb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRikishiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRikishiRequest) ProtoMessage() {}
+
+func (x *CreateRikishiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRikishiRequest.ProtoReflect.Descriptor instead.
+func (*CreateRikishiRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateRikishiRequest) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetHighestRank() string {
+	if x != nil {
+		return x.HighestRank
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetHeya() string {
+	if x != nil {
+		return x.Heya
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetShikonaHistory() []string {
+	if x != nil {
+		return x.ShikonaHistory
+	}
+	return nil
+}
+
+func (x *CreateRikishiRequest) GetShikonaKanji() string {
+	if x != nil {
+		return x.ShikonaKanji
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetShikonaKana() string {
+	if x != nil {
+		return x.ShikonaKana
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetHeyaKanji() string {
+	if x != nil {
+		return x.HeyaKanji
+	}
+	return ""
+}
+
+func (x *CreateRikishiRequest) GetHeyaKana() string {
+	if x != nil {
+		return x.HeyaKana
+	}
+	return ""
+}
+
+type GetRikishiRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shikona       string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRikishiRequest) Reset() {
+	*x = GetRikishiRequest{}
+	mi := &file_sumodb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRikishiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRikishiRequest) ProtoMessage() {}
+
+func (x *GetRikishiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRikishiRequest.ProtoReflect.Descriptor instead.
+func (*GetRikishiRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRikishiRequest) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+type ListRikishisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shikona       string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	HighestRank   string                 `protobuf:"bytes,2,opt,name=highest_rank,json=highestRank,proto3" json:"highest_rank,omitempty"`
+	Heya          string                 `protobuf:"bytes,3,opt,name=heya,proto3" json:"heya,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRikishisRequest) Reset() {
+	*x = ListRikishisRequest{}
+	mi := &file_sumodb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRikishisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRikishisRequest) ProtoMessage() {}
+
+func (x *ListRikishisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRikishisRequest.ProtoReflect.Descriptor instead.
+func (*ListRikishisRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRikishisRequest) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+func (x *ListRikishisRequest) GetHighestRank() string {
+	if x != nil {
+		return x.HighestRank
+	}
+	return ""
+}
+
+func (x *ListRikishisRequest) GetHeya() string {
+	if x != nil {
+		return x.Heya
+	}
+	return ""
+}
+
+func (x *ListRikishisRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListRikishisResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rikishis      []*Rikishi             `protobuf:"bytes,1,rep,name=rikishis,proto3" json:"rikishis,omitempty"`
+	Metadata      *Metadata              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRikishisResponse) Reset() {
+	*x = ListRikishisResponse{}
+	mi := &file_sumodb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRikishisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRikishisResponse) ProtoMessage() {}
+
+func (x *ListRikishisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRikishisResponse.ProtoReflect.Descriptor instead.
+func (*ListRikishisResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRikishisResponse) GetRikishis() []*Rikishi {
+	if x != nil {
+		return x.Rikishis
+	}
+	return nil
+}
+
+func (x *ListRikishisResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdateRikishiRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Shikona string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	// version must match the stored version when set, like If-Match.
+	Version        int32       `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	NewShikona     *string     `protobuf:"bytes,3,opt,name=new_shikona,json=newShikona,proto3,oneof" json:"new_shikona,omitempty"`
+	HighestRank    *string     `protobuf:"bytes,4,opt,name=highest_rank,json=highestRank,proto3,oneof" json:"highest_rank,omitempty"`
+	Heya           *string     `protobuf:"bytes,5,opt,name=heya,proto3,oneof" json:"heya,omitempty"`
+	ShikonaHistory *StringList `protobuf:"bytes,6,opt,name=shikona_history,json=shikonaHistory,proto3" json:"shikona_history,omitempty"`
+	ShikonaKanji   *string     `protobuf:"bytes,7,opt,name=shikona_kanji,json=shikonaKanji,proto3,oneof" json:"shikona_kanji,omitempty"`
+	ShikonaKana    *string     `protobuf:"bytes,8,opt,name=shikona_kana,json=shikonaKana,proto3,oneof" json:"shikona_kana,omitempty"`
+	HeyaKanji      *string     `protobuf:"bytes,9,opt,name=heya_kanji,json=heyaKanji,proto3,oneof" json:"heya_kanji,omitempty"`
+	HeyaKana       *string     `protobuf:"bytes,10,opt,name=heya_kana,json=heyaKana,proto3,oneof" json:"heya_kana,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateRikishiRequest) Reset() {
+	*x = UpdateRikishiRequest{}
+	mi := &file_sumodb_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRikishiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRikishiRequest) ProtoMessage() {}
+
+func (x *UpdateRikishiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRikishiRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRikishiRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateRikishiRequest) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateRikishiRequest) GetNewShikona() string {
+	if x != nil && x.NewShikona != nil {
+		return *x.NewShikona
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetHighestRank() string {
+	if x != nil && x.HighestRank != nil {
+		return *x.HighestRank
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetHeya() string {
+	if x != nil && x.Heya != nil {
+		return *x.Heya
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetShikonaHistory() *StringList {
+	if x != nil {
+		return x.ShikonaHistory
+	}
+	return nil
+}
+
+func (x *UpdateRikishiRequest) GetShikonaKanji() string {
+	if x != nil && x.ShikonaKanji != nil {
+		return *x.ShikonaKanji
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetShikonaKana() string {
+	if x != nil && x.ShikonaKana != nil {
+		return *x.ShikonaKana
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetHeyaKanji() string {
+	if x != nil && x.HeyaKanji != nil {
+		return *x.HeyaKanji
+	}
+	return ""
+}
+
+func (x *UpdateRikishiRequest) GetHeyaKana() string {
+	if x != nil && x.HeyaKana != nil {
+		return *x.HeyaKana
+	}
+	return ""
+}
+
+type DeleteRikishiRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shikona       string                 `protobuf:"bytes,1,opt,name=shikona,proto3" json:"shikona,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRikishiRequest) Reset() {
+	*x = DeleteRikishiRequest{}
+	mi := &file_sumodb_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRikishiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRikishiRequest) ProtoMessage() {}
+
+func (x *DeleteRikishiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRikishiRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRikishiRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteRikishiRequest) GetShikona() string {
+	if x != nil {
+		return x.Shikona
+	}
+	return ""
+}
+
+func (x *DeleteRikishiRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type TournamentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tournament    string                 `protobuf:"bytes,2,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Rikishi       string                 `protobuf:"bytes,3,opt,name=rikishi,proto3" json:"rikishi,omitempty"`
+	Rank          string                 `protobuf:"bytes,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Wins          int32                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+	Absent        int32                  `protobuf:"varint,7,opt,name=absent,proto3" json:"absent,omitempty"`
+	Version       int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentResult) Reset() {
+	*x = TournamentResult{}
+	mi := &file_sumodb_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentResult) ProtoMessage() {}
+
+func (x *TournamentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentResult.ProtoReflect.Descriptor instead.
+func (*TournamentResult) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TournamentResult) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TournamentResult) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *TournamentResult) GetRikishi() string {
+	if x != nil {
+		return x.Rikishi
+	}
+	return ""
+}
+
+func (x *TournamentResult) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *TournamentResult) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *TournamentResult) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *TournamentResult) GetAbsent() int32 {
+	if x != nil {
+		return x.Absent
+	}
+	return 0
+}
+
+func (x *TournamentResult) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateTournamentResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    string                 `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Rikishi       string                 `protobuf:"bytes,2,opt,name=rikishi,proto3" json:"rikishi,omitempty"`
+	Rank          string                 `protobuf:"bytes,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Wins          int32                  `protobuf:"varint,4,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,5,opt,name=losses,proto3" json:"losses,omitempty"`
+	Absent        int32                  `protobuf:"varint,6,opt,name=absent,proto3" json:"absent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTournamentResultRequest) Reset() {
+	*x = CreateTournamentResultRequest{}
+	mi := &file_sumodb_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentResultRequest) ProtoMessage() {}
+
+func (x *CreateTournamentResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentResultRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentResultRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateTournamentResultRequest) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *CreateTournamentResultRequest) GetRikishi() string {
+	if x != nil {
+		return x.Rikishi
+	}
+	return ""
+}
+
+func (x *CreateTournamentResultRequest) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *CreateTournamentResultRequest) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *CreateTournamentResultRequest) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *CreateTournamentResultRequest) GetAbsent() int32 {
+	if x != nil {
+		return x.Absent
+	}
+	return 0
+}
+
+type CreateTournamentsResultsRequest struct {
+	state              protoimpl.MessageState           `protogen:"open.v1"`
+	TournamentsResults []*CreateTournamentResultRequest `protobuf:"bytes,1,rep,name=tournaments_results,json=tournamentsResults,proto3" json:"tournaments_results,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateTournamentsResultsRequest) Reset() {
+	*x = CreateTournamentsResultsRequest{}
+	mi := &file_sumodb_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentsResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentsResultsRequest) ProtoMessage() {}
+
+func (x *CreateTournamentsResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentsResultsRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentsResultsRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateTournamentsResultsRequest) GetTournamentsResults() []*CreateTournamentResultRequest {
+	if x != nil {
+		return x.TournamentsResults
+	}
+	return nil
+}
+
+type CreateTournamentsResultsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TournamentsResults []*TournamentResult    `protobuf:"bytes,1,rep,name=tournaments_results,json=tournamentsResults,proto3" json:"tournaments_results,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateTournamentsResultsResponse) Reset() {
+	*x = CreateTournamentsResultsResponse{}
+	mi := &file_sumodb_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentsResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentsResultsResponse) ProtoMessage() {}
+
+func (x *CreateTournamentsResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentsResultsResponse.ProtoReflect.Descriptor instead.
+func (*CreateTournamentsResultsResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateTournamentsResultsResponse) GetTournamentsResults() []*TournamentResult {
+	if x != nil {
+		return x.TournamentsResults
+	}
+	return nil
+}
+
+type GetTournamentResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTournamentResultRequest) Reset() {
+	*x = GetTournamentResultRequest{}
+	mi := &file_sumodb_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTournamentResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentResultRequest) ProtoMessage() {}
+
+func (x *GetTournamentResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentResultRequest.ProtoReflect.Descriptor instead.
+func (*GetTournamentResultRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTournamentResultRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTournamentsResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    string                 `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Rikishi       string                 `protobuf:"bytes,2,opt,name=rikishi,proto3" json:"rikishi,omitempty"`
+	Rank          string                 `protobuf:"bytes,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Wins          int32                  `protobuf:"varint,4,opt,name=wins,proto3" json:"wins,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,5,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsResultsRequest) Reset() {
+	*x = ListTournamentsResultsRequest{}
+	mi := &file_sumodb_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsResultsRequest) ProtoMessage() {}
+
+func (x *ListTournamentsResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResultsRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTournamentsResultsRequest) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *ListTournamentsResultsRequest) GetRikishi() string {
+	if x != nil {
+		return x.Rikishi
+	}
+	return ""
+}
+
+func (x *ListTournamentsResultsRequest) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *ListTournamentsResultsRequest) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *ListTournamentsResultsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListTournamentsResultsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TournamentsResults []*TournamentResult    `protobuf:"bytes,1,rep,name=tournaments_results,json=tournamentsResults,proto3" json:"tournaments_results,omitempty"`
+	Metadata           *Metadata              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListTournamentsResultsResponse) Reset() {
+	*x = ListTournamentsResultsResponse{}
+	mi := &file_sumodb_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsResultsResponse) ProtoMessage() {}
+
+func (x *ListTournamentsResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResultsResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTournamentsResultsResponse) GetTournamentsResults() []*TournamentResult {
+	if x != nil {
+		return x.TournamentsResults
+	}
+	return nil
+}
+
+func (x *ListTournamentsResultsResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdateTournamentResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Tournament    *string                `protobuf:"bytes,3,opt,name=tournament,proto3,oneof" json:"tournament,omitempty"`
+	Rikishi       *string                `protobuf:"bytes,4,opt,name=rikishi,proto3,oneof" json:"rikishi,omitempty"`
+	Rank          *string                `protobuf:"bytes,5,opt,name=rank,proto3,oneof" json:"rank,omitempty"`
+	Wins          *int32                 `protobuf:"varint,6,opt,name=wins,proto3,oneof" json:"wins,omitempty"`
+	Losses        *int32                 `protobuf:"varint,7,opt,name=losses,proto3,oneof" json:"losses,omitempty"`
+	Absent        *int32                 `protobuf:"varint,8,opt,name=absent,proto3,oneof" json:"absent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTournamentResultRequest) Reset() {
+	*x = UpdateTournamentResultRequest{}
+	mi := &file_sumodb_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTournamentResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTournamentResultRequest) ProtoMessage() {}
+
+func (x *UpdateTournamentResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTournamentResultRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTournamentResultRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateTournamentResultRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTournamentResultRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateTournamentResultRequest) GetTournament() string {
+	if x != nil && x.Tournament != nil {
+		return *x.Tournament
+	}
+	return ""
+}
+
+func (x *UpdateTournamentResultRequest) GetRikishi() string {
+	if x != nil && x.Rikishi != nil {
+		return *x.Rikishi
+	}
+	return ""
+}
+
+func (x *UpdateTournamentResultRequest) GetRank() string {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return ""
+}
+
+func (x *UpdateTournamentResultRequest) GetWins() int32 {
+	if x != nil && x.Wins != nil {
+		return *x.Wins
+	}
+	return 0
+}
+
+func (x *UpdateTournamentResultRequest) GetLosses() int32 {
+	if x != nil && x.Losses != nil {
+		return *x.Losses
+	}
+	return 0
+}
+
+func (x *UpdateTournamentResultRequest) GetAbsent() int32 {
+	if x != nil && x.Absent != nil {
+		return *x.Absent
+	}
+	return 0
+}
+
+type DeleteTournamentResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTournamentResultRequest) Reset() {
+	*x = DeleteTournamentResultRequest{}
+	mi := &file_sumodb_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTournamentResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTournamentResultRequest) ProtoMessage() {}
+
+func (x *DeleteTournamentResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTournamentResultRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTournamentResultRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteTournamentResultRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTournamentResultRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Bout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tournament    string                 `protobuf:"bytes,2,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Day           string                 `protobuf:"bytes,3,opt,name=day,proto3" json:"day,omitempty"`
+	Division      string                 `protobuf:"bytes,4,opt,name=division,proto3" json:"division,omitempty"`
+	Winner        string                 `protobuf:"bytes,5,opt,name=winner,proto3" json:"winner,omitempty"`
+	Loser         string                 `protobuf:"bytes,6,opt,name=loser,proto3" json:"loser,omitempty"`
+	Kimarite      string                 `protobuf:"bytes,7,opt,name=kimarite,proto3" json:"kimarite,omitempty"`
+	KimariteKanji string                 `protobuf:"bytes,8,opt,name=kimarite_kanji,json=kimariteKanji,proto3" json:"kimarite_kanji,omitempty"`
+	KimariteKana  string                 `protobuf:"bytes,9,opt,name=kimarite_kana,json=kimariteKana,proto3" json:"kimarite_kana,omitempty"`
+	Version       int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bout) Reset() {
+	*x = Bout{}
+	mi := &file_sumodb_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bout) ProtoMessage() {}
+
+func (x *Bout) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bout.ProtoReflect.Descriptor instead.
+func (*Bout) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Bout) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Bout) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *Bout) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *Bout) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *Bout) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *Bout) GetLoser() string {
+	if x != nil {
+		return x.Loser
+	}
+	return ""
+}
+
+func (x *Bout) GetKimarite() string {
+	if x != nil {
+		return x.Kimarite
+	}
+	return ""
+}
+
+func (x *Bout) GetKimariteKanji() string {
+	if x != nil {
+		return x.KimariteKanji
+	}
+	return ""
+}
+
+func (x *Bout) GetKimariteKana() string {
+	if x != nil {
+		return x.KimariteKana
+	}
+	return ""
+}
+
+func (x *Bout) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateBoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    string                 `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Day           string                 `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	Division      string                 `protobuf:"bytes,3,opt,name=division,proto3" json:"division,omitempty"`
+	Winner        string                 `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`
+	Loser         string                 `protobuf:"bytes,5,opt,name=loser,proto3" json:"loser,omitempty"`
+	Kimarite      string                 `protobuf:"bytes,6,opt,name=kimarite,proto3" json:"kimarite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBoutRequest) Reset() {
+	*x = CreateBoutRequest{}
+	mi := &file_sumodb_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBoutRequest) ProtoMessage() {}
+
+func (x *CreateBoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBoutRequest.ProtoReflect.Descriptor instead.
+func (*CreateBoutRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateBoutRequest) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *CreateBoutRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *CreateBoutRequest) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *CreateBoutRequest) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *CreateBoutRequest) GetLoser() string {
+	if x != nil {
+		return x.Loser
+	}
+	return ""
+}
+
+func (x *CreateBoutRequest) GetKimarite() string {
+	if x != nil {
+		return x.Kimarite
+	}
+	return ""
+}
+
+type CreateBoutsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bouts         []*CreateBoutRequest   `protobuf:"bytes,1,rep,name=bouts,proto3" json:"bouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBoutsRequest) Reset() {
+	*x = CreateBoutsRequest{}
+	mi := &file_sumodb_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBoutsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBoutsRequest) ProtoMessage() {}
+
+func (x *CreateBoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBoutsRequest.ProtoReflect.Descriptor instead.
+func (*CreateBoutsRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateBoutsRequest) GetBouts() []*CreateBoutRequest {
+	if x != nil {
+		return x.Bouts
+	}
+	return nil
+}
+
+type CreateBoutsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bouts         []*Bout                `protobuf:"bytes,1,rep,name=bouts,proto3" json:"bouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBoutsResponse) Reset() {
+	*x = CreateBoutsResponse{}
+	mi := &file_sumodb_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBoutsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBoutsResponse) ProtoMessage() {}
+
+func (x *CreateBoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBoutsResponse.ProtoReflect.Descriptor instead.
+func (*CreateBoutsResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateBoutsResponse) GetBouts() []*Bout {
+	if x != nil {
+		return x.Bouts
+	}
+	return nil
+}
+
+type GetBoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBoutRequest) Reset() {
+	*x = GetBoutRequest{}
+	mi := &file_sumodb_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoutRequest) ProtoMessage() {}
+
+func (x *GetBoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoutRequest.ProtoReflect.Descriptor instead.
+func (*GetBoutRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetBoutRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBoutsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    string                 `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Day           string                 `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	Division      string                 `protobuf:"bytes,3,opt,name=division,proto3" json:"division,omitempty"`
+	Kimarite      string                 `protobuf:"bytes,4,opt,name=kimarite,proto3" json:"kimarite,omitempty"`
+	Rikishi1      string                 `protobuf:"bytes,5,opt,name=rikishi1,proto3" json:"rikishi1,omitempty"`
+	Rikishi2      string                 `protobuf:"bytes,6,opt,name=rikishi2,proto3" json:"rikishi2,omitempty"`
+	Winner        string                 `protobuf:"bytes,7,opt,name=winner,proto3" json:"winner,omitempty"`
+	Loser         string                 `protobuf:"bytes,8,opt,name=loser,proto3" json:"loser,omitempty"`
+	From          string                 `protobuf:"bytes,9,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,10,opt,name=to,proto3" json:"to,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,11,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBoutsRequest) Reset() {
+	*x = ListBoutsRequest{}
+	mi := &file_sumodb_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBoutsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBoutsRequest) ProtoMessage() {}
+
+func (x *ListBoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBoutsRequest.ProtoReflect.Descriptor instead.
+func (*ListBoutsRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListBoutsRequest) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetDivision() string {
+	if x != nil {
+		return x.Division
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetKimarite() string {
+	if x != nil {
+		return x.Kimarite
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetRikishi1() string {
+	if x != nil {
+		return x.Rikishi1
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetRikishi2() string {
+	if x != nil {
+		return x.Rikishi2
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetLoser() string {
+	if x != nil {
+		return x.Loser
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListBoutsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListBoutsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bouts         []*Bout                `protobuf:"bytes,1,rep,name=bouts,proto3" json:"bouts,omitempty"`
+	Metadata      *Metadata              `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBoutsResponse) Reset() {
+	*x = ListBoutsResponse{}
+	mi := &file_sumodb_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBoutsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBoutsResponse) ProtoMessage() {}
+
+func (x *ListBoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBoutsResponse.ProtoReflect.Descriptor instead.
+func (*ListBoutsResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListBoutsResponse) GetBouts() []*Bout {
+	if x != nil {
+		return x.Bouts
+	}
+	return nil
+}
+
+func (x *ListBoutsResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdateBoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Tournament    *string                `protobuf:"bytes,3,opt,name=tournament,proto3,oneof" json:"tournament,omitempty"`
+	Day           *string                `protobuf:"bytes,4,opt,name=day,proto3,oneof" json:"day,omitempty"`
+	Division      *string                `protobuf:"bytes,5,opt,name=division,proto3,oneof" json:"division,omitempty"`
+	Winner        *string                `protobuf:"bytes,6,opt,name=winner,proto3,oneof" json:"winner,omitempty"`
+	Loser         *string                `protobuf:"bytes,7,opt,name=loser,proto3,oneof" json:"loser,omitempty"`
+	Kimarite      *string                `protobuf:"bytes,8,opt,name=kimarite,proto3,oneof" json:"kimarite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBoutRequest) Reset() {
+	*x = UpdateBoutRequest{}
+	mi := &file_sumodb_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBoutRequest) ProtoMessage() {}
+
+func (x *UpdateBoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBoutRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBoutRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateBoutRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBoutRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateBoutRequest) GetTournament() string {
+	if x != nil && x.Tournament != nil {
+		return *x.Tournament
+	}
+	return ""
+}
+
+func (x *UpdateBoutRequest) GetDay() string {
+	if x != nil && x.Day != nil {
+		return *x.Day
+	}
+	return ""
+}
+
+func (x *UpdateBoutRequest) GetDivision() string {
+	if x != nil && x.Division != nil {
+		return *x.Division
+	}
+	return ""
+}
+
+func (x *UpdateBoutRequest) GetWinner() string {
+	if x != nil && x.Winner != nil {
+		return *x.Winner
+	}
+	return ""
+}
+
+func (x *UpdateBoutRequest) GetLoser() string {
+	if x != nil && x.Loser != nil {
+		return *x.Loser
+	}
+	return ""
+}
+
+func (x *UpdateBoutRequest) GetKimarite() string {
+	if x != nil && x.Kimarite != nil {
+		return *x.Kimarite
+	}
+	return ""
+}
+
+type DeleteBoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBoutRequest) Reset() {
+	*x = DeleteBoutRequest{}
+	mi := &file_sumodb_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBoutRequest) ProtoMessage() {}
+
+func (x *DeleteBoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBoutRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBoutRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteBoutRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteBoutRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type WatchBoutsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    string                 `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Day           string                 `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	Rikishi       string                 `protobuf:"bytes,3,opt,name=rikishi,proto3" json:"rikishi,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBoutsRequest) Reset() {
+	*x = WatchBoutsRequest{}
+	mi := &file_sumodb_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBoutsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBoutsRequest) ProtoMessage() {}
+
+func (x *WatchBoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBoutsRequest.ProtoReflect.Descriptor instead.
+func (*WatchBoutsRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WatchBoutsRequest) GetTournament() string {
+	if x != nil {
+		return x.Tournament
+	}
+	return ""
+}
+
+func (x *WatchBoutsRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *WatchBoutsRequest) GetRikishi() string {
+	if x != nil {
+		return x.Rikishi
+	}
+	return ""
+}
+
+type BoutEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          BoutEvent_Type         `protobuf:"varint,2,opt,name=type,proto3,enum=sumodb.v1.BoutEvent_Type" json:"type,omitempty"`
+	Bout          *Bout                  `protobuf:"bytes,3,opt,name=bout,proto3" json:"bout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoutEvent) Reset() {
+	*x = BoutEvent{}
+	mi := &file_sumodb_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoutEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoutEvent) ProtoMessage() {}
+
+func (x *BoutEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoutEvent.ProtoReflect.Descriptor instead.
+func (*BoutEvent) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BoutEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BoutEvent) GetType() BoutEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return BoutEvent_TYPE_UNSPECIFIED
+}
+
+func (x *BoutEvent) GetBout() *Bout {
+	if x != nil {
+		return x.Bout
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_sumodb_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Rikishi       string                 `protobuf:"bytes,3,opt,name=rikishi,proto3" json:"rikishi,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Highlight     string                 `protobuf:"bytes,5,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_sumodb_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchHit) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SearchHit) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SearchHit) GetRikishi() string {
+	if x != nil {
+		return x.Rikishi
+	}
+	return ""
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetHighlight() string {
+	if x != nil {
+		return x.Highlight
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchHit           `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_sumodb_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchResponse) GetResults() []*SearchHit {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type RegisterUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterUserRequest) Reset() {
+	*x = RegisterUserRequest{}
+	mi := &file_sumodb_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterUserRequest) ProtoMessage() {}
+
+func (x *RegisterUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterUserRequest.ProtoReflect.Descriptor instead.
+func (*RegisterUserRequest) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RegisterUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Activated     bool                   `protobuf:"varint,5,opt,name=activated,proto3" json:"activated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_sumodb_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_sumodb_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_sumodb_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetActivated() bool {
+	if x != nil {
+		return x.Activated
+	}
+	return false
+}
+
+var File_sumodb_proto protoreflect.FileDescriptor
+
+const file_sumodb_proto_rawDesc = "" +
+	"\n" +
+	"\fsumodb.proto\x12\tsumodb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x14\n" +
+	"\x12HealthcheckRequest\"i\n" +
+	"\x13HealthcheckResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12 \n" +
+	"\venvironment\x18\x02 \x01(\tR\venvironment\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"\x9b\x01\n" +
+	"\vPageRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\x05 \x01(\bR\tskipTotal\x12\x10\n" +
+	"\x03all\x18\x06 \x01(\bR\x03all\"\xed\x01\n" +
+	"\bMetadata\x12!\n" +
+	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"first_page\x18\x03 \x01(\x05R\tfirstPage\x12\x1b\n" +
+	"\tlast_page\x18\x04 \x01(\x05R\blastPage\x12#\n" +
+	"\rtotal_records\x18\x05 \x01(\x05R\ftotalRecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"*\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xa1\x02\n" +
+	"\aRikishi\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\x12!\n" +
+	"\fhighest_rank\x18\x02 \x01(\tR\vhighestRank\x12\x12\n" +
+	"\x04heya\x18\x03 \x01(\tR\x04heya\x12'\n" +
+	"\x0fshikona_history\x18\x04 \x03(\tR\x0eshikonaHistory\x12#\n" +
+	"\rshikona_kanji\x18\x05 \x01(\tR\fshikonaKanji\x12!\n" +
+	"\fshikona_kana\x18\x06 \x01(\tR\vshikonaKana\x12\x1d\n" +
+	"\n" +
+	"heya_kanji\x18\a \x01(\tR\theyaKanji\x12\x1b\n" +
+	"\theya_kana\x18\b \x01(\tR\bheyaKana\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\"\x94\x02\n" +
+	"\x14CreateRikishiRequest\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\x12!\n" +
+	"\fhighest_rank\x18\x02 \x01(\tR\vhighestRank\x12\x12\n" +
+	"\x04heya\x18\x03 \x01(\tR\x04heya\x12'\n" +
+	"\x0fshikona_history\x18\x04 \x03(\tR\x0eshikonaHistory\x12#\n" +
+	"\rshikona_kanji\x18\x05 \x01(\tR\fshikonaKanji\x12!\n" +
+	"\fshikona_kana\x18\x06 \x01(\tR\vshikonaKana\x12\x1d\n" +
+	"\n" +
+	"heya_kanji\x18\a \x01(\tR\theyaKanji\x12\x1b\n" +
+	"\theya_kana\x18\b \x01(\tR\bheyaKana\"-\n" +
+	"\x11GetRikishiRequest\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\"\x92\x01\n" +
+	"\x13ListRikishisRequest\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\x12!\n" +
+	"\fhighest_rank\x18\x02 \x01(\tR\vhighestRank\x12\x12\n" +
+	"\x04heya\x18\x03 \x01(\tR\x04heya\x12*\n" +
+	"\x04page\x18\x04 \x01(\v2\x16.sumodb.v1.PageRequestR\x04page\"w\n" +
+	"\x14ListRikishisResponse\x12.\n" +
+	"\brikishis\x18\x01 \x03(\v2\x12.sumodb.v1.RikishiR\brikishis\x12/\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x13.sumodb.v1.MetadataR\bmetadata\"\xf3\x03\n" +
+	"\x14UpdateRikishiRequest\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12$\n" +
+	"\vnew_shikona\x18\x03 \x01(\tH\x00R\n" +
+	"newShikona\x88\x01\x01\x12&\n" +
+	"\fhighest_rank\x18\x04 \x01(\tH\x01R\vhighestRank\x88\x01\x01\x12\x17\n" +
+	"\x04heya\x18\x05 \x01(\tH\x02R\x04heya\x88\x01\x01\x12>\n" +
+	"\x0fshikona_history\x18\x06 \x01(\v2\x15.sumodb.v1.StringListR\x0eshikonaHistory\x12(\n" +
+	"\rshikona_kanji\x18\a \x01(\tH\x03R\fshikonaKanji\x88\x01\x01\x12&\n" +
+	"\fshikona_kana\x18\b \x01(\tH\x04R\vshikonaKana\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"heya_kanji\x18\t \x01(\tH\x05R\theyaKanji\x88\x01\x01\x12 \n" +
+	"\theya_kana\x18\n" +
+	" \x01(\tH\x06R\bheyaKana\x88\x01\x01B\x0e\n" +
+	"\f_new_shikonaB\x0f\n" +
+	"\r_highest_rankB\a\n" +
+	"\x05_heyaB\x10\n" +
+	"\x0e_shikona_kanjiB\x0f\n" +
+	"\r_shikona_kanaB\r\n" +
+	"\v_heya_kanjiB\f\n" +
+	"\n" +
+	"_heya_kana\"J\n" +
+	"\x14DeleteRikishiRequest\x12\x18\n" +
+	"\ashikona\x18\x01 \x01(\tR\ashikona\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xce\x01\n" +
+	"\x10TournamentResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x02 \x01(\tR\n" +
+	"tournament\x12\x18\n" +
+	"\arikishi\x18\x03 \x01(\tR\arikishi\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\tR\x04rank\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x06 \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06absent\x18\a \x01(\x05R\x06absent\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\"\xb1\x01\n" +
+	"\x1dCreateTournamentResultRequest\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\tR\n" +
+	"tournament\x12\x18\n" +
+	"\arikishi\x18\x02 \x01(\tR\arikishi\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\tR\x04rank\x12\x12\n" +
+	"\x04wins\x18\x04 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x05 \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06absent\x18\x06 \x01(\x05R\x06absent\"|\n" +
+	"\x1fCreateTournamentsResultsRequest\x12Y\n" +
+	"\x13tournaments_results\x18\x01 \x03(\v2(.sumodb.v1.CreateTournamentResultRequestR\x12tournamentsResults\"p\n" +
+	" CreateTournamentsResultsResponse\x12L\n" +
+	"\x13tournaments_results\x18\x01 \x03(\v2\x1b.sumodb.v1.TournamentResultR\x12tournamentsResults\",\n" +
+	"\x1aGetTournamentResultRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xad\x01\n" +
+	"\x1dListTournamentsResultsRequest\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\tR\n" +
+	"tournament\x12\x18\n" +
+	"\arikishi\x18\x02 \x01(\tR\arikishi\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\tR\x04rank\x12\x12\n" +
+	"\x04wins\x18\x04 \x01(\x05R\x04wins\x12*\n" +
+	"\x04page\x18\x05 \x01(\v2\x16.sumodb.v1.PageRequestR\x04page\"\x9f\x01\n" +
+	"\x1eListTournamentsResultsResponse\x12L\n" +
+	"\x13tournaments_results\x18\x01 \x03(\v2\x1b.sumodb.v1.TournamentResultR\x12tournamentsResults\x12/\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x13.sumodb.v1.MetadataR\bmetadata\"\xbc\x02\n" +
+	"\x1dUpdateTournamentResultRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12#\n" +
+	"\n" +
+	"tournament\x18\x03 \x01(\tH\x00R\n" +
+	"tournament\x88\x01\x01\x12\x1d\n" +
+	"\arikishi\x18\x04 \x01(\tH\x01R\arikishi\x88\x01\x01\x12\x17\n" +
+	"\x04rank\x18\x05 \x01(\tH\x02R\x04rank\x88\x01\x01\x12\x17\n" +
+	"\x04wins\x18\x06 \x01(\x05H\x03R\x04wins\x88\x01\x01\x12\x1b\n" +
+	"\x06losses\x18\a \x01(\x05H\x04R\x06losses\x88\x01\x01\x12\x1b\n" +
+	"\x06absent\x18\b \x01(\x05H\x05R\x06absent\x88\x01\x01B\r\n" +
+	"\v_tournamentB\n" +
+	"\n" +
+	"\b_rikishiB\a\n" +
+	"\x05_rankB\a\n" +
+	"\x05_winsB\t\n" +
+	"\a_lossesB\t\n" +
+	"\a_absent\"I\n" +
+	"\x1dDeleteTournamentResultRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x94\x02\n" +
+	"\x04Bout\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x02 \x01(\tR\n" +
+	"tournament\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\tR\x03day\x12\x1a\n" +
+	"\bdivision\x18\x04 \x01(\tR\bdivision\x12\x16\n" +
+	"\x06winner\x18\x05 \x01(\tR\x06winner\x12\x14\n" +
+	"\x05loser\x18\x06 \x01(\tR\x05loser\x12\x1a\n" +
+	"\bkimarite\x18\a \x01(\tR\bkimarite\x12%\n" +
+	"\x0ekimarite_kanji\x18\b \x01(\tR\rkimariteKanji\x12#\n" +
+	"\rkimarite_kana\x18\t \x01(\tR\fkimariteKana\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\"\xab\x01\n" +
+	"\x11CreateBoutRequest\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\tR\n" +
+	"tournament\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\tR\x03day\x12\x1a\n" +
+	"\bdivision\x18\x03 \x01(\tR\bdivision\x12\x16\n" +
+	"\x06winner\x18\x04 \x01(\tR\x06winner\x12\x14\n" +
+	"\x05loser\x18\x05 \x01(\tR\x05loser\x12\x1a\n" +
+	"\bkimarite\x18\x06 \x01(\tR\bkimarite\"H\n" +
+	"\x12CreateBoutsRequest\x122\n" +
+	"\x05bouts\x18\x01 \x03(\v2\x1c.sumodb.v1.CreateBoutRequestR\x05bouts\"<\n" +
+	"\x13CreateBoutsResponse\x12%\n" +
+	"\x05bouts\x18\x01 \x03(\v2\x0f.sumodb.v1.BoutR\x05bouts\" \n" +
+	"\x0eGetBoutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb2\x02\n" +
+	"\x10ListBoutsRequest\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\tR\n" +
+	"tournament\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\tR\x03day\x12\x1a\n" +
+	"\bdivision\x18\x03 \x01(\tR\bdivision\x12\x1a\n" +
+	"\bkimarite\x18\x04 \x01(\tR\bkimarite\x12\x1a\n" +
+	"\brikishi1\x18\x05 \x01(\tR\brikishi1\x12\x1a\n" +
+	"\brikishi2\x18\x06 \x01(\tR\brikishi2\x12\x16\n" +
+	"\x06winner\x18\a \x01(\tR\x06winner\x12\x14\n" +
+	"\x05loser\x18\b \x01(\tR\x05loser\x12\x12\n" +
+	"\x04from\x18\t \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\n" +
+	" \x01(\tR\x02to\x12*\n" +
+	"\x04page\x18\v \x01(\v2\x16.sumodb.v1.PageRequestR\x04page\"k\n" +
+	"\x11ListBoutsResponse\x12%\n" +
+	"\x05bouts\x18\x01 \x03(\v2\x0f.sumodb.v1.BoutR\x05bouts\x12/\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x13.sumodb.v1.MetadataR\bmetadata\"\xb9\x02\n" +
+	"\x11UpdateBoutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12#\n" +
+	"\n" +
+	"tournament\x18\x03 \x01(\tH\x00R\n" +
+	"tournament\x88\x01\x01\x12\x15\n" +
+	"\x03day\x18\x04 \x01(\tH\x01R\x03day\x88\x01\x01\x12\x1f\n" +
+	"\bdivision\x18\x05 \x01(\tH\x02R\bdivision\x88\x01\x01\x12\x1b\n" +
+	"\x06winner\x18\x06 \x01(\tH\x03R\x06winner\x88\x01\x01\x12\x19\n" +
+	"\x05loser\x18\a \x01(\tH\x04R\x05loser\x88\x01\x01\x12\x1f\n" +
+	"\bkimarite\x18\b \x01(\tH\x05R\bkimarite\x88\x01\x01B\r\n" +
+	"\v_tournamentB\x06\n" +
+	"\x04_dayB\v\n" +
+	"\t_divisionB\t\n" +
+	"\a_winnerB\b\n" +
+	"\x06_loserB\v\n" +
+	"\t_kimarite\"=\n" +
+	"\x11DeleteBoutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"_\n" +
+	"\x11WatchBoutsRequest\x12\x1e\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\tR\n" +
+	"tournament\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\tR\x03day\x12\x18\n" +
+	"\arikishi\x18\x03 \x01(\tR\arikishi\"\xc3\x01\n" +
+	"\tBoutEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.sumodb.v1.BoutEvent.TypeR\x04type\x12#\n" +
+	"\x04bout\x18\x03 \x01(\v2\x0f.sumodb.v1.BoutR\x04bout\"R\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x03\"I\n" +
+	"\rSearchRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x83\x01\n" +
+	"\tSearchHit\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x18\n" +
+	"\arikishi\x18\x03 \x01(\tR\arikishi\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x1c\n" +
+	"\thighlight\x18\x05 \x01(\tR\thighlight\"@\n" +
+	"\x0eSearchResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.sumodb.v1.SearchHitR\aresults\"[\n" +
+	"\x13RegisterUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\x99\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1c\n" +
+	"\tactivated\x18\x05 \x01(\bR\tactivated2\xf2\f\n" +
+	"\x06Sumodb\x12L\n" +
+	"\vHealthcheck\x12\x1d.sumodb.v1.HealthcheckRequest\x1a\x1e.sumodb.v1.HealthcheckResponse\x12D\n" +
+	"\rCreateRikishi\x12\x1f.sumodb.v1.CreateRikishiRequest\x1a\x12.sumodb.v1.Rikishi\x12>\n" +
+	"\n" +
+	"GetRikishi\x12\x1c.sumodb.v1.GetRikishiRequest\x1a\x12.sumodb.v1.Rikishi\x12O\n" +
+	"\fListRikishis\x12\x1e.sumodb.v1.ListRikishisRequest\x1a\x1f.sumodb.v1.ListRikishisResponse\x12D\n" +
+	"\rUpdateRikishi\x12\x1f.sumodb.v1.UpdateRikishiRequest\x1a\x12.sumodb.v1.Rikishi\x12K\n" +
+	"\rDeleteRikishi\x12\x1f.sumodb.v1.DeleteRikishiRequest\x1a\x19.sumodb.v1.DeleteResponse\x12_\n" +
+	"\x16CreateTournamentResult\x12(.sumodb.v1.CreateTournamentResultRequest\x1a\x1b.sumodb.v1.TournamentResult\x12s\n" +
+	"\x18CreateTournamentsResults\x12*.sumodb.v1.CreateTournamentsResultsRequest\x1a+.sumodb.v1.CreateTournamentsResultsResponse\x12Y\n" +
+	"\x13GetTournamentResult\x12%.sumodb.v1.GetTournamentResultRequest\x1a\x1b.sumodb.v1.TournamentResult\x12m\n" +
+	"\x16ListTournamentsResults\x12(.sumodb.v1.ListTournamentsResultsRequest\x1a).sumodb.v1.ListTournamentsResultsResponse\x12_\n" +
+	"\x16UpdateTournamentResult\x12(.sumodb.v1.UpdateTournamentResultRequest\x1a\x1b.sumodb.v1.TournamentResult\x12]\n" +
+	"\x16DeleteTournamentResult\x12(.sumodb.v1.DeleteTournamentResultRequest\x1a\x19.sumodb.v1.DeleteResponse\x12;\n" +
+	"\n" +
+	"CreateBout\x12\x1c.sumodb.v1.CreateBoutRequest\x1a\x0f.sumodb.v1.Bout\x12L\n" +
+	"\vCreateBouts\x12\x1d.sumodb.v1.CreateBoutsRequest\x1a\x1e.sumodb.v1.CreateBoutsResponse\x125\n" +
+	"\aGetBout\x12\x19.sumodb.v1.GetBoutRequest\x1a\x0f.sumodb.v1.Bout\x12F\n" +
+	"\tListBouts\x12\x1b.sumodb.v1.ListBoutsRequest\x1a\x1c.sumodb.v1.ListBoutsResponse\x12;\n" +
+	"\n" +
+	"UpdateBout\x12\x1c.sumodb.v1.UpdateBoutRequest\x1a\x0f.sumodb.v1.Bout\x12E\n" +
+	"\n" +
+	"DeleteBout\x12\x1c.sumodb.v1.DeleteBoutRequest\x1a\x19.sumodb.v1.DeleteResponse\x12B\n" +
+	"\n" +
+	"WatchBouts\x12\x1c.sumodb.v1.WatchBoutsRequest\x1a\x14.sumodb.v1.BoutEvent0\x01\x12=\n" +
+	"\x06Search\x12\x18.sumodb.v1.SearchRequest\x1a\x19.sumodb.v1.SearchResponse\x12?\n" +
+	"\fRegisterUser\x12\x1e.sumodb.v1.RegisterUserRequest\x1a\x0f.sumodb.v1.UserB3Z1github.com/corsairconstantine/sumodb/pkg/sumodbpbb\x06proto3"
+
+var (
+	file_sumodb_proto_rawDescOnce sync.Once
+	file_sumodb_proto_rawDescData []byte
+)
+
+func file_sumodb_proto_rawDescGZIP() []byte {
+	file_sumodb_proto_rawDescOnce.Do(func() {
+		file_sumodb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sumodb_proto_rawDesc), len(file_sumodb_proto_rawDesc)))
+	})
+	return file_sumodb_proto_rawDescData
+}
+
+var file_sumodb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sumodb_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_sumodb_proto_goTypes = []any{
+	(BoutEvent_Type)(0),                      // 0: sumodb.v1.BoutEvent.Type
+	(*HealthcheckRequest)(nil),               // 1: sumodb.v1.HealthcheckRequest
+	(*HealthcheckResponse)(nil),              // 2: sumodb.v1.HealthcheckResponse
+	(*PageRequest)(nil),                      // 3: sumodb.v1.PageRequest
+	(*Metadata)(nil),                         // 4: sumodb.v1.Metadata
+	(*DeleteResponse)(nil),                   // 5: sumodb.v1.DeleteResponse
+	(*StringList)(nil),                       // 6: sumodb.v1.StringList
+	(*Rikishi)(nil),                          // 7: sumodb.v1.Rikishi
+	(*CreateRikishiRequest)(nil),             // 8: sumodb.v1.CreateRikishiRequest
+	(*GetRikishiRequest)(nil),                // 9: sumodb.v1.GetRikishiRequest
+	(*ListRikishisRequest)(nil),              // 10: sumodb.v1.ListRikishisRequest
+	(*ListRikishisResponse)(nil),             // 11: sumodb.v1.ListRikishisResponse
+	(*UpdateRikishiRequest)(nil),             // 12: sumodb.v1.UpdateRikishiRequest
+	(*DeleteRikishiRequest)(nil),             // 13: sumodb.v1.DeleteRikishiRequest
+	(*TournamentResult)(nil),                 // 14: sumodb.v1.TournamentResult
+	(*CreateTournamentResultRequest)(nil),    // 15: sumodb.v1.CreateTournamentResultRequest
+	(*CreateTournamentsResultsRequest)(nil),  // 16: sumodb.v1.CreateTournamentsResultsRequest
+	(*CreateTournamentsResultsResponse)(nil), // 17: sumodb.v1.CreateTournamentsResultsResponse
+	(*GetTournamentResultRequest)(nil),       // 18: sumodb.v1.GetTournamentResultRequest
+	(*ListTournamentsResultsRequest)(nil),    // 19: sumodb.v1.ListTournamentsResultsRequest
+	(*ListTournamentsResultsResponse)(nil),   // 20: sumodb.v1.ListTournamentsResultsResponse
+	(*UpdateTournamentResultRequest)(nil),    // 21: sumodb.v1.UpdateTournamentResultRequest
+	(*DeleteTournamentResultRequest)(nil),    // 22: sumodb.v1.DeleteTournamentResultRequest
+	(*Bout)(nil),                             // 23: sumodb.v1.Bout
+	(*CreateBoutRequest)(nil),                // 24: sumodb.v1.CreateBoutRequest
+	(*CreateBoutsRequest)(nil),               // 25: sumodb.v1.CreateBoutsRequest
+	(*CreateBoutsResponse)(nil),              // 26: sumodb.v1.CreateBoutsResponse
+	(*GetBoutRequest)(nil),                   // 27: sumodb.v1.GetBoutRequest
+	(*ListBoutsRequest)(nil),                 // 28: sumodb.v1.ListBoutsRequest
+	(*ListBoutsResponse)(nil),                // 29: sumodb.v1.ListBoutsResponse
+	(*UpdateBoutRequest)(nil),                // 30: sumodb.v1.UpdateBoutRequest
+	(*DeleteBoutRequest)(nil),                // 31: sumodb.v1.DeleteBoutRequest
+	(*WatchBoutsRequest)(nil),                // 32: sumodb.v1.WatchBoutsRequest
+	(*BoutEvent)(nil),                        // 33: sumodb.v1.BoutEvent
+	(*SearchRequest)(nil),                    // 34: sumodb.v1.SearchRequest
+	(*SearchHit)(nil),                        // 35: sumodb.v1.SearchHit
+	(*SearchResponse)(nil),                   // 36: sumodb.v1.SearchResponse
+	(*RegisterUserRequest)(nil),              // 37: sumodb.v1.RegisterUserRequest
+	(*User)(nil),                             // 38: sumodb.v1.User
+	(*timestamppb.Timestamp)(nil),            // 39: google.protobuf.Timestamp
+}
+var file_sumodb_proto_depIdxs = []int32{
+	3,  // 0: sumodb.v1.ListRikishisRequest.page:type_name -> sumodb.v1.PageRequest
+	7,  // 1: sumodb.v1.ListRikishisResponse.rikishis:type_name -> sumodb.v1.Rikishi
+	4,  // 2: sumodb.v1.ListRikishisResponse.metadata:type_name -> sumodb.v1.Metadata
+	6,  // 3: sumodb.v1.UpdateRikishiRequest.shikona_history:type_name -> sumodb.v1.StringList
+	15, // 4: sumodb.v1.CreateTournamentsResultsRequest.tournaments_results:type_name -> sumodb.v1.CreateTournamentResultRequest
+	14, // 5: sumodb.v1.CreateTournamentsResultsResponse.tournaments_results:type_name -> sumodb.v1.TournamentResult
+	3,  // 6: sumodb.v1.ListTournamentsResultsRequest.page:type_name -> sumodb.v1.PageRequest
+	14, // 7: sumodb.v1.ListTournamentsResultsResponse.tournaments_results:type_name -> sumodb.v1.TournamentResult
+	4,  // 8: sumodb.v1.ListTournamentsResultsResponse.metadata:type_name -> sumodb.v1.Metadata
+	24, // 9: sumodb.v1.CreateBoutsRequest.bouts:type_name -> sumodb.v1.CreateBoutRequest
+	23, // 10: sumodb.v1.CreateBoutsResponse.bouts:type_name -> sumodb.v1.Bout
+	3,  // 11: sumodb.v1.ListBoutsRequest.page:type_name -> sumodb.v1.PageRequest
+	23, // 12: sumodb.v1.ListBoutsResponse.bouts:type_name -> sumodb.v1.Bout
+	4,  // 13: sumodb.v1.ListBoutsResponse.metadata:type_name -> sumodb.v1.Metadata
+	0,  // 14: sumodb.v1.BoutEvent.type:type_name -> sumodb.v1.BoutEvent.Type
+	23, // 15: sumodb.v1.BoutEvent.bout:type_name -> sumodb.v1.Bout
+	35, // 16: sumodb.v1.SearchResponse.results:type_name -> sumodb.v1.SearchHit
+	39, // 17: sumodb.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 18: sumodb.v1.Sumodb.Healthcheck:input_type -> sumodb.v1.HealthcheckRequest
+	8,  // 19: sumodb.v1.Sumodb.CreateRikishi:input_type -> sumodb.v1.CreateRikishiRequest
+	9,  // 20: sumodb.v1.Sumodb.GetRikishi:input_type -> sumodb.v1.GetRikishiRequest
+	10, // 21: sumodb.v1.Sumodb.ListRikishis:input_type -> sumodb.v1.ListRikishisRequest
+	12, // 22: sumodb.v1.Sumodb.UpdateRikishi:input_type -> sumodb.v1.UpdateRikishiRequest
+	13, // 23: sumodb.v1.Sumodb.DeleteRikishi:input_type -> sumodb.v1.DeleteRikishiRequest
+	15, // 24: sumodb.v1.Sumodb.CreateTournamentResult:input_type -> sumodb.v1.CreateTournamentResultRequest
+	16, // 25: sumodb.v1.Sumodb.CreateTournamentsResults:input_type -> sumodb.v1.CreateTournamentsResultsRequest
+	18, // 26: sumodb.v1.Sumodb.GetTournamentResult:input_type -> sumodb.v1.GetTournamentResultRequest
+	19, // 27: sumodb.v1.Sumodb.ListTournamentsResults:input_type -> sumodb.v1.ListTournamentsResultsRequest
+	21, // 28: sumodb.v1.Sumodb.UpdateTournamentResult:input_type -> sumodb.v1.UpdateTournamentResultRequest
+	22, // 29: sumodb.v1.Sumodb.DeleteTournamentResult:input_type -> sumodb.v1.DeleteTournamentResultRequest
+	24, // 30: sumodb.v1.Sumodb.CreateBout:input_type -> sumodb.v1.CreateBoutRequest
+	25, // 31: sumodb.v1.Sumodb.CreateBouts:input_type -> sumodb.v1.CreateBoutsRequest
+	27, // 32: sumodb.v1.Sumodb.GetBout:input_type -> sumodb.v1.GetBoutRequest
+	28, // 33: sumodb.v1.Sumodb.ListBouts:input_type -> sumodb.v1.ListBoutsRequest
+	30, // 34: sumodb.v1.Sumodb.UpdateBout:input_type -> sumodb.v1.UpdateBoutRequest
+	31, // 35: sumodb.v1.Sumodb.DeleteBout:input_type -> sumodb.v1.DeleteBoutRequest
+	32, // 36: sumodb.v1.Sumodb.WatchBouts:input_type -> sumodb.v1.WatchBoutsRequest
+	34, // 37: sumodb.v1.Sumodb.Search:input_type -> sumodb.v1.SearchRequest
+	37, // 38: sumodb.v1.Sumodb.RegisterUser:input_type -> sumodb.v1.RegisterUserRequest
+	2,  // 39: sumodb.v1.Sumodb.Healthcheck:output_type -> sumodb.v1.HealthcheckResponse
+	7,  // 40: sumodb.v1.Sumodb.CreateRikishi:output_type -> sumodb.v1.Rikishi
+	7,  // 41: sumodb.v1.Sumodb.GetRikishi:output_type -> sumodb.v1.Rikishi
+	11, // 42: sumodb.v1.Sumodb.ListRikishis:output_type -> sumodb.v1.ListRikishisResponse
+	7,  // 43: sumodb.v1.Sumodb.UpdateRikishi:output_type -> sumodb.v1.Rikishi
+	5,  // 44: sumodb.v1.Sumodb.DeleteRikishi:output_type -> sumodb.v1.DeleteResponse
+	14, // 45: sumodb.v1.Sumodb.CreateTournamentResult:output_type -> sumodb.v1.TournamentResult
+	17, // 46: sumodb.v1.Sumodb.CreateTournamentsResults:output_type -> sumodb.v1.CreateTournamentsResultsResponse
+	14, // 47: sumodb.v1.Sumodb.GetTournamentResult:output_type -> sumodb.v1.TournamentResult
+	20, // 48: sumodb.v1.Sumodb.ListTournamentsResults:output_type -> sumodb.v1.ListTournamentsResultsResponse
+	14, // 49: sumodb.v1.Sumodb.UpdateTournamentResult:output_type -> sumodb.v1.TournamentResult
+	5,  // 50: sumodb.v1.Sumodb.DeleteTournamentResult:output_type -> sumodb.v1.DeleteResponse
+	23, // 51: sumodb.v1.Sumodb.CreateBout:output_type -> sumodb.v1.Bout
+	26, // 52: sumodb.v1.Sumodb.CreateBouts:output_type -> sumodb.v1.CreateBoutsResponse
+	23, // 53: sumodb.v1.Sumodb.GetBout:output_type -> sumodb.v1.Bout
+	29, // 54: sumodb.v1.Sumodb.ListBouts:output_type -> sumodb.v1.ListBoutsResponse
+	23, // 55: sumodb.v1.Sumodb.UpdateBout:output_type -> sumodb.v1.Bout
+	5,  // 56: sumodb.v1.Sumodb.DeleteBout:output_type -> sumodb.v1.DeleteResponse
+	33, // 57: sumodb.v1.Sumodb.WatchBouts:output_type -> sumodb.v1.BoutEvent
+	36, // 58: sumodb.v1.Sumodb.Search:output_type -> sumodb.v1.SearchResponse
+	38, // 59: sumodb.v1.Sumodb.RegisterUser:output_type -> sumodb.v1.User
+	39, // [39:60] is the sub-list for method output_type
+	18, // [18:39] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_sumodb_proto_init() }
+func file_sumodb_proto_init() {
+	if File_sumodb_proto != nil {
+		return
+	}
+	file_sumodb_proto_msgTypes[11].OneofWrappers = []any{}
+	file_sumodb_proto_msgTypes[20].OneofWrappers = []any{}
+	file_sumodb_proto_msgTypes[29].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sumodb_proto_rawDesc), len(file_sumodb_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   38,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sumodb_proto_goTypes,
+		DependencyIndexes: file_sumodb_proto_depIdxs,
+		EnumInfos:         file_sumodb_proto_enumTypes,
+		MessageInfos:      file_sumodb_proto_msgTypes,
+	}.Build()
+	File_sumodb_proto = out.File
+	file_sumodb_proto_goTypes = nil
+	file_sumodb_proto_depIdxs = nil
+}
@@ -0,0 +1,893 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: sumodb.proto
+
+package sumodbpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Sumodb_Healthcheck_FullMethodName              = "/sumodb.v1.Sumodb/Healthcheck"
+	Sumodb_CreateRikishi_FullMethodName            = "/sumodb.v1.Sumodb/CreateRikishi"
+	Sumodb_GetRikishi_FullMethodName               = "/sumodb.v1.Sumodb/GetRikishi"
+	Sumodb_ListRikishis_FullMethodName             = "/sumodb.v1.Sumodb/ListRikishis"
+	Sumodb_UpdateRikishi_FullMethodName            = "/sumodb.v1.Sumodb/UpdateRikishi"
+	Sumodb_DeleteRikishi_FullMethodName            = "/sumodb.v1.Sumodb/DeleteRikishi"
+	Sumodb_CreateTournamentResult_FullMethodName   = "/sumodb.v1.Sumodb/CreateTournamentResult"
+	Sumodb_CreateTournamentsResults_FullMethodName = "/sumodb.v1.Sumodb/CreateTournamentsResults"
+	Sumodb_GetTournamentResult_FullMethodName      = "/sumodb.v1.Sumodb/GetTournamentResult"
+	Sumodb_ListTournamentsResults_FullMethodName   = "/sumodb.v1.Sumodb/ListTournamentsResults"
+	Sumodb_UpdateTournamentResult_FullMethodName   = "/sumodb.v1.Sumodb/UpdateTournamentResult"
+	Sumodb_DeleteTournamentResult_FullMethodName   = "/sumodb.v1.Sumodb/DeleteTournamentResult"
+	Sumodb_CreateBout_FullMethodName               = "/sumodb.v1.Sumodb/CreateBout"
+	Sumodb_CreateBouts_FullMethodName              = "/sumodb.v1.Sumodb/CreateBouts"
+	Sumodb_GetBout_FullMethodName                  = "/sumodb.v1.Sumodb/GetBout"
+	Sumodb_ListBouts_FullMethodName                = "/sumodb.v1.Sumodb/ListBouts"
+	Sumodb_UpdateBout_FullMethodName               = "/sumodb.v1.Sumodb/UpdateBout"
+	Sumodb_DeleteBout_FullMethodName               = "/sumodb.v1.Sumodb/DeleteBout"
+	Sumodb_WatchBouts_FullMethodName               = "/sumodb.v1.Sumodb/WatchBouts"
+	Sumodb_Search_FullMethodName                   = "/sumodb.v1.Sumodb/Search"
+	Sumodb_RegisterUser_FullMethodName             = "/sumodb.v1.Sumodb/RegisterUser"
+)
+
+// SumodbClient is the client API for Sumodb service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Sumodb mirrors the JSON API. Calls that change or list records follow the
+// same validation and API key rules as their REST counterparts.
+type SumodbClient interface {
+	Healthcheck(ctx context.Context, in *HealthcheckRequest, opts ...grpc.CallOption) (*HealthcheckResponse, error)
+	CreateRikishi(ctx context.Context, in *CreateRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error)
+	GetRikishi(ctx context.Context, in *GetRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error)
+	ListRikishis(ctx context.Context, in *ListRikishisRequest, opts ...grpc.CallOption) (*ListRikishisResponse, error)
+	UpdateRikishi(ctx context.Context, in *UpdateRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error)
+	DeleteRikishi(ctx context.Context, in *DeleteRikishiRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	CreateTournamentResult(ctx context.Context, in *CreateTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error)
+	CreateTournamentsResults(ctx context.Context, in *CreateTournamentsResultsRequest, opts ...grpc.CallOption) (*CreateTournamentsResultsResponse, error)
+	GetTournamentResult(ctx context.Context, in *GetTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error)
+	ListTournamentsResults(ctx context.Context, in *ListTournamentsResultsRequest, opts ...grpc.CallOption) (*ListTournamentsResultsResponse, error)
+	UpdateTournamentResult(ctx context.Context, in *UpdateTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error)
+	DeleteTournamentResult(ctx context.Context, in *DeleteTournamentResultRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	CreateBout(ctx context.Context, in *CreateBoutRequest, opts ...grpc.CallOption) (*Bout, error)
+	CreateBouts(ctx context.Context, in *CreateBoutsRequest, opts ...grpc.CallOption) (*CreateBoutsResponse, error)
+	GetBout(ctx context.Context, in *GetBoutRequest, opts ...grpc.CallOption) (*Bout, error)
+	ListBouts(ctx context.Context, in *ListBoutsRequest, opts ...grpc.CallOption) (*ListBoutsResponse, error)
+	UpdateBout(ctx context.Context, in *UpdateBoutRequest, opts ...grpc.CallOption) (*Bout, error)
+	DeleteBout(ctx context.Context, in *DeleteBoutRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// WatchBouts streams bouts as they are created, updated or deleted.
+	WatchBouts(ctx context.Context, in *WatchBoutsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoutEvent], error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*User, error)
+}
+
+type sumodbClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSumodbClient(cc grpc.ClientConnInterface) SumodbClient {
+	return &sumodbClient{cc}
+}
+
+func (c *sumodbClient) Healthcheck(ctx context.Context, in *HealthcheckRequest, opts ...grpc.CallOption) (*HealthcheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthcheckResponse)
+	err := c.cc.Invoke(ctx, Sumodb_Healthcheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) CreateRikishi(ctx context.Context, in *CreateRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rikishi)
+	err := c.cc.Invoke(ctx, Sumodb_CreateRikishi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) GetRikishi(ctx context.Context, in *GetRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rikishi)
+	err := c.cc.Invoke(ctx, Sumodb_GetRikishi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) ListRikishis(ctx context.Context, in *ListRikishisRequest, opts ...grpc.CallOption) (*ListRikishisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRikishisResponse)
+	err := c.cc.Invoke(ctx, Sumodb_ListRikishis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) UpdateRikishi(ctx context.Context, in *UpdateRikishiRequest, opts ...grpc.CallOption) (*Rikishi, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rikishi)
+	err := c.cc.Invoke(ctx, Sumodb_UpdateRikishi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) DeleteRikishi(ctx context.Context, in *DeleteRikishiRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, Sumodb_DeleteRikishi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) CreateTournamentResult(ctx context.Context, in *CreateTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentResult)
+	err := c.cc.Invoke(ctx, Sumodb_CreateTournamentResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) CreateTournamentsResults(ctx context.Context, in *CreateTournamentsResultsRequest, opts ...grpc.CallOption) (*CreateTournamentsResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTournamentsResultsResponse)
+	err := c.cc.Invoke(ctx, Sumodb_CreateTournamentsResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) GetTournamentResult(ctx context.Context, in *GetTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentResult)
+	err := c.cc.Invoke(ctx, Sumodb_GetTournamentResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) ListTournamentsResults(ctx context.Context, in *ListTournamentsResultsRequest, opts ...grpc.CallOption) (*ListTournamentsResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTournamentsResultsResponse)
+	err := c.cc.Invoke(ctx, Sumodb_ListTournamentsResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) UpdateTournamentResult(ctx context.Context, in *UpdateTournamentResultRequest, opts ...grpc.CallOption) (*TournamentResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentResult)
+	err := c.cc.Invoke(ctx, Sumodb_UpdateTournamentResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) DeleteTournamentResult(ctx context.Context, in *DeleteTournamentResultRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, Sumodb_DeleteTournamentResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) CreateBout(ctx context.Context, in *CreateBoutRequest, opts ...grpc.CallOption) (*Bout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bout)
+	err := c.cc.Invoke(ctx, Sumodb_CreateBout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) CreateBouts(ctx context.Context, in *CreateBoutsRequest, opts ...grpc.CallOption) (*CreateBoutsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBoutsResponse)
+	err := c.cc.Invoke(ctx, Sumodb_CreateBouts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) GetBout(ctx context.Context, in *GetBoutRequest, opts ...grpc.CallOption) (*Bout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bout)
+	err := c.cc.Invoke(ctx, Sumodb_GetBout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) ListBouts(ctx context.Context, in *ListBoutsRequest, opts ...grpc.CallOption) (*ListBoutsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBoutsResponse)
+	err := c.cc.Invoke(ctx, Sumodb_ListBouts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) UpdateBout(ctx context.Context, in *UpdateBoutRequest, opts ...grpc.CallOption) (*Bout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bout)
+	err := c.cc.Invoke(ctx, Sumodb_UpdateBout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) DeleteBout(ctx context.Context, in *DeleteBoutRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, Sumodb_DeleteBout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) WatchBouts(ctx context.Context, in *WatchBoutsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoutEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Sumodb_ServiceDesc.Streams[0], Sumodb_WatchBouts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBoutsRequest, BoutEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sumodb_WatchBoutsClient = grpc.ServerStreamingClient[BoutEvent]
+
+func (c *sumodbClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Sumodb_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sumodbClient) RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Sumodb_RegisterUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SumodbServer is the server API for Sumodb service.
+// All implementations must embed UnimplementedSumodbServer
+// for forward compatibility.
+//
+// Sumodb mirrors the JSON API. Calls that change or list records follow the
+// same validation and API key rules as their REST counterparts.
+type SumodbServer interface {
+	Healthcheck(context.Context, *HealthcheckRequest) (*HealthcheckResponse, error)
+	CreateRikishi(context.Context, *CreateRikishiRequest) (*Rikishi, error)
+	GetRikishi(context.Context, *GetRikishiRequest) (*Rikishi, error)
+	ListRikishis(context.Context, *ListRikishisRequest) (*ListRikishisResponse, error)
+	UpdateRikishi(context.Context, *UpdateRikishiRequest) (*Rikishi, error)
+	DeleteRikishi(context.Context, *DeleteRikishiRequest) (*DeleteResponse, error)
+	CreateTournamentResult(context.Context, *CreateTournamentResultRequest) (*TournamentResult, error)
+	CreateTournamentsResults(context.Context, *CreateTournamentsResultsRequest) (*CreateTournamentsResultsResponse, error)
+	GetTournamentResult(context.Context, *GetTournamentResultRequest) (*TournamentResult, error)
+	ListTournamentsResults(context.Context, *ListTournamentsResultsRequest) (*ListTournamentsResultsResponse, error)
+	UpdateTournamentResult(context.Context, *UpdateTournamentResultRequest) (*TournamentResult, error)
+	DeleteTournamentResult(context.Context, *DeleteTournamentResultRequest) (*DeleteResponse, error)
+	CreateBout(context.Context, *CreateBoutRequest) (*Bout, error)
+	CreateBouts(context.Context, *CreateBoutsRequest) (*CreateBoutsResponse, error)
+	GetBout(context.Context, *GetBoutRequest) (*Bout, error)
+	ListBouts(context.Context, *ListBoutsRequest) (*ListBoutsResponse, error)
+	UpdateBout(context.Context, *UpdateBoutRequest) (*Bout, error)
+	DeleteBout(context.Context, *DeleteBoutRequest) (*DeleteResponse, error)
+	// WatchBouts streams bouts as they are created, updated or deleted.
+	WatchBouts(*WatchBoutsRequest, grpc.ServerStreamingServer[BoutEvent]) error
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	RegisterUser(context.Context, *RegisterUserRequest) (*User, error)
+	mustEmbedUnimplementedSumodbServer()
+}
+
+// UnimplementedSumodbServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSumodbServer struct{}
+
+func (UnimplementedSumodbServer) Healthcheck(context.Context, *HealthcheckRequest) (*HealthcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Healthcheck not implemented")
+}
+func (UnimplementedSumodbServer) CreateRikishi(context.Context, *CreateRikishiRequest) (*Rikishi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRikishi not implemented")
+}
+func (UnimplementedSumodbServer) GetRikishi(context.Context, *GetRikishiRequest) (*Rikishi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRikishi not implemented")
+}
+func (UnimplementedSumodbServer) ListRikishis(context.Context, *ListRikishisRequest) (*ListRikishisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRikishis not implemented")
+}
+func (UnimplementedSumodbServer) UpdateRikishi(context.Context, *UpdateRikishiRequest) (*Rikishi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRikishi not implemented")
+}
+func (UnimplementedSumodbServer) DeleteRikishi(context.Context, *DeleteRikishiRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRikishi not implemented")
+}
+func (UnimplementedSumodbServer) CreateTournamentResult(context.Context, *CreateTournamentResultRequest) (*TournamentResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournamentResult not implemented")
+}
+func (UnimplementedSumodbServer) CreateTournamentsResults(context.Context, *CreateTournamentsResultsRequest) (*CreateTournamentsResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournamentsResults not implemented")
+}
+func (UnimplementedSumodbServer) GetTournamentResult(context.Context, *GetTournamentResultRequest) (*TournamentResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTournamentResult not implemented")
+}
+func (UnimplementedSumodbServer) ListTournamentsResults(context.Context, *ListTournamentsResultsRequest) (*ListTournamentsResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTournamentsResults not implemented")
+}
+func (UnimplementedSumodbServer) UpdateTournamentResult(context.Context, *UpdateTournamentResultRequest) (*TournamentResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTournamentResult not implemented")
+}
+func (UnimplementedSumodbServer) DeleteTournamentResult(context.Context, *DeleteTournamentResultRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTournamentResult not implemented")
+}
+func (UnimplementedSumodbServer) CreateBout(context.Context, *CreateBoutRequest) (*Bout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBout not implemented")
+}
+func (UnimplementedSumodbServer) CreateBouts(context.Context, *CreateBoutsRequest) (*CreateBoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBouts not implemented")
+}
+func (UnimplementedSumodbServer) GetBout(context.Context, *GetBoutRequest) (*Bout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBout not implemented")
+}
+func (UnimplementedSumodbServer) ListBouts(context.Context, *ListBoutsRequest) (*ListBoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBouts not implemented")
+}
+func (UnimplementedSumodbServer) UpdateBout(context.Context, *UpdateBoutRequest) (*Bout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBout not implemented")
+}
+func (UnimplementedSumodbServer) DeleteBout(context.Context, *DeleteBoutRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBout not implemented")
+}
+func (UnimplementedSumodbServer) WatchBouts(*WatchBoutsRequest, grpc.ServerStreamingServer[BoutEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBouts not implemented")
+}
+func (UnimplementedSumodbServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedSumodbServer) RegisterUser(context.Context, *RegisterUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedSumodbServer) mustEmbedUnimplementedSumodbServer() {}
+func (UnimplementedSumodbServer) testEmbeddedByValue()                {}
+
+// UnsafeSumodbServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SumodbServer will
+// result in compilation errors.
+type UnsafeSumodbServer interface {
+	mustEmbedUnimplementedSumodbServer()
+}
+
+func RegisterSumodbServer(s grpc.ServiceRegistrar, srv SumodbServer) {
+	// If the following call pancis, it indicates UnimplementedSumodbServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Sumodb_ServiceDesc, srv)
+}
+
+func _Sumodb_Healthcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).Healthcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_Healthcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).Healthcheck(ctx, req.(*HealthcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_CreateRikishi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRikishiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).CreateRikishi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_CreateRikishi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).CreateRikishi(ctx, req.(*CreateRikishiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_GetRikishi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRikishiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).GetRikishi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_GetRikishi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).GetRikishi(ctx, req.(*GetRikishiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_ListRikishis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRikishisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).ListRikishis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_ListRikishis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).ListRikishis(ctx, req.(*ListRikishisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_UpdateRikishi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRikishiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).UpdateRikishi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_UpdateRikishi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).UpdateRikishi(ctx, req.(*UpdateRikishiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_DeleteRikishi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRikishiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).DeleteRikishi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_DeleteRikishi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).DeleteRikishi(ctx, req.(*DeleteRikishiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_CreateTournamentResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).CreateTournamentResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_CreateTournamentResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).CreateTournamentResult(ctx, req.(*CreateTournamentResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_CreateTournamentsResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentsResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).CreateTournamentsResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_CreateTournamentsResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).CreateTournamentsResults(ctx, req.(*CreateTournamentsResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_GetTournamentResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTournamentResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).GetTournamentResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_GetTournamentResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).GetTournamentResult(ctx, req.(*GetTournamentResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_ListTournamentsResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTournamentsResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).ListTournamentsResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_ListTournamentsResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).ListTournamentsResults(ctx, req.(*ListTournamentsResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_UpdateTournamentResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTournamentResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).UpdateTournamentResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_UpdateTournamentResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).UpdateTournamentResult(ctx, req.(*UpdateTournamentResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_DeleteTournamentResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTournamentResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).DeleteTournamentResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_DeleteTournamentResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).DeleteTournamentResult(ctx, req.(*DeleteTournamentResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_CreateBout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).CreateBout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_CreateBout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).CreateBout(ctx, req.(*CreateBoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_CreateBouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).CreateBouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_CreateBouts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).CreateBouts(ctx, req.(*CreateBoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_GetBout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).GetBout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_GetBout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).GetBout(ctx, req.(*GetBoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_ListBouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).ListBouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_ListBouts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).ListBouts(ctx, req.(*ListBoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_UpdateBout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).UpdateBout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_UpdateBout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).UpdateBout(ctx, req.(*UpdateBoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_DeleteBout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).DeleteBout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_DeleteBout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).DeleteBout(ctx, req.(*DeleteBoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_WatchBouts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBoutsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SumodbServer).WatchBouts(m, &grpc.GenericServerStream[WatchBoutsRequest, BoutEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sumodb_WatchBoutsServer = grpc.ServerStreamingServer[BoutEvent]
+
+func _Sumodb_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sumodb_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SumodbServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sumodb_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SumodbServer).RegisterUser(ctx, req.(*RegisterUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sumodb_ServiceDesc is the grpc.ServiceDesc for Sumodb service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sumodb_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sumodb.v1.Sumodb",
+	HandlerType: (*SumodbServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Healthcheck",
+			Handler:    _Sumodb_Healthcheck_Handler,
+		},
+		{
+			MethodName: "CreateRikishi",
+			Handler:    _Sumodb_CreateRikishi_Handler,
+		},
+		{
+			MethodName: "GetRikishi",
+			Handler:    _Sumodb_GetRikishi_Handler,
+		},
+		{
+			MethodName: "ListRikishis",
+			Handler:    _Sumodb_ListRikishis_Handler,
+		},
+		{
+			MethodName: "UpdateRikishi",
+			Handler:    _Sumodb_UpdateRikishi_Handler,
+		},
+		{
+			MethodName: "DeleteRikishi",
+			Handler:    _Sumodb_DeleteRikishi_Handler,
+		},
+		{
+			MethodName: "CreateTournamentResult",
+			Handler:    _Sumodb_CreateTournamentResult_Handler,
+		},
+		{
+			MethodName: "CreateTournamentsResults",
+			Handler:    _Sumodb_CreateTournamentsResults_Handler,
+		},
+		{
+			MethodName: "GetTournamentResult",
+			Handler:    _Sumodb_GetTournamentResult_Handler,
+		},
+		{
+			MethodName: "ListTournamentsResults",
+			Handler:    _Sumodb_ListTournamentsResults_Handler,
+		},
+		{
+			MethodName: "UpdateTournamentResult",
+			Handler:    _Sumodb_UpdateTournamentResult_Handler,
+		},
+		{
+			MethodName: "DeleteTournamentResult",
+			Handler:    _Sumodb_DeleteTournamentResult_Handler,
+		},
+		{
+			MethodName: "CreateBout",
+			Handler:    _Sumodb_CreateBout_Handler,
+		},
+		{
+			MethodName: "CreateBouts",
+			Handler:    _Sumodb_CreateBouts_Handler,
+		},
+		{
+			MethodName: "GetBout",
+			Handler:    _Sumodb_GetBout_Handler,
+		},
+		{
+			MethodName: "ListBouts",
+			Handler:    _Sumodb_ListBouts_Handler,
+		},
+		{
+			MethodName: "UpdateBout",
+			Handler:    _Sumodb_UpdateBout_Handler,
+		},
+		{
+			MethodName: "DeleteBout",
+			Handler:    _Sumodb_DeleteBout_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Sumodb_Search_Handler,
+		},
+		{
+			MethodName: "RegisterUser",
+			Handler:    _Sumodb_RegisterUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBouts",
+			Handler:       _Sumodb_WatchBouts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sumodb.proto",
+}